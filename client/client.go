@@ -7,21 +7,36 @@ import (
 	"os"
 
 	"golang.org/x/oauth2"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/version"
 )
 
-func newClient(ctx context.Context, serviceAccountFilePath string) (*http.Client, error) {
+func newClient(ctx context.Context, serviceAccountFilePath string) (*http.Client, oauth2.TokenSource, error) {
 	_, err := os.Stat(serviceAccountFilePath)
 	if os.IsNotExist(err) {
-		return nil, fmt.Errorf("service account file %s doesn't exist: %v", serviceAccountFilePath, err)
+		return nil, nil, fmt.Errorf("service account file %s doesn't exist: %v", serviceAccountFilePath, err)
 	}
 
 	if os.IsPermission(err) {
-		return nil, fmt.Errorf("service account file %s cannot be read, because of permission problems: %v", serviceAccountFilePath, err)
+		return nil, nil, fmt.Errorf("service account file %s cannot be read, because of permission problems: %v", serviceAccountFilePath, err)
+	}
+
+	baseClient := &http.Client{
+		Transport: NewTracingTransport(NewUserAgentTransport(http.DefaultTransport, version.AppVersion.UserAgent())),
 	}
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, baseClient)
+
+	ts := oauth2.ReuseTokenSource(nil, NewTracingTokenSource(NewGCPServiceAccountTokenSource(serviceAccountFilePath)))
 
-	return oauth2.NewClient(ctx, NewGCPServiceAccountTokenSource(serviceAccountFilePath)), nil
+	return oauth2.NewClient(ctx, ts), ts, nil
 }
 
 func New(serviceAccountFilePath string) (*http.Client, error) {
+	client, _, err := newClient(context.Background(), serviceAccountFilePath)
+
+	return client, err
+}
+
+func NewWithTokenSource(serviceAccountFilePath string) (*http.Client, oauth2.TokenSource, error) {
 	return newClient(context.Background(), serviceAccountFilePath)
 }
@@ -21,3 +21,14 @@ func TestNew_NoFile(t *testing.T) {
 	_, err := New("non-existing-file")
 	assert.Error(t, err, "service account file non-existing-file doesn't exist: stat non-existing-file: no such file or directory")
 }
+
+func TestNewWithTokenSource(t *testing.T) {
+	tests.RunOnTempDir(t, "client-test-new-with-token-source", func(t *testing.T, dir string) {
+		tests.RunWithTempFile(t, dir, "service-account.json", func(t *testing.T, file string) {
+			c, ts, err := NewWithTokenSource(file)
+			assert.NoError(t, err)
+			assert.NotNil(t, c)
+			assert.NotNil(t, ts)
+		})
+	})
+}
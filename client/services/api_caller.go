@@ -0,0 +1,354 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/time/rate"
+	"google.golang.org/api/googleapi"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	DefaultMaxRetries       = 5
+	DefaultInitialBackoffMs = 500
+	DefaultMaxBackoffMs     = 30000
+	DefaultRateLimit        = 10
+	DefaultRateBurst        = 20
+)
+
+var (
+	apiRequestRetries = prometheus.NewDesc(
+		"gcp_exporter_api_request_retries_total",
+		"Total number of GCP API requests that were retried",
+		[]string{"project", "reason"},
+		nil,
+	)
+
+	apiRequestThrottles = prometheus.NewDesc(
+		"gcp_exporter_api_request_throttled_total",
+		"Total number of GCP API requests delayed by the client side rate limiter",
+		[]string{"project"},
+		nil,
+	)
+
+	DefaultAPICallPolicy = &APICallPolicy{
+		MaxRetries:       DefaultMaxRetries,
+		InitialBackoffMs: DefaultInitialBackoffMs,
+		MaxBackoffMs:     DefaultMaxBackoffMs,
+		RateLimit:        DefaultRateLimit,
+		RateBurst:        DefaultRateBurst,
+	}
+
+	DefaultAPICaller = NewAPICaller(DefaultAPICallPolicy)
+)
+
+type APICallPolicy struct {
+	MaxRetries       int `long:"api-max-retries" env:"GCP_EXPORTER_API_MAX_RETRIES" description:"Maximum number of retries for a failed GCP API request"`
+	InitialBackoffMs int `long:"api-initial-backoff" env:"GCP_EXPORTER_API_INITIAL_BACKOFF" description:"Initial backoff (in milliseconds) between GCP API request retries"`
+	MaxBackoffMs     int `long:"api-max-backoff" env:"GCP_EXPORTER_API_MAX_BACKOFF" description:"Maximum backoff (in milliseconds) between GCP API request retries"`
+	RateLimit        int `long:"api-rate-limit" env:"GCP_EXPORTER_API_RATE_LIMIT" description:"Maximum number of GCP API requests per second, per project; 0 disables the limit"`
+	RateBurst        int `long:"api-rate-burst" env:"GCP_EXPORTER_API_RATE_BURST" description:"Number of GCP API requests per project that may exceed the rate limit in a burst"`
+}
+
+func (p *APICallPolicy) backoff(attempt int) time.Duration {
+	initial := time.Duration(p.InitialBackoffMs) * time.Millisecond
+	max := time.Duration(p.MaxBackoffMs) * time.Millisecond
+
+	backoff := time.Duration(float64(initial) * math.Pow(2, float64(attempt)))
+	if backoff > max || backoff <= 0 {
+		backoff = max
+	}
+
+	return backoff
+}
+
+type APICallerInterface interface {
+	Call(ctx context.Context, project string, call func() error) error
+}
+
+type retryPermutation struct {
+	Project string
+	Reason  string
+}
+
+type APICaller struct {
+	policy *APICallPolicy
+
+	limiters     map[string]*rate.Limiter
+	limitersLock sync.Mutex
+
+	retries      map[retryPermutation]uint64
+	throttles    map[string]uint64
+	countersLock sync.RWMutex
+
+	sleep  func(ctx context.Context, d time.Duration) error
+	jitter func(d time.Duration) time.Duration
+}
+
+func (ac *APICaller) Call(ctx context.Context, project string, call func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := ac.wait(ctx, project)
+		if err != nil {
+			return err
+		}
+
+		err = call()
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+
+		retryable, reason, retryAfter := classifyError(err)
+		if !retryable || attempt >= ac.policy.MaxRetries {
+			return err
+		}
+
+		// Waiting longer than the maximum backoff would stall the whole
+		// data refresh, so the request fails instead
+		if retryAfter > time.Duration(ac.policy.MaxBackoffMs)*time.Millisecond {
+			return err
+		}
+
+		delay := ac.jitter(ac.policy.backoff(attempt))
+		if retryAfter > delay {
+			delay = retryAfter
+		}
+
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"project": project,
+			"reason":  reason,
+			"attempt": attempt + 1,
+			"delay":   delay,
+		}).Warningln("Retrying GCP API request")
+
+		ac.countRetry(project, reason)
+
+		span := trace.SpanFromContext(ctx)
+		span.SetAttributes(attribute.Int("gcp.api.retries", attempt+1))
+		span.AddEvent("retry", trace.WithAttributes(
+			attribute.String("reason", reason),
+			attribute.Int("attempt", attempt+1),
+			attribute.String("delay", delay.String()),
+		))
+
+		err = ac.sleep(ctx, delay)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (ac *APICaller) wait(ctx context.Context, project string) error {
+	limiter := ac.limiter(project)
+	if limiter == nil {
+		return nil
+	}
+
+	reservation := limiter.Reserve()
+	if !reservation.OK() {
+		return fmt.Errorf("rate limiter burst for project %s is too small", project)
+	}
+
+	delay := reservation.Delay()
+	if delay <= 0 {
+		return nil
+	}
+
+	ac.countThrottle(project)
+	trace.SpanFromContext(ctx).AddEvent("throttled", trace.WithAttributes(attribute.String("delay", delay.String())))
+
+	err := ac.sleep(ctx, delay)
+	if err != nil {
+		reservation.Cancel()
+		return err
+	}
+
+	return nil
+}
+
+func (ac *APICaller) limiter(project string) *rate.Limiter {
+	if ac.policy.RateLimit <= 0 {
+		return nil
+	}
+
+	ac.limitersLock.Lock()
+	defer ac.limitersLock.Unlock()
+
+	limiter, ok := ac.limiters[project]
+	if !ok {
+		burst := ac.policy.RateBurst
+		if burst < 1 {
+			burst = 1
+		}
+
+		limiter = rate.NewLimiter(rate.Limit(ac.policy.RateLimit), burst)
+		ac.limiters[project] = limiter
+	}
+
+	return limiter
+}
+
+func (ac *APICaller) countRetry(project string, reason string) {
+	ac.countersLock.Lock()
+	defer ac.countersLock.Unlock()
+
+	ac.retries[retryPermutation{Project: project, Reason: reason}]++
+}
+
+func (ac *APICaller) countThrottle(project string) {
+	ac.countersLock.Lock()
+	defer ac.countersLock.Unlock()
+
+	ac.throttles[project]++
+}
+
+func (ac *APICaller) Describe(ch chan<- *prometheus.Desc) {
+	ch <- apiRequestRetries
+	ch <- apiRequestThrottles
+}
+
+func (ac *APICaller) Collect(ch chan<- prometheus.Metric) {
+	ac.countersLock.RLock()
+	defer ac.countersLock.RUnlock()
+
+	for permutation, count := range ac.retries {
+		ch <- prometheus.MustNewConstMetric(
+			apiRequestRetries,
+			prometheus.CounterValue,
+			float64(count),
+			permutation.Project,
+			permutation.Reason,
+		)
+	}
+
+	for project, count := range ac.throttles {
+		ch <- prometheus.MustNewConstMetric(
+			apiRequestThrottles,
+			prometheus.CounterValue,
+			float64(count),
+			project,
+		)
+	}
+}
+
+func classifyError(err error) (bool, string, time.Duration) {
+	if e, ok := err.(*url.Error); ok {
+		err = e.Err
+	}
+
+	switch e := err.(type) {
+	case *googleapi.Error:
+		retryAfter := parseRetryAfter(e.Header)
+
+		switch e.Code {
+		case http.StatusTooManyRequests:
+			return true, "rate_limited", retryAfter
+		case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true, "server_error", retryAfter
+		case http.StatusForbidden:
+			for _, item := range e.Errors {
+				if item.Reason == "rateLimitExceeded" || item.Reason == "userRateLimitExceeded" {
+					return true, "rate_limited", retryAfter
+				}
+			}
+		}
+
+		return false, "", 0
+	case *oauth2.RetrieveError:
+		return false, "", 0
+	case net.Error:
+		if e.Timeout() {
+			return true, "timeout", 0
+		}
+	}
+
+	if err == io.ErrUnexpectedEOF || isConnectionError(err) {
+		return true, "network_error", 0
+	}
+
+	return false, "", 0
+}
+
+// isConnectionError checks if the connection was reset or refused. Other
+// network errors, like TLS or DNS failures, will not go away on a retry.
+func isConnectionError(err error) bool {
+	if e, ok := err.(*net.OpError); ok {
+		err = e.Err
+	}
+
+	if e, ok := err.(*os.SyscallError); ok {
+		err = e.Err
+	}
+
+	return err == syscall.ECONNRESET || err == syscall.ECONNREFUSED
+}
+
+func parseRetryAfter(header http.Header) time.Duration {
+	if header == nil {
+		return 0
+	}
+
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+
+	seconds, err := strconv.Atoi(value)
+	if err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	at, err := http.ParseTime(value)
+	if err == nil {
+		return time.Until(at)
+	}
+
+	return 0
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func fullJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+
+	return time.Duration(rand.Int63n(int64(d)))
+}
+
+func NewAPICaller(policy *APICallPolicy) *APICaller {
+	return &APICaller{
+		policy:    policy,
+		limiters:  make(map[string]*rate.Limiter),
+		retries:   make(map[retryPermutation]uint64),
+		throttles: make(map[string]uint64),
+		sleep:     sleepWithContext,
+		jitter:    fullJitter,
+	}
+}
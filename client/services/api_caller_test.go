@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/googleapi"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestAPICaller(policy *APICallPolicy) (*APICaller, *[]time.Duration) {
+	sleeps := make([]time.Duration, 0)
+
+	ac := NewAPICaller(policy)
+	ac.jitter = func(d time.Duration) time.Duration {
+		return d
+	}
+	ac.sleep = func(ctx context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return ctx.Err()
+	}
+
+	return ac, &sleeps
+}
+
+func TestAPICaller_Call_success(t *testing.T) {
+	ac, sleeps := newTestAPICaller(&APICallPolicy{MaxRetries: 3})
+
+	calls := 0
+	err := ac.Call(context.Background(), "fake-project", func() error {
+		calls++
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Empty(t, *sleeps)
+}
+
+func TestAPICaller_Call_retries(t *testing.T) {
+	examples := map[string]struct {
+		err            error
+		expectedReason string
+	}{
+		"too-many-requests":   {err: &googleapi.Error{Code: http.StatusTooManyRequests}, expectedReason: "rate_limited"},
+		"service-unavailable": {err: &googleapi.Error{Code: http.StatusServiceUnavailable}, expectedReason: "server_error"},
+		"rate-limit-exceeded": {err: &googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}}}, expectedReason: "rate_limited"},
+		"unexpected-eof":      {err: io.ErrUnexpectedEOF, expectedReason: "network_error"},
+		"wrapped-eof":         {err: &url.Error{Op: "Get", URL: "https://fake", Err: io.ErrUnexpectedEOF}, expectedReason: "network_error"},
+		"connection-reset":    {err: &url.Error{Op: "Get", URL: "https://fake", Err: &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}}, expectedReason: "network_error"},
+		"connection-refused":  {err: &net.OpError{Op: "dial", Err: os.NewSyscallError("connect", syscall.ECONNREFUSED)}, expectedReason: "network_error"},
+		"timeout":             {err: &url.Error{Op: "Get", URL: "https://fake", Err: &net.DNSError{IsTimeout: true}}, expectedReason: "timeout"},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			ac, sleeps := newTestAPICaller(&APICallPolicy{MaxRetries: 3, InitialBackoffMs: 100, MaxBackoffMs: 1000})
+
+			calls := 0
+			err := ac.Call(context.Background(), "fake-project", func() error {
+				calls++
+				if calls < 3 {
+					return example.err
+				}
+
+				return nil
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, 3, calls)
+			assert.Equal(t, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond}, *sleeps)
+			assert.Equal(t, uint64(2), ac.retries[retryPermutation{Project: "fake-project", Reason: example.expectedReason}])
+		})
+	}
+}
+
+func TestAPICaller_Call_notRetryable(t *testing.T) {
+	examples := map[string]error{
+		"not-found":        &googleapi.Error{Code: http.StatusNotFound},
+		"forbidden":        &googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "forbidden"}}},
+		"other-error-type": fmt.Errorf("fake-error"),
+		"token-error":      &url.Error{Op: "Get", URL: "https://fake", Err: &oauth2.RetrieveError{Response: &http.Response{StatusCode: http.StatusBadRequest}}},
+		"dns-not-found":    &url.Error{Op: "Get", URL: "https://fake", Err: &net.DNSError{Err: "no such host"}},
+		"tls-error":        &url.Error{Op: "Get", URL: "https://fake", Err: x509.UnknownAuthorityError{}},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			ac, sleeps := newTestAPICaller(&APICallPolicy{MaxRetries: 3})
+
+			calls := 0
+			err := ac.Call(context.Background(), "fake-project", func() error {
+				calls++
+				return example
+			})
+
+			assert.Equal(t, example, err)
+			assert.Equal(t, 1, calls)
+			assert.Empty(t, *sleeps)
+			assert.Empty(t, ac.retries)
+		})
+	}
+}
+
+func TestAPICaller_Call_maxRetriesExceeded(t *testing.T) {
+	ac, sleeps := newTestAPICaller(&APICallPolicy{MaxRetries: 2, InitialBackoffMs: 100, MaxBackoffMs: 150})
+
+	calls := 0
+	err := ac.Call(context.Background(), "fake-project", func() error {
+		calls++
+		return &googleapi.Error{Code: http.StatusServiceUnavailable, Message: "fake-unavailable"}
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "fake-unavailable")
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []time.Duration{100 * time.Millisecond, 150 * time.Millisecond}, *sleeps)
+}
+
+func TestAPICaller_Call_honorsRetryAfter(t *testing.T) {
+	ac, sleeps := newTestAPICaller(&APICallPolicy{MaxRetries: 1, InitialBackoffMs: 100, MaxBackoffMs: 10000})
+
+	header := http.Header{}
+	header.Set("Retry-After", "7")
+
+	calls := 0
+	err := ac.Call(context.Background(), "fake-project", func() error {
+		calls++
+		if calls == 1 {
+			return &googleapi.Error{Code: http.StatusTooManyRequests, Header: header}
+		}
+
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{7 * time.Second}, *sleeps)
+}
+
+func TestAPICaller_Call_retryAfterAboveMaxBackoff(t *testing.T) {
+	examples := map[string]string{
+		"seconds": "3600",
+		"date":    time.Now().Add(24 * time.Hour).UTC().Format(http.TimeFormat),
+	}
+
+	for name, value := range examples {
+		t.Run(name, func(t *testing.T) {
+			ac, sleeps := newTestAPICaller(&APICallPolicy{MaxRetries: 3, InitialBackoffMs: 100, MaxBackoffMs: 30000})
+
+			header := http.Header{}
+			header.Set("Retry-After", value)
+
+			calls := 0
+			err := ac.Call(context.Background(), "fake-project", func() error {
+				calls++
+				return &googleapi.Error{Code: http.StatusTooManyRequests, Header: header}
+			})
+
+			require.Error(t, err)
+			assert.Equal(t, 1, calls)
+			assert.Empty(t, *sleeps)
+			assert.Empty(t, ac.retries)
+		})
+	}
+}
+
+func TestAPICaller_Call_canceledContext(t *testing.T) {
+	ac, _ := newTestAPICaller(&APICallPolicy{MaxRetries: 3})
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	calls := 0
+	err := ac.Call(ctx, "fake-project", func() error {
+		calls++
+		cancelFn()
+
+		return &googleapi.Error{Code: http.StatusServiceUnavailable}
+	})
+
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestAPICaller_Call_rateLimited(t *testing.T) {
+	ac, sleeps := newTestAPICaller(&APICallPolicy{RateLimit: 1, RateBurst: 1})
+
+	for i := 0; i < 3; i++ {
+		err := ac.Call(context.Background(), "fake-project-1", func() error { return nil })
+		assert.NoError(t, err)
+	}
+
+	err := ac.Call(context.Background(), "fake-project-2", func() error { return nil })
+	assert.NoError(t, err)
+
+	assert.Len(t, *sleeps, 2)
+	assert.Equal(t, uint64(2), ac.throttles["fake-project-1"])
+	assert.Equal(t, uint64(0), ac.throttles["fake-project-2"])
+}
+
+func TestAPICaller_Describe(t *testing.T) {
+	ch := make(chan *prometheus.Desc, 10)
+	defer close(ch)
+
+	ac := NewAPICaller(&APICallPolicy{})
+	ac.Describe(ch)
+
+	assert.Len(t, ch, 2)
+}
+
+func TestAPICaller_Collect(t *testing.T) {
+	ch := make(chan prometheus.Metric, 10)
+	defer close(ch)
+
+	ac := NewAPICaller(&APICallPolicy{})
+	ac.countRetry("fake-project", "rate_limited")
+	ac.countRetry("fake-project", "server_error")
+	ac.countThrottle("fake-project")
+
+	ac.Collect(ch)
+
+	assert.Len(t, ch, 3)
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	examples := map[string]struct {
+		value    string
+		expected time.Duration
+	}{
+		"empty":   {value: "", expected: 0},
+		"seconds": {value: "12", expected: 12 * time.Second},
+		"invalid": {value: "soon", expected: 0},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set("Retry-After", example.value)
+
+			assert.Equal(t, example.expected, parseRetryAfter(header))
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/api/bigquery/v2"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tracing"
+)
+
+const (
+	bigQueryTimeoutMs = 10000
+	bigQueryMaxPolls  = 30
+)
+
+type BigQueryServiceInterface interface {
+	Query(ctx context.Context, project string, query string, perPage int64) ([]map[string]string, error)
+}
+
+type BigQueryService struct {
+	service *bigquery.Service
+	caller  APICallerInterface
+}
+
+func (bqs *BigQueryService) Query(ctx context.Context, project string, query string, perPage int64) (rows []map[string]string, err error) {
+	ctx, span := tracing.Start(ctx, "bigquery.jobs.query", attribute.String("gcp.project", project))
+	defer func() { tracing.End(span, err) }()
+
+	if bqs.service == nil {
+		return nil, fmt.Errorf("service not initialized")
+	}
+
+	useLegacySQL := false
+	jqc := bqs.service.Jobs.Query(project, &bigquery.QueryRequest{
+		Query:        query,
+		UseLegacySql: &useLegacySQL,
+		MaxResults:   perPage,
+		TimeoutMs:    bigQueryTimeoutMs,
+	})
+	jqc.Context(ctx)
+
+	var response *bigquery.QueryResponse
+	err = bqs.caller.Call(ctx, project, func() error {
+		var err error
+		response, err = jqc.Do()
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rows = make([]map[string]string, 0)
+
+	complete := response.JobComplete
+	pageToken := response.PageToken
+	if complete {
+		rows, err = appendQueryRows(rows, response.Schema, response.Rows)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if complete && pageToken == "" {
+		return rows, nil
+	}
+
+	if response.JobReference == nil {
+		return nil, fmt.Errorf("missing job reference of query")
+	}
+
+	gqrc := bqs.service.Jobs.GetQueryResults(project, response.JobReference.JobId)
+	gqrc.Location(response.JobReference.Location)
+	gqrc.MaxResults(perPage)
+	gqrc.TimeoutMs(bigQueryTimeoutMs)
+
+	// Until the job is complete, the same page is requested again; the API
+	// waits up to the timeout for the job before returning.
+	polls := 0
+	for pageNumber := 1; !complete || pageToken != ""; pageNumber++ {
+		if !complete {
+			if polls >= bigQueryMaxPolls {
+				return nil, fmt.Errorf("query job %s not complete after %d polls", response.JobReference.JobId, polls)
+			}
+
+			polls++
+		}
+
+		pageToken, err = listPage(ctx, bqs.caller, "bigquery.jobs.getQueryResults.page", project, pageToken, pageNumber, func(ctx context.Context, pageToken string) (string, error) {
+			page, err := gqrc.PageToken(pageToken).Context(ctx).Do()
+			if err != nil {
+				return "", err
+			}
+
+			complete = page.JobComplete
+			if !complete {
+				return pageToken, nil
+			}
+
+			rows, err = appendQueryRows(rows, page.Schema, page.Rows)
+			if err != nil {
+				return "", err
+			}
+
+			return page.PageToken, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		span.SetAttributes(attribute.Int("gcp.pages", pageNumber))
+	}
+
+	return rows, nil
+}
+
+func appendQueryRows(rows []map[string]string, schema *bigquery.TableSchema, tableRows []*bigquery.TableRow) ([]map[string]string, error) {
+	if len(tableRows) < 1 {
+		return rows, nil
+	}
+
+	if schema == nil {
+		return nil, fmt.Errorf("missing schema of query results")
+	}
+
+	for _, tableRow := range tableRows {
+		if len(tableRow.F) != len(schema.Fields) {
+			return nil, fmt.Errorf("query results row has %d fields, schema has %d", len(tableRow.F), len(schema.Fields))
+		}
+
+		row := make(map[string]string, len(schema.Fields))
+		for i, field := range schema.Fields {
+			if tableRow.F[i].V == nil {
+				row[field.Name] = ""
+				continue
+			}
+
+			row[field.Name] = fmt.Sprint(tableRow.F[i].V)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func NewBigQueryService(client *http.Client) (*BigQueryService, error) {
+	service, err := bigquery.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	bqs := &BigQueryService{
+		service: service,
+		caller:  DefaultAPICaller,
+	}
+
+	return bqs, nil
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/bigquery/v2"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tests"
+)
+
+var testQuerySchema = &bigquery.TableSchema{
+	Fields: []*bigquery.TableFieldSchema{
+		{Name: "project", Type: "STRING"},
+		{Name: "cost", Type: "FLOAT"},
+	},
+}
+
+func newTestQueryRow(project interface{}, cost interface{}) *bigquery.TableRow {
+	return &bigquery.TableRow{F: []*bigquery.TableCell{{V: project}, {V: cost}}}
+}
+
+func newTestBigQueryService(t *testing.T, api *tests.FakeAPI) *BigQueryService {
+	bqs, err := NewBigQueryService(&http.Client{})
+	require.NoError(t, err)
+
+	bqs.service.BasePath = api.URL + "/"
+	bqs.caller, _ = newTestAPICaller(&APICallPolicy{})
+
+	return bqs
+}
+
+func TestBigQueryService_Query(t *testing.T) {
+	api := tests.NewFakeAPI(t)
+	defer api.Close()
+
+	api.HandleFunc(http.MethodPost, "/projects/fake-project/queries", func(r *http.Request) (int, interface{}) {
+		request := &bigquery.QueryRequest{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(request))
+
+		assert.Equal(t, "SELECT 1", request.Query)
+		require.NotNil(t, request.UseLegacySql)
+		assert.False(t, *request.UseLegacySql)
+		assert.Equal(t, int64(100), request.MaxResults)
+
+		return http.StatusOK, &bigquery.QueryResponse{
+			JobComplete: true,
+			Schema:      testQuerySchema,
+			Rows: []*bigquery.TableRow{
+				newTestQueryRow("project-1", "1.5"),
+				newTestQueryRow(nil, "0.25"),
+			},
+		}
+	})
+
+	bqs := newTestBigQueryService(t, api)
+
+	rows, err := bqs.Query(context.Background(), "fake-project", "SELECT 1", 100)
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"project": "project-1", "cost": "1.5"},
+		{"project": "", "cost": "0.25"},
+	}, rows)
+	assert.Len(t, api.Requests(), 1)
+}
+
+func TestBigQueryService_Query_incompleteAndPaged(t *testing.T) {
+	api := tests.NewFakeAPI(t)
+	defer api.Close()
+
+	jobReference := &bigquery.JobReference{ProjectId: "fake-project", JobId: "job-1", Location: "US"}
+
+	api.Handle(http.MethodPost, "/projects/fake-project/queries", http.StatusOK, &bigquery.QueryResponse{
+		JobComplete:  false,
+		JobReference: jobReference,
+	})
+
+	polls := 0
+	api.HandleFunc(http.MethodGet, "/projects/fake-project/queries/job-1", func(r *http.Request) (int, interface{}) {
+		assert.Equal(t, "US", r.URL.Query().Get("location"))
+
+		switch r.URL.Query().Get("pageToken") {
+		case "":
+			polls++
+			if polls < 2 {
+				return http.StatusOK, &bigquery.GetQueryResultsResponse{JobComplete: false, JobReference: jobReference}
+			}
+
+			return http.StatusOK, &bigquery.GetQueryResultsResponse{
+				JobComplete: true,
+				Schema:      testQuerySchema,
+				Rows:        []*bigquery.TableRow{newTestQueryRow("project-1", "1")},
+				PageToken:   "page-2",
+			}
+		case "page-2":
+			return http.StatusOK, &bigquery.GetQueryResultsResponse{
+				JobComplete: true,
+				Schema:      testQuerySchema,
+				Rows:        []*bigquery.TableRow{newTestQueryRow("project-2", "2")},
+			}
+		}
+
+		return http.StatusBadRequest, nil
+	})
+
+	bqs := newTestBigQueryService(t, api)
+
+	rows, err := bqs.Query(context.Background(), "fake-project", "SELECT 1", 100)
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]string{
+		{"project": "project-1", "cost": "1"},
+		{"project": "project-2", "cost": "2"},
+	}, rows)
+	assert.Len(t, api.Requests(), 4)
+}
+
+func TestBigQueryService_Query_neverComplete(t *testing.T) {
+	api := tests.NewFakeAPI(t)
+	defer api.Close()
+
+	jobReference := &bigquery.JobReference{ProjectId: "fake-project", JobId: "job-1", Location: "US"}
+
+	api.Handle(http.MethodPost, "/projects/fake-project/queries", http.StatusOK, &bigquery.QueryResponse{
+		JobComplete:  false,
+		JobReference: jobReference,
+	})
+	api.Handle(http.MethodGet, "/projects/fake-project/queries/job-1", http.StatusOK, &bigquery.GetQueryResultsResponse{
+		JobComplete:  false,
+		JobReference: jobReference,
+	})
+
+	bqs := newTestBigQueryService(t, api)
+
+	rows, err := bqs.Query(context.Background(), "fake-project", "SELECT 1", 100)
+	assert.Nil(t, rows)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "query job job-1 not complete after 30 polls")
+	assert.Len(t, api.Requests(), 1+bigQueryMaxPolls)
+}
+
+func TestBigQueryService_Query_error(t *testing.T) {
+	api := tests.NewFakeAPI(t)
+	defer api.Close()
+
+	bqs := newTestBigQueryService(t, api)
+
+	rows, err := bqs.Query(context.Background(), "fake-project", "SELECT 1", 100)
+	assert.Nil(t, rows)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Error 404")
+}
+
+func TestBigQueryService_Query_invalidRows(t *testing.T) {
+	api := tests.NewFakeAPI(t)
+	defer api.Close()
+
+	api.Handle(http.MethodPost, "/projects/fake-project/queries", http.StatusOK, &bigquery.QueryResponse{
+		JobComplete: true,
+		Schema:      testQuerySchema,
+		Rows:        []*bigquery.TableRow{{F: []*bigquery.TableCell{{V: "project-1"}}}},
+	})
+
+	bqs := newTestBigQueryService(t, api)
+
+	_, err := bqs.Query(context.Background(), "fake-project", "SELECT 1", 100)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "query results row has 1 fields, schema has 2")
+}
+
+func TestBigQueryService_notInitialized(t *testing.T) {
+	bqs := &BigQueryService{}
+
+	_, err := bqs.Query(context.Background(), "fake-project", "SELECT 1", 100)
+	assert.EqualError(t, err, "service not initialized")
+}
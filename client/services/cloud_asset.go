@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/api/cloudasset/v1"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tracing"
+)
+
+const (
+	searchAllResourcesReadMask = "name,assetType,project,location,labels,state,parentAssetType"
+)
+
+type CloudAssetServiceInterface interface {
+	SearchAllResources(ctx context.Context, scope string, assetTypes []string, query string, perPage int64) ([]*cloudasset.ResourceSearchResult, error)
+}
+
+type CloudAssetService struct {
+	service *cloudasset.Service
+	caller  APICallerInterface
+}
+
+// SearchAllResources searches resources within the scope, given as
+// projects/<id>, folders/<number> or organizations/<number>. The scope is
+// also used as the API call key in place of the project.
+func (cas *CloudAssetService) SearchAllResources(ctx context.Context, scope string, assetTypes []string, query string, perPage int64) (results []*cloudasset.ResourceSearchResult, err error) {
+	ctx, span := tracing.Start(ctx, "cloudasset.searchAllResources", attribute.String("gcp.scope", scope))
+	defer func() { tracing.End(span, err) }()
+
+	if cas.service == nil {
+		return nil, fmt.Errorf("service not initialized")
+	}
+
+	results = make([]*cloudasset.ResourceSearchResult, 0)
+
+	sarc := cas.service.V1.SearchAllResources(scope)
+	sarc.AssetTypes(assetTypes...)
+	sarc.Query(query)
+	sarc.ReadMask(searchAllResourcesReadMask)
+	sarc.PageSize(perPage)
+
+	err = listPages(ctx, cas.caller, span, "cloudasset.searchAllResources.page", scope, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := sarc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		results = append(results, page.Results...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func NewCloudAssetService(client *http.Client) (*CloudAssetService, error) {
+	service, err := cloudasset.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	cas := &CloudAssetService{
+		service: service,
+		caller:  DefaultAPICaller,
+	}
+
+	return cas, nil
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudAssetService_SearchAllResources(t *testing.T) {
+	rt := &sequenceRoundTripper{
+		responses: []*http.Response{
+			newJSONResponse(http.StatusOK, `{"results": [{"name": "resource-1"}], "nextPageToken": "page-2"}`),
+			newJSONResponse(http.StatusOK, `{"results": [{"name": "resource-2"}]}`),
+		},
+	}
+
+	cas, err := NewCloudAssetService(&http.Client{Transport: rt})
+	require.NoError(t, err)
+	cas.caller, _ = newTestAPICaller(&APICallPolicy{})
+
+	assetTypes := []string{"compute.googleapis.com/Instance", "compute.googleapis.com/Disk"}
+
+	results, err := cas.SearchAllResources(context.Background(), "folders/123", assetTypes, "state:RUNNING", 1)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	assert.Equal(t, "resource-1", results[0].Name)
+	assert.Equal(t, "resource-2", results[1].Name)
+
+	require.Len(t, rt.requests, 2)
+	assert.Equal(t, "/v1/folders/123:searchAllResources", rt.requests[0].URL.Path)
+
+	query := rt.requests[0].URL.Query()
+	assert.Equal(t, assetTypes, query["assetTypes"])
+	assert.Equal(t, "state:RUNNING", query.Get("query"))
+	assert.Equal(t, searchAllResourcesReadMask, query.Get("readMask"))
+	assert.Equal(t, "page-2", rt.requests[1].URL.Query().Get("pageToken"))
+}
+
+func TestCloudAssetService_SearchAllResources_notInitialized(t *testing.T) {
+	cas := &CloudAssetService{caller: DefaultAPICaller}
+
+	_, err := cas.SearchAllResources(context.Background(), "projects/fake-project", nil, "", 10)
+	assert.EqualError(t, err, "service not initialized")
+}
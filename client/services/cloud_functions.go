@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/api/cloudfunctions/v2"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tracing"
+)
+
+type CloudFunctionsServiceInterface interface {
+	ListFunctions(ctx context.Context, project string, region string, perPage int64) ([]*cloudfunctions.Function, error)
+}
+
+type CloudFunctionsService struct {
+	service *cloudfunctions.Service
+	caller  APICallerInterface
+}
+
+func (cfs *CloudFunctionsService) ListFunctions(ctx context.Context, project string, region string, perPage int64) (functions []*cloudfunctions.Function, err error) {
+	ctx, span := tracing.Start(ctx, "cloudfunctions.functions.list",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.region", region),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	if cfs.service == nil {
+		return nil, fmt.Errorf("service not initialized")
+	}
+
+	functions = make([]*cloudfunctions.Function, 0)
+
+	flc := cfs.service.Projects.Locations.Functions.List(fmt.Sprintf("projects/%s/locations/%s", project, region))
+	flc.PageSize(perPage)
+
+	err = listPages(ctx, cfs.caller, span, "cloudfunctions.functions.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := flc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		functions = append(functions, page.Functions...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return functions, nil
+}
+
+func NewCloudFunctionsService(client *http.Client) (*CloudFunctionsService, error) {
+	service, err := cloudfunctions.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	cfs := &CloudFunctionsService{
+		service: service,
+		caller:  DefaultAPICaller,
+	}
+
+	return cfs, nil
+}
@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudFunctionsService_ListFunctions(t *testing.T) {
+	rt := &sequenceRoundTripper{
+		responses: []*http.Response{
+			newJSONResponse(http.StatusOK, `{"functions": [{"name": "projects/fake-project/locations/us-east1/functions/function-1"}], "nextPageToken": "page-2"}`),
+			newJSONResponse(http.StatusOK, `{"functions": [{"name": "projects/fake-project/locations/us-east1/functions/function-2"}]}`),
+		},
+	}
+
+	cfs, err := NewCloudFunctionsService(&http.Client{Transport: rt})
+	require.NoError(t, err)
+	cfs.caller, _ = newTestAPICaller(&APICallPolicy{})
+
+	functions, err := cfs.ListFunctions(context.Background(), "fake-project", "us-east1", 1)
+	require.NoError(t, err)
+	require.Len(t, functions, 2)
+	assert.Equal(t, "projects/fake-project/locations/us-east1/functions/function-1", functions[0].Name)
+	assert.Equal(t, "projects/fake-project/locations/us-east1/functions/function-2", functions[1].Name)
+
+	require.Len(t, rt.requests, 2)
+	assert.Equal(t, "/v2/projects/fake-project/locations/us-east1/functions", rt.requests[0].URL.Path)
+	assert.Equal(t, "page-2", rt.requests[1].URL.Query().Get("pageToken"))
+}
+
+func TestCloudFunctionsService_ListFunctions_notInitialized(t *testing.T) {
+	cfs := &CloudFunctionsService{caller: DefaultAPICaller}
+
+	_, err := cfs.ListFunctions(context.Background(), "fake-project", "us-east1", 10)
+	assert.EqualError(t, err, "service not initialized")
+}
@@ -5,33 +5,67 @@ import (
 	"fmt"
 	"net/http"
 
+	"go.opentelemetry.io/otel/attribute"
 	"google.golang.org/api/compute/v1"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tracing"
 )
 
 type ComputeServiceInterface interface {
 	ListInstances(ctx context.Context, project string, zone string, perPage int64) ([]*compute.Instance, error)
+	ListDisks(ctx context.Context, project string, zone string, perPage int64) ([]*compute.Disk, error)
+	ListInstanceGroups(ctx context.Context, project string, zone string, perPage int64) ([]*compute.InstanceGroup, error)
+	ListRegionInstanceGroups(ctx context.Context, project string, region string, perPage int64) ([]*compute.InstanceGroup, error)
+	ListInstanceGroupManagers(ctx context.Context, project string, zone string, perPage int64) ([]*compute.InstanceGroupManager, error)
+	ListRegionInstanceGroupManagers(ctx context.Context, project string, region string, perPage int64) ([]*compute.InstanceGroupManager, error)
+	ListAutoscalers(ctx context.Context, project string, zone string, perPage int64) ([]*compute.Autoscaler, error)
+	ListRegionAutoscalers(ctx context.Context, project string, region string, perPage int64) ([]*compute.Autoscaler, error)
+	ListAddresses(ctx context.Context, project string, region string, perPage int64) ([]*compute.Address, error)
+	ListGlobalAddresses(ctx context.Context, project string, perPage int64) ([]*compute.Address, error)
+	ListForwardingRules(ctx context.Context, project string, region string, perPage int64) ([]*compute.ForwardingRule, error)
+	ListGlobalForwardingRules(ctx context.Context, project string, perPage int64) ([]*compute.ForwardingRule, error)
+	ListFirewalls(ctx context.Context, project string, perPage int64) ([]*compute.Firewall, error)
+	ListSubnetworks(ctx context.Context, project string, region string, perPage int64) ([]*compute.Subnetwork, error)
+	ListBackendServices(ctx context.Context, project string, perPage int64) ([]*compute.BackendService, error)
+	ListRegionBackendServices(ctx context.Context, project string, region string, perPage int64) ([]*compute.BackendService, error)
 	GetRegion(ctx context.Context, project string, region string) (*compute.Region, error)
+	GetInstanceGroupManager(ctx context.Context, project string, zone string, name string) (*compute.InstanceGroupManager, error)
+	GetBackendServiceHealth(ctx context.Context, project string, backendService string, group string) (*compute.BackendServiceGroupHealth, error)
+	GetRegionBackendServiceHealth(ctx context.Context, project string, region string, backendService string, group string) (*compute.BackendServiceGroupHealth, error)
 }
 
 type ComputeService struct {
 	service *compute.Service
+	caller  APICallerInterface
 }
 
-func (cs *ComputeService) ListInstances(ctx context.Context, project string, zone string, perPage int64) ([]*compute.Instance, error) {
-	err := cs.failIfInitialized()
+func (cs *ComputeService) ListInstances(ctx context.Context, project string, zone string, perPage int64) (instances []*compute.Instance, err error) {
+	ctx, span := tracing.Start(ctx, "compute.instances.list",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.zone", zone),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
 	if err != nil {
 		return nil, err
 	}
 
-	instances := make([]*compute.Instance, 0)
+	instances = make([]*compute.Instance, 0)
 
 	ilc := cs.service.Instances.List(project, zone)
 	ilc.MaxResults(perPage)
-	err = ilc.Pages(ctx, func(page *compute.InstanceList) error {
+
+	err = listPages(ctx, cs.caller, span, "compute.instances.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := ilc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
 		instances = append(instances, page.Items...)
-		return nil
-	})
 
+		return page.NextPageToken, nil
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -39,8 +73,520 @@ func (cs *ComputeService) ListInstances(ctx context.Context, project string, zon
 	return instances, nil
 }
 
-func (cs *ComputeService) GetRegion(ctx context.Context, project string, region string) (*compute.Region, error) {
-	err := cs.failIfInitialized()
+func (cs *ComputeService) ListDisks(ctx context.Context, project string, zone string, perPage int64) (disks []*compute.Disk, err error) {
+	ctx, span := tracing.Start(ctx, "compute.disks.list",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.zone", zone),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	disks = make([]*compute.Disk, 0)
+
+	dlc := cs.service.Disks.List(project, zone)
+	dlc.MaxResults(perPage)
+
+	err = listPages(ctx, cs.caller, span, "compute.disks.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := dlc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		disks = append(disks, page.Items...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return disks, nil
+}
+
+func (cs *ComputeService) ListInstanceGroups(ctx context.Context, project string, zone string, perPage int64) (groups []*compute.InstanceGroup, err error) {
+	ctx, span := tracing.Start(ctx, "compute.instanceGroups.list",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.zone", zone),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	groups = make([]*compute.InstanceGroup, 0)
+
+	iglc := cs.service.InstanceGroups.List(project, zone)
+	iglc.MaxResults(perPage)
+
+	err = listPages(ctx, cs.caller, span, "compute.instanceGroups.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := iglc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		groups = append(groups, page.Items...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (cs *ComputeService) ListRegionInstanceGroups(ctx context.Context, project string, region string, perPage int64) (groups []*compute.InstanceGroup, err error) {
+	ctx, span := tracing.Start(ctx, "compute.regionInstanceGroups.list",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.region", region),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	groups = make([]*compute.InstanceGroup, 0)
+
+	riglc := cs.service.RegionInstanceGroups.List(project, region)
+	riglc.MaxResults(perPage)
+
+	err = listPages(ctx, cs.caller, span, "compute.regionInstanceGroups.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := riglc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		groups = append(groups, page.Items...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return groups, nil
+}
+
+func (cs *ComputeService) ListInstanceGroupManagers(ctx context.Context, project string, zone string, perPage int64) (managers []*compute.InstanceGroupManager, err error) {
+	ctx, span := tracing.Start(ctx, "compute.instanceGroupManagers.list",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.zone", zone),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	managers = make([]*compute.InstanceGroupManager, 0)
+
+	igmlc := cs.service.InstanceGroupManagers.List(project, zone)
+	igmlc.MaxResults(perPage)
+
+	err = listPages(ctx, cs.caller, span, "compute.instanceGroupManagers.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := igmlc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		managers = append(managers, page.Items...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return managers, nil
+}
+
+func (cs *ComputeService) ListRegionInstanceGroupManagers(ctx context.Context, project string, region string, perPage int64) (managers []*compute.InstanceGroupManager, err error) {
+	ctx, span := tracing.Start(ctx, "compute.regionInstanceGroupManagers.list",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.region", region),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	managers = make([]*compute.InstanceGroupManager, 0)
+
+	rigmlc := cs.service.RegionInstanceGroupManagers.List(project, region)
+	rigmlc.MaxResults(perPage)
+
+	err = listPages(ctx, cs.caller, span, "compute.regionInstanceGroupManagers.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := rigmlc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		managers = append(managers, page.Items...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return managers, nil
+}
+
+func (cs *ComputeService) ListAutoscalers(ctx context.Context, project string, zone string, perPage int64) (autoscalers []*compute.Autoscaler, err error) {
+	ctx, span := tracing.Start(ctx, "compute.autoscalers.list",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.zone", zone),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	autoscalers = make([]*compute.Autoscaler, 0)
+
+	alc := cs.service.Autoscalers.List(project, zone)
+	alc.MaxResults(perPage)
+
+	err = listPages(ctx, cs.caller, span, "compute.autoscalers.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := alc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		autoscalers = append(autoscalers, page.Items...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return autoscalers, nil
+}
+
+func (cs *ComputeService) ListRegionAutoscalers(ctx context.Context, project string, region string, perPage int64) (autoscalers []*compute.Autoscaler, err error) {
+	ctx, span := tracing.Start(ctx, "compute.regionAutoscalers.list",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.region", region),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	autoscalers = make([]*compute.Autoscaler, 0)
+
+	ralc := cs.service.RegionAutoscalers.List(project, region)
+	ralc.MaxResults(perPage)
+
+	err = listPages(ctx, cs.caller, span, "compute.regionAutoscalers.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := ralc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		autoscalers = append(autoscalers, page.Items...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return autoscalers, nil
+}
+
+func (cs *ComputeService) ListAddresses(ctx context.Context, project string, region string, perPage int64) (addresses []*compute.Address, err error) {
+	ctx, span := tracing.Start(ctx, "compute.addresses.list",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.region", region),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	addresses = make([]*compute.Address, 0)
+
+	alc := cs.service.Addresses.List(project, region)
+	alc.MaxResults(perPage)
+
+	err = listPages(ctx, cs.caller, span, "compute.addresses.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := alc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		addresses = append(addresses, page.Items...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return addresses, nil
+}
+
+func (cs *ComputeService) ListGlobalAddresses(ctx context.Context, project string, perPage int64) (addresses []*compute.Address, err error) {
+	ctx, span := tracing.Start(ctx, "compute.globalAddresses.list",
+		attribute.String("gcp.project", project),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	addresses = make([]*compute.Address, 0)
+
+	galc := cs.service.GlobalAddresses.List(project)
+	galc.MaxResults(perPage)
+
+	err = listPages(ctx, cs.caller, span, "compute.globalAddresses.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := galc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		addresses = append(addresses, page.Items...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return addresses, nil
+}
+
+func (cs *ComputeService) ListForwardingRules(ctx context.Context, project string, region string, perPage int64) (rules []*compute.ForwardingRule, err error) {
+	ctx, span := tracing.Start(ctx, "compute.forwardingRules.list",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.region", region),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	rules = make([]*compute.ForwardingRule, 0)
+
+	frlc := cs.service.ForwardingRules.List(project, region)
+	frlc.MaxResults(perPage)
+
+	err = listPages(ctx, cs.caller, span, "compute.forwardingRules.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := frlc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		rules = append(rules, page.Items...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (cs *ComputeService) ListGlobalForwardingRules(ctx context.Context, project string, perPage int64) (rules []*compute.ForwardingRule, err error) {
+	ctx, span := tracing.Start(ctx, "compute.globalForwardingRules.list",
+		attribute.String("gcp.project", project),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	rules = make([]*compute.ForwardingRule, 0)
+
+	gfrlc := cs.service.GlobalForwardingRules.List(project)
+	gfrlc.MaxResults(perPage)
+
+	err = listPages(ctx, cs.caller, span, "compute.globalForwardingRules.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := gfrlc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		rules = append(rules, page.Items...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+func (cs *ComputeService) ListFirewalls(ctx context.Context, project string, perPage int64) (firewalls []*compute.Firewall, err error) {
+	ctx, span := tracing.Start(ctx, "compute.firewalls.list",
+		attribute.String("gcp.project", project),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	firewalls = make([]*compute.Firewall, 0)
+
+	flc := cs.service.Firewalls.List(project)
+	flc.MaxResults(perPage)
+
+	err = listPages(ctx, cs.caller, span, "compute.firewalls.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := flc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		firewalls = append(firewalls, page.Items...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return firewalls, nil
+}
+
+func (cs *ComputeService) ListSubnetworks(ctx context.Context, project string, region string, perPage int64) (subnetworks []*compute.Subnetwork, err error) {
+	ctx, span := tracing.Start(ctx, "compute.subnetworks.list",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.region", region),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	subnetworks = make([]*compute.Subnetwork, 0)
+
+	slc := cs.service.Subnetworks.List(project, region)
+	slc.MaxResults(perPage)
+
+	err = listPages(ctx, cs.caller, span, "compute.subnetworks.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := slc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		subnetworks = append(subnetworks, page.Items...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return subnetworks, nil
+}
+
+func (cs *ComputeService) ListBackendServices(ctx context.Context, project string, perPage int64) (backendServices []*compute.BackendService, err error) {
+	ctx, span := tracing.Start(ctx, "compute.backendServices.list",
+		attribute.String("gcp.project", project),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	backendServices = make([]*compute.BackendService, 0)
+
+	bslc := cs.service.BackendServices.List(project)
+	bslc.MaxResults(perPage)
+
+	err = listPages(ctx, cs.caller, span, "compute.backendServices.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := bslc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		backendServices = append(backendServices, page.Items...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return backendServices, nil
+}
+
+func (cs *ComputeService) ListRegionBackendServices(ctx context.Context, project string, region string, perPage int64) (backendServices []*compute.BackendService, err error) {
+	ctx, span := tracing.Start(ctx, "compute.regionBackendServices.list",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.region", region),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	backendServices = make([]*compute.BackendService, 0)
+
+	rbslc := cs.service.RegionBackendServices.List(project, region)
+	rbslc.MaxResults(perPage)
+
+	err = listPages(ctx, cs.caller, span, "compute.regionBackendServices.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := rbslc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		backendServices = append(backendServices, page.Items...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return backendServices, nil
+}
+
+func (cs *ComputeService) GetRegion(ctx context.Context, project string, region string) (reg *compute.Region, err error) {
+	ctx, span := tracing.Start(ctx, "compute.regions.get",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.region", region),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
 	if err != nil {
 		return nil, err
 	}
@@ -48,7 +594,104 @@ func (cs *ComputeService) GetRegion(ctx context.Context, project string, region
 	rgc := cs.service.Regions.Get(project, region)
 	rgc.Context(ctx)
 
-	return rgc.Do()
+	err = cs.caller.Call(ctx, project, func() error {
+		var err error
+		reg, err = rgc.Do()
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return reg, nil
+}
+
+func (cs *ComputeService) GetInstanceGroupManager(ctx context.Context, project string, zone string, name string) (manager *compute.InstanceGroupManager, err error) {
+	ctx, span := tracing.Start(ctx, "compute.instanceGroupManagers.get",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.zone", zone),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	igmgc := cs.service.InstanceGroupManagers.Get(project, zone, name)
+	igmgc.Context(ctx)
+
+	err = cs.caller.Call(ctx, project, func() error {
+		var err error
+		manager, err = igmgc.Do()
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return manager, nil
+}
+
+func (cs *ComputeService) GetBackendServiceHealth(ctx context.Context, project string, backendService string, group string) (health *compute.BackendServiceGroupHealth, err error) {
+	ctx, span := tracing.Start(ctx, "compute.backendServices.getHealth",
+		attribute.String("gcp.project", project),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	bsghc := cs.service.BackendServices.GetHealth(project, backendService, &compute.ResourceGroupReference{Group: group})
+	bsghc.Context(ctx)
+
+	err = cs.caller.Call(ctx, project, func() error {
+		var err error
+		health, err = bsghc.Do()
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return health, nil
+}
+
+func (cs *ComputeService) GetRegionBackendServiceHealth(ctx context.Context, project string, region string, backendService string, group string) (health *compute.BackendServiceGroupHealth, err error) {
+	ctx, span := tracing.Start(ctx, "compute.regionBackendServices.getHealth",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.region", region),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	rbsghc := cs.service.RegionBackendServices.GetHealth(project, region, backendService, &compute.ResourceGroupReference{Group: group})
+	rbsghc.Context(ctx)
+
+	err = cs.caller.Call(ctx, project, func() error {
+		var err error
+		health, err = rbsghc.Do()
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return health, nil
 }
 
 func (cs *ComputeService) failIfInitialized() error {
@@ -67,6 +710,7 @@ func NewComputeService(client *http.Client) (*ComputeService, error) {
 
 	cs := &ComputeService{
 		service: service,
+		caller:  DefaultAPICaller,
 	}
 
 	return cs, nil
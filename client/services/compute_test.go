@@ -9,6 +9,10 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tests"
 )
 
 type fakeRoundTripper struct {
@@ -93,3 +97,162 @@ func TestComputeService_GetRegion_notInitialized(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "service not initialized")
 }
+
+type sequenceRoundTripper struct {
+	responses []*http.Response
+	requests  []*http.Request
+}
+
+func (srt *sequenceRoundTripper) RoundTrip(request *http.Request) (*http.Response, error) {
+	srt.requests = append(srt.requests, request)
+
+	resp := srt.responses[0]
+	srt.responses = srt.responses[1:]
+
+	return resp, nil
+}
+
+func newJSONResponse(statusCode int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: statusCode,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+func TestComputeService_ListInstances_pagesWithRetries(t *testing.T) {
+	rt := &sequenceRoundTripper{
+		responses: []*http.Response{
+			newJSONResponse(http.StatusServiceUnavailable, `{"error": {"code": 503, "message": "Backend Error"}}`),
+			newJSONResponse(http.StatusOK, `{"items": [{"id": "1"}, {"id": "2"}], "nextPageToken": "page-2"}`),
+			newJSONResponse(http.StatusOK, `{"items": [{"id": "3"}]}`),
+		},
+	}
+
+	c, err := NewComputeService(&http.Client{Transport: rt})
+	require.NoError(t, err)
+
+	caller, sleeps := newTestAPICaller(&APICallPolicy{MaxRetries: 3, InitialBackoffMs: 10, MaxBackoffMs: 100})
+	c.caller = caller
+
+	instancesList, err := c.ListInstances(context.Background(), "fake-project", "fake-zone", 2)
+
+	require.NoError(t, err)
+	assert.Len(t, instancesList, 3)
+	assert.Len(t, *sleeps, 1)
+	require.Len(t, rt.requests, 3)
+	assert.Equal(t, "page-2", rt.requests[2].URL.Query().Get("pageToken"))
+	assert.Equal(t, uint64(1), caller.retries[retryPermutation{Project: "fake-project", Reason: "server_error"}])
+}
+
+func TestComputeService_ListInstances_tracing(t *testing.T) {
+	tests.RunWithSpanRecorder(t, func(t *testing.T, recorder *tracetest.SpanRecorder) {
+		rt := &sequenceRoundTripper{
+			responses: []*http.Response{
+				newJSONResponse(http.StatusOK, `{"items": [{"id": "1"}], "nextPageToken": "page-2"}`),
+				newJSONResponse(http.StatusTooManyRequests, `{"error": {"code": 429, "message": "Rate Limit Exceeded"}}`),
+				newJSONResponse(http.StatusOK, `{"items": [{"id": "2"}]}`),
+			},
+		}
+
+		c, err := NewComputeService(&http.Client{Transport: rt})
+		require.NoError(t, err)
+
+		caller, _ := newTestAPICaller(&APICallPolicy{MaxRetries: 3, InitialBackoffMs: 10, MaxBackoffMs: 100})
+		c.caller = caller
+
+		_, err = c.ListInstances(context.Background(), "fake-project", "fake-zone", 1)
+		require.NoError(t, err)
+
+		listSpans := tests.FindSpans(recorder, "compute.instances.list")
+		require.Len(t, listSpans, 1)
+
+		attributes := tests.SpanAttributes(listSpans[0])
+		assert.Equal(t, "fake-project", attributes["gcp.project"].AsString())
+		assert.Equal(t, "fake-zone", attributes["gcp.zone"].AsString())
+		assert.Equal(t, int64(2), attributes["gcp.pages"].AsInt64())
+
+		pageSpans := tests.FindSpans(recorder, "compute.instances.list.page")
+		require.Len(t, pageSpans, 2)
+
+		for i, span := range pageSpans {
+			assert.Equal(t, listSpans[0].SpanContext().SpanID(), span.Parent().SpanID())
+			assert.Equal(t, int64(i+1), tests.SpanAttributes(span)["gcp.page"].AsInt64())
+		}
+
+		assert.NotContains(t, tests.SpanAttributes(pageSpans[0]), attribute.Key("gcp.api.retries"))
+		assert.Equal(t, int64(1), tests.SpanAttributes(pageSpans[1])["gcp.api.retries"].AsInt64())
+		require.Len(t, pageSpans[1].Events(), 1)
+		assert.Equal(t, "retry", pageSpans[1].Events()[0].Name)
+	})
+}
+
+func TestComputeService_ListRegionInstanceGroupManagers_pages(t *testing.T) {
+	rt := &sequenceRoundTripper{
+		responses: []*http.Response{
+			newJSONResponse(http.StatusOK, `{"items": [{"name": "mig-1"}], "nextPageToken": "page-2"}`),
+			newJSONResponse(http.StatusOK, `{"items": [{"name": "mig-2"}]}`),
+		},
+	}
+
+	c, err := NewComputeService(&http.Client{Transport: rt})
+	require.NoError(t, err)
+
+	caller, _ := newTestAPICaller(&APICallPolicy{MaxRetries: 3, InitialBackoffMs: 10, MaxBackoffMs: 100})
+	c.caller = caller
+
+	managers, err := c.ListRegionInstanceGroupManagers(context.Background(), "fake-project", "fake-region", 1)
+
+	require.NoError(t, err)
+	require.Len(t, managers, 2)
+	assert.Equal(t, "mig-1", managers[0].Name)
+	assert.Equal(t, "mig-2", managers[1].Name)
+	require.Len(t, rt.requests, 2)
+	assert.Contains(t, rt.requests[0].URL.Path, "/projects/fake-project/regions/fake-region/instanceGroupManagers")
+	assert.Equal(t, "page-2", rt.requests[1].URL.Query().Get("pageToken"))
+}
+
+func TestComputeService_ListInstanceGroupManagers_notInitialized(t *testing.T) {
+	c := &ComputeService{}
+	managers, err := c.ListInstanceGroupManagers(context.Background(), "fake-project", "fake-zone", 10)
+
+	assert.Empty(t, managers)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "service not initialized")
+}
+
+func TestComputeService_GetBackendServiceHealth(t *testing.T) {
+	rt := &sequenceRoundTripper{
+		responses: []*http.Response{
+			newJSONResponse(http.StatusOK, `{"healthStatus": [{"healthState": "HEALTHY"}, {"healthState": "UNHEALTHY"}]}`),
+		},
+	}
+
+	c, err := NewComputeService(&http.Client{Transport: rt})
+	require.NoError(t, err)
+
+	c.caller, _ = newTestAPICaller(&APICallPolicy{})
+
+	health, err := c.GetBackendServiceHealth(context.Background(), "fake-project", "fake-backend-service", "fake-group")
+
+	require.NoError(t, err)
+	require.Len(t, health.HealthStatus, 2)
+	assert.Equal(t, "HEALTHY", health.HealthStatus[0].HealthState)
+	assert.Equal(t, "UNHEALTHY", health.HealthStatus[1].HealthState)
+	require.Len(t, rt.requests, 1)
+	assert.Equal(t, http.MethodPost, rt.requests[0].Method)
+	assert.Contains(t, rt.requests[0].URL.Path, "/projects/fake-project/global/backendServices/fake-backend-service/getHealth")
+
+	body, err := ioutil.ReadAll(rt.requests[0].Body)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"group": "fake-group"}`, string(body))
+}
+
+func TestComputeService_ListRegionBackendServices_notInitialized(t *testing.T) {
+	c := &ComputeService{}
+	backendServices, err := c.ListRegionBackendServices(context.Background(), "fake-project", "fake-region", 10)
+
+	assert.Empty(t, backendServices)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "service not initialized")
+}
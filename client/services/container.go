@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/api/container/v1"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tracing"
+)
+
+type ContainerServiceInterface interface {
+	ListClusters(ctx context.Context, project string) ([]*container.Cluster, error)
+	GetServerConfig(ctx context.Context, project string, location string) (*container.ServerConfig, error)
+}
+
+type ContainerService struct {
+	service *container.Service
+	caller  APICallerInterface
+}
+
+func (cs *ContainerService) ListClusters(ctx context.Context, project string) (clusters []*container.Cluster, err error) {
+	ctx, span := tracing.Start(ctx, "container.clusters.list", attribute.String("gcp.project", project))
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	clc := cs.service.Projects.Locations.Clusters.List(fmt.Sprintf("projects/%s/locations/-", project))
+	clc.Context(ctx)
+
+	var response *container.ListClustersResponse
+	err = cs.caller.Call(ctx, project, func() error {
+		var err error
+		response, err = clc.Do()
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.MissingZones) > 0 {
+		return nil, fmt.Errorf("clusters data missing for zones: %v", response.MissingZones)
+	}
+
+	return response.Clusters, nil
+}
+
+func (cs *ContainerService) GetServerConfig(ctx context.Context, project string, location string) (config *container.ServerConfig, err error) {
+	ctx, span := tracing.Start(ctx, "container.serverConfig.get",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.location", location),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	err = cs.failIfInitialized()
+	if err != nil {
+		return nil, err
+	}
+
+	gscc := cs.service.Projects.Locations.GetServerConfig(fmt.Sprintf("projects/%s/locations/%s", project, location))
+	gscc.Context(ctx)
+
+	err = cs.caller.Call(ctx, project, func() error {
+		var err error
+		config, err = gscc.Do()
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+func (cs *ContainerService) failIfInitialized() error {
+	if cs.service != nil {
+		return nil
+	}
+
+	return fmt.Errorf("service not initialized")
+}
+
+func NewContainerService(client *http.Client) (*ContainerService, error) {
+	service, err := container.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := &ContainerService{
+		service: service,
+		caller:  DefaultAPICaller,
+	}
+
+	return cs, nil
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/container/v1"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tests"
+)
+
+func newTestContainerService(t *testing.T, api *tests.FakeAPI) *ContainerService {
+	cs, err := NewContainerService(&http.Client{})
+	require.NoError(t, err)
+
+	cs.service.BasePath = api.URL + "/"
+	cs.caller, _ = newTestAPICaller(&APICallPolicy{})
+
+	return cs
+}
+
+func TestContainerService_ListClusters(t *testing.T) {
+	api := tests.NewFakeAPI(t)
+	defer api.Close()
+
+	api.Handle(http.MethodGet, "/v1/projects/fake-project/locations/-/clusters", http.StatusOK, &container.ListClustersResponse{
+		Clusters: []*container.Cluster{
+			{Name: "cluster-1", Location: "us-east1"},
+			{Name: "cluster-2", Location: "us-east1-c"},
+		},
+	})
+
+	cs := newTestContainerService(t, api)
+
+	clusters, err := cs.ListClusters(context.Background(), "fake-project")
+	require.NoError(t, err)
+	require.Len(t, clusters, 2)
+	assert.Equal(t, "cluster-1", clusters[0].Name)
+	assert.Equal(t, "us-east1-c", clusters[1].Location)
+}
+
+func TestContainerService_ListClusters_missingZones(t *testing.T) {
+	api := tests.NewFakeAPI(t)
+	defer api.Close()
+
+	api.Handle(http.MethodGet, "/v1/projects/fake-project/locations/-/clusters", http.StatusOK, &container.ListClustersResponse{
+		MissingZones: []string{"us-east1-b"},
+	})
+
+	cs := newTestContainerService(t, api)
+
+	_, err := cs.ListClusters(context.Background(), "fake-project")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "clusters data missing for zones: [us-east1-b]")
+}
+
+func TestContainerService_GetServerConfig(t *testing.T) {
+	api := tests.NewFakeAPI(t)
+	defer api.Close()
+
+	api.Handle(http.MethodGet, "/v1/projects/fake-project/locations/us-east1/serverConfig", http.StatusOK, &container.ServerConfig{
+		DefaultClusterVersion: "1.27.3-gke.100",
+		Channels: []*container.ReleaseChannelConfig{
+			{Channel: "REGULAR", DefaultVersion: "1.27.3-gke.100"},
+		},
+	})
+
+	cs := newTestContainerService(t, api)
+
+	config, err := cs.GetServerConfig(context.Background(), "fake-project", "us-east1")
+	require.NoError(t, err)
+	assert.Equal(t, "1.27.3-gke.100", config.DefaultClusterVersion)
+	require.Len(t, config.Channels, 1)
+	assert.Equal(t, "REGULAR", config.Channels[0].Channel)
+}
+
+func TestContainerService_GetServerConfig_notFound(t *testing.T) {
+	api := tests.NewFakeAPI(t)
+	defer api.Close()
+
+	cs := newTestContainerService(t, api)
+
+	config, err := cs.GetServerConfig(context.Background(), "fake-project", "us-east1")
+	assert.Nil(t, config)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Error 404")
+}
+
+func TestContainerService_notInitialized(t *testing.T) {
+	cs := &ContainerService{}
+
+	_, err := cs.ListClusters(context.Background(), "fake-project")
+	assert.EqualError(t, err, "service not initialized")
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/api/iam/v1"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tracing"
+)
+
+const (
+	UserManagedKeyType = "USER_MANAGED"
+)
+
+type IAMServiceInterface interface {
+	ListServiceAccounts(ctx context.Context, project string, perPage int64) ([]*iam.ServiceAccount, error)
+	ListUserManagedKeys(ctx context.Context, project string, serviceAccount string) ([]*iam.ServiceAccountKey, error)
+}
+
+type IAMService struct {
+	service *iam.Service
+	caller  APICallerInterface
+}
+
+func (is *IAMService) ListServiceAccounts(ctx context.Context, project string, perPage int64) (accounts []*iam.ServiceAccount, err error) {
+	ctx, span := tracing.Start(ctx, "iam.serviceAccounts.list", attribute.String("gcp.project", project))
+	defer func() { tracing.End(span, err) }()
+
+	if is.service == nil {
+		return nil, fmt.Errorf("service not initialized")
+	}
+
+	accounts = make([]*iam.ServiceAccount, 0)
+
+	salc := is.service.Projects.ServiceAccounts.List("projects/" + project)
+	salc.PageSize(perPage)
+
+	err = listPages(ctx, is.caller, span, "iam.serviceAccounts.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := salc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		accounts = append(accounts, page.Accounts...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return accounts, nil
+}
+
+// ListUserManagedKeys lists user-managed keys of the service account given
+// with its full resource name
+func (is *IAMService) ListUserManagedKeys(ctx context.Context, project string, serviceAccount string) (keys []*iam.ServiceAccountKey, err error) {
+	ctx, span := tracing.Start(ctx, "iam.serviceAccountKeys.list",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.service_account", serviceAccount),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	if is.service == nil {
+		return nil, fmt.Errorf("service not initialized")
+	}
+
+	sklc := is.service.Projects.ServiceAccounts.Keys.List(serviceAccount)
+	sklc.KeyTypes(UserManagedKeyType)
+	sklc.Context(ctx)
+
+	var response *iam.ListServiceAccountKeysResponse
+	err = is.caller.Call(ctx, project, func() error {
+		var err error
+		response, err = sklc.Do()
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Keys, nil
+}
+
+func NewIAMService(client *http.Client) (*IAMService, error) {
+	service, err := iam.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	is := &IAMService{
+		service: service,
+		caller:  DefaultAPICaller,
+	}
+
+	return is, nil
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIAMService_ListServiceAccounts(t *testing.T) {
+	rt := &sequenceRoundTripper{
+		responses: []*http.Response{
+			newJSONResponse(http.StatusOK, `{"accounts": [{"email": "sa-1@fake-project.iam.gserviceaccount.com"}], "nextPageToken": "page-2"}`),
+			newJSONResponse(http.StatusOK, `{"accounts": [{"email": "sa-2@fake-project.iam.gserviceaccount.com"}]}`),
+		},
+	}
+
+	is, err := NewIAMService(&http.Client{Transport: rt})
+	require.NoError(t, err)
+	is.caller, _ = newTestAPICaller(&APICallPolicy{})
+
+	accounts, err := is.ListServiceAccounts(context.Background(), "fake-project", 1)
+	require.NoError(t, err)
+	require.Len(t, accounts, 2)
+	assert.Equal(t, "sa-1@fake-project.iam.gserviceaccount.com", accounts[0].Email)
+	assert.Equal(t, "sa-2@fake-project.iam.gserviceaccount.com", accounts[1].Email)
+
+	require.Len(t, rt.requests, 2)
+	assert.Equal(t, "/v1/projects/fake-project/serviceAccounts", rt.requests[0].URL.Path)
+	assert.Equal(t, "page-2", rt.requests[1].URL.Query().Get("pageToken"))
+}
+
+func TestIAMService_ListUserManagedKeys(t *testing.T) {
+	rt := &sequenceRoundTripper{
+		responses: []*http.Response{
+			newJSONResponse(http.StatusOK, `{"keys": [{"name": "key-1", "keyType": "USER_MANAGED"}]}`),
+		},
+	}
+
+	is, err := NewIAMService(&http.Client{Transport: rt})
+	require.NoError(t, err)
+	is.caller, _ = newTestAPICaller(&APICallPolicy{})
+
+	serviceAccount := "projects/fake-project/serviceAccounts/sa-1@fake-project.iam.gserviceaccount.com"
+
+	keys, err := is.ListUserManagedKeys(context.Background(), "fake-project", serviceAccount)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, "key-1", keys[0].Name)
+
+	require.Len(t, rt.requests, 1)
+	assert.Equal(t, "/v1/"+serviceAccount+"/keys", rt.requests[0].URL.Path)
+	assert.Equal(t, []string{"USER_MANAGED"}, rt.requests[0].URL.Query()["keyTypes"])
+}
+
+func TestIAMService_notInitialized(t *testing.T) {
+	is := &IAMService{caller: DefaultAPICaller}
+
+	_, err := is.ListServiceAccounts(context.Background(), "fake-project", 10)
+	assert.EqualError(t, err, "service not initialized")
+
+	_, err = is.ListUserManagedKeys(context.Background(), "fake-project", "fake-service-account")
+	assert.EqualError(t, err, "service not initialized")
+}
@@ -0,0 +1,27 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package services
+
+import context "context"
+import mock "github.com/stretchr/testify/mock"
+
+// MockAPICallerInterface is an autogenerated mock type for the APICallerInterface type
+type MockAPICallerInterface struct {
+	mock.Mock
+}
+
+// Call provides a mock function with given fields: ctx, project, call
+func (_m *MockAPICallerInterface) Call(ctx context.Context, project string, call func() error) error {
+	ret := _m.Called(ctx, project, call)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, func() error) error); ok {
+		r0 = rf(ctx, project, call)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -0,0 +1,36 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package services
+
+import context "context"
+import mock "github.com/stretchr/testify/mock"
+
+// MockBigQueryServiceInterface is an autogenerated mock type for the BigQueryServiceInterface type
+type MockBigQueryServiceInterface struct {
+	mock.Mock
+}
+
+// Query provides a mock function with given fields: ctx, project, query, perPage
+func (_m *MockBigQueryServiceInterface) Query(ctx context.Context, project string, query string, perPage int64) ([]map[string]string, error) {
+	ret := _m.Called(ctx, project, query, perPage)
+
+	var r0 []map[string]string
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) []map[string]string); ok {
+		r0 = rf(ctx, project, query, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]map[string]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, project, query, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,37 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package services
+
+import cloudasset "google.golang.org/api/cloudasset/v1"
+import context "context"
+import mock "github.com/stretchr/testify/mock"
+
+// MockCloudAssetServiceInterface is an autogenerated mock type for the CloudAssetServiceInterface type
+type MockCloudAssetServiceInterface struct {
+	mock.Mock
+}
+
+// SearchAllResources provides a mock function with given fields: ctx, scope, assetTypes, query, perPage
+func (_m *MockCloudAssetServiceInterface) SearchAllResources(ctx context.Context, scope string, assetTypes []string, query string, perPage int64) ([]*cloudasset.ResourceSearchResult, error) {
+	ret := _m.Called(ctx, scope, assetTypes, query, perPage)
+
+	var r0 []*cloudasset.ResourceSearchResult
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, string, int64) []*cloudasset.ResourceSearchResult); ok {
+		r0 = rf(ctx, scope, assetTypes, query, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*cloudasset.ResourceSearchResult)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, string, int64) error); ok {
+		r1 = rf(ctx, scope, assetTypes, query, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
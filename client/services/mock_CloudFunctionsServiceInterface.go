@@ -0,0 +1,37 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package services
+
+import cloudfunctions "google.golang.org/api/cloudfunctions/v2"
+import context "context"
+import mock "github.com/stretchr/testify/mock"
+
+// MockCloudFunctionsServiceInterface is an autogenerated mock type for the CloudFunctionsServiceInterface type
+type MockCloudFunctionsServiceInterface struct {
+	mock.Mock
+}
+
+// ListFunctions provides a mock function with given fields: ctx, project, region, perPage
+func (_m *MockCloudFunctionsServiceInterface) ListFunctions(ctx context.Context, project string, region string, perPage int64) ([]*cloudfunctions.Function, error) {
+	ret := _m.Called(ctx, project, region, perPage)
+
+	var r0 []*cloudfunctions.Function
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) []*cloudfunctions.Function); ok {
+		r0 = rf(ctx, project, region, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*cloudfunctions.Function)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, project, region, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
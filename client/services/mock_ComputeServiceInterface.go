@@ -13,6 +13,52 @@ type MockComputeServiceInterface struct {
 	mock.Mock
 }
 
+// GetBackendServiceHealth provides a mock function with given fields: ctx, project, backendService, group
+func (_m *MockComputeServiceInterface) GetBackendServiceHealth(ctx context.Context, project string, backendService string, group string) (*compute.BackendServiceGroupHealth, error) {
+	ret := _m.Called(ctx, project, backendService, group)
+
+	var r0 *compute.BackendServiceGroupHealth
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *compute.BackendServiceGroupHealth); ok {
+		r0 = rf(ctx, project, backendService, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*compute.BackendServiceGroupHealth)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, project, backendService, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetInstanceGroupManager provides a mock function with given fields: ctx, project, zone, name
+func (_m *MockComputeServiceInterface) GetInstanceGroupManager(ctx context.Context, project string, zone string, name string) (*compute.InstanceGroupManager, error) {
+	ret := _m.Called(ctx, project, zone, name)
+
+	var r0 *compute.InstanceGroupManager
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) *compute.InstanceGroupManager); ok {
+		r0 = rf(ctx, project, zone, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*compute.InstanceGroupManager)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, project, zone, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetRegion provides a mock function with given fields: ctx, project, region
 func (_m *MockComputeServiceInterface) GetRegion(ctx context.Context, project string, region string) (*compute.Region, error) {
 	ret := _m.Called(ctx, project, region)
@@ -36,6 +82,259 @@ func (_m *MockComputeServiceInterface) GetRegion(ctx context.Context, project st
 	return r0, r1
 }
 
+// GetRegionBackendServiceHealth provides a mock function with given fields: ctx, project, region, backendService, group
+func (_m *MockComputeServiceInterface) GetRegionBackendServiceHealth(ctx context.Context, project string, region string, backendService string, group string) (*compute.BackendServiceGroupHealth, error) {
+	ret := _m.Called(ctx, project, region, backendService, group)
+
+	var r0 *compute.BackendServiceGroupHealth
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, string) *compute.BackendServiceGroupHealth); ok {
+		r0 = rf(ctx, project, region, backendService, group)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*compute.BackendServiceGroupHealth)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, string) error); ok {
+		r1 = rf(ctx, project, region, backendService, group)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAddresses provides a mock function with given fields: ctx, project, region, perPage
+func (_m *MockComputeServiceInterface) ListAddresses(ctx context.Context, project string, region string, perPage int64) ([]*compute.Address, error) {
+	ret := _m.Called(ctx, project, region, perPage)
+
+	var r0 []*compute.Address
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) []*compute.Address); ok {
+		r0 = rf(ctx, project, region, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*compute.Address)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, project, region, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAutoscalers provides a mock function with given fields: ctx, project, zone, perPage
+func (_m *MockComputeServiceInterface) ListAutoscalers(ctx context.Context, project string, zone string, perPage int64) ([]*compute.Autoscaler, error) {
+	ret := _m.Called(ctx, project, zone, perPage)
+
+	var r0 []*compute.Autoscaler
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) []*compute.Autoscaler); ok {
+		r0 = rf(ctx, project, zone, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*compute.Autoscaler)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, project, zone, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListBackendServices provides a mock function with given fields: ctx, project, perPage
+func (_m *MockComputeServiceInterface) ListBackendServices(ctx context.Context, project string, perPage int64) ([]*compute.BackendService, error) {
+	ret := _m.Called(ctx, project, perPage)
+
+	var r0 []*compute.BackendService
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []*compute.BackendService); ok {
+		r0 = rf(ctx, project, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*compute.BackendService)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, project, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListDisks provides a mock function with given fields: ctx, project, zone, perPage
+func (_m *MockComputeServiceInterface) ListDisks(ctx context.Context, project string, zone string, perPage int64) ([]*compute.Disk, error) {
+	ret := _m.Called(ctx, project, zone, perPage)
+
+	var r0 []*compute.Disk
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) []*compute.Disk); ok {
+		r0 = rf(ctx, project, zone, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*compute.Disk)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, project, zone, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListFirewalls provides a mock function with given fields: ctx, project, perPage
+func (_m *MockComputeServiceInterface) ListFirewalls(ctx context.Context, project string, perPage int64) ([]*compute.Firewall, error) {
+	ret := _m.Called(ctx, project, perPage)
+
+	var r0 []*compute.Firewall
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []*compute.Firewall); ok {
+		r0 = rf(ctx, project, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*compute.Firewall)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, project, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListForwardingRules provides a mock function with given fields: ctx, project, region, perPage
+func (_m *MockComputeServiceInterface) ListForwardingRules(ctx context.Context, project string, region string, perPage int64) ([]*compute.ForwardingRule, error) {
+	ret := _m.Called(ctx, project, region, perPage)
+
+	var r0 []*compute.ForwardingRule
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) []*compute.ForwardingRule); ok {
+		r0 = rf(ctx, project, region, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*compute.ForwardingRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, project, region, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListGlobalAddresses provides a mock function with given fields: ctx, project, perPage
+func (_m *MockComputeServiceInterface) ListGlobalAddresses(ctx context.Context, project string, perPage int64) ([]*compute.Address, error) {
+	ret := _m.Called(ctx, project, perPage)
+
+	var r0 []*compute.Address
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []*compute.Address); ok {
+		r0 = rf(ctx, project, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*compute.Address)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, project, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListGlobalForwardingRules provides a mock function with given fields: ctx, project, perPage
+func (_m *MockComputeServiceInterface) ListGlobalForwardingRules(ctx context.Context, project string, perPage int64) ([]*compute.ForwardingRule, error) {
+	ret := _m.Called(ctx, project, perPage)
+
+	var r0 []*compute.ForwardingRule
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []*compute.ForwardingRule); ok {
+		r0 = rf(ctx, project, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*compute.ForwardingRule)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, project, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListInstanceGroupManagers provides a mock function with given fields: ctx, project, zone, perPage
+func (_m *MockComputeServiceInterface) ListInstanceGroupManagers(ctx context.Context, project string, zone string, perPage int64) ([]*compute.InstanceGroupManager, error) {
+	ret := _m.Called(ctx, project, zone, perPage)
+
+	var r0 []*compute.InstanceGroupManager
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) []*compute.InstanceGroupManager); ok {
+		r0 = rf(ctx, project, zone, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*compute.InstanceGroupManager)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, project, zone, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListInstanceGroups provides a mock function with given fields: ctx, project, zone, perPage
+func (_m *MockComputeServiceInterface) ListInstanceGroups(ctx context.Context, project string, zone string, perPage int64) ([]*compute.InstanceGroup, error) {
+	ret := _m.Called(ctx, project, zone, perPage)
+
+	var r0 []*compute.InstanceGroup
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) []*compute.InstanceGroup); ok {
+		r0 = rf(ctx, project, zone, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*compute.InstanceGroup)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, project, zone, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ListInstances provides a mock function with given fields: ctx, project, zone, perPage
 func (_m *MockComputeServiceInterface) ListInstances(ctx context.Context, project string, zone string, perPage int64) ([]*compute.Instance, error) {
 	ret := _m.Called(ctx, project, zone, perPage)
@@ -58,3 +357,118 @@ func (_m *MockComputeServiceInterface) ListInstances(ctx context.Context, projec
 
 	return r0, r1
 }
+
+// ListRegionAutoscalers provides a mock function with given fields: ctx, project, region, perPage
+func (_m *MockComputeServiceInterface) ListRegionAutoscalers(ctx context.Context, project string, region string, perPage int64) ([]*compute.Autoscaler, error) {
+	ret := _m.Called(ctx, project, region, perPage)
+
+	var r0 []*compute.Autoscaler
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) []*compute.Autoscaler); ok {
+		r0 = rf(ctx, project, region, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*compute.Autoscaler)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, project, region, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRegionBackendServices provides a mock function with given fields: ctx, project, region, perPage
+func (_m *MockComputeServiceInterface) ListRegionBackendServices(ctx context.Context, project string, region string, perPage int64) ([]*compute.BackendService, error) {
+	ret := _m.Called(ctx, project, region, perPage)
+
+	var r0 []*compute.BackendService
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) []*compute.BackendService); ok {
+		r0 = rf(ctx, project, region, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*compute.BackendService)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, project, region, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRegionInstanceGroupManagers provides a mock function with given fields: ctx, project, region, perPage
+func (_m *MockComputeServiceInterface) ListRegionInstanceGroupManagers(ctx context.Context, project string, region string, perPage int64) ([]*compute.InstanceGroupManager, error) {
+	ret := _m.Called(ctx, project, region, perPage)
+
+	var r0 []*compute.InstanceGroupManager
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) []*compute.InstanceGroupManager); ok {
+		r0 = rf(ctx, project, region, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*compute.InstanceGroupManager)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, project, region, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRegionInstanceGroups provides a mock function with given fields: ctx, project, region, perPage
+func (_m *MockComputeServiceInterface) ListRegionInstanceGroups(ctx context.Context, project string, region string, perPage int64) ([]*compute.InstanceGroup, error) {
+	ret := _m.Called(ctx, project, region, perPage)
+
+	var r0 []*compute.InstanceGroup
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) []*compute.InstanceGroup); ok {
+		r0 = rf(ctx, project, region, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*compute.InstanceGroup)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, project, region, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSubnetworks provides a mock function with given fields: ctx, project, region, perPage
+func (_m *MockComputeServiceInterface) ListSubnetworks(ctx context.Context, project string, region string, perPage int64) ([]*compute.Subnetwork, error) {
+	ret := _m.Called(ctx, project, region, perPage)
+
+	var r0 []*compute.Subnetwork
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) []*compute.Subnetwork); ok {
+		r0 = rf(ctx, project, region, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*compute.Subnetwork)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, project, region, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
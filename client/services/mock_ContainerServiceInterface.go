@@ -0,0 +1,60 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package services
+
+import container "google.golang.org/api/container/v1"
+import context "context"
+import mock "github.com/stretchr/testify/mock"
+
+// MockContainerServiceInterface is an autogenerated mock type for the ContainerServiceInterface type
+type MockContainerServiceInterface struct {
+	mock.Mock
+}
+
+// GetServerConfig provides a mock function with given fields: ctx, project, location
+func (_m *MockContainerServiceInterface) GetServerConfig(ctx context.Context, project string, location string) (*container.ServerConfig, error) {
+	ret := _m.Called(ctx, project, location)
+
+	var r0 *container.ServerConfig
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *container.ServerConfig); ok {
+		r0 = rf(ctx, project, location)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*container.ServerConfig)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, project, location)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListClusters provides a mock function with given fields: ctx, project
+func (_m *MockContainerServiceInterface) ListClusters(ctx context.Context, project string) ([]*container.Cluster, error) {
+	ret := _m.Called(ctx, project)
+
+	var r0 []*container.Cluster
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*container.Cluster); ok {
+		r0 = rf(ctx, project)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*container.Cluster)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, project)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,60 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package services
+
+import context "context"
+import iam "google.golang.org/api/iam/v1"
+import mock "github.com/stretchr/testify/mock"
+
+// MockIAMServiceInterface is an autogenerated mock type for the IAMServiceInterface type
+type MockIAMServiceInterface struct {
+	mock.Mock
+}
+
+// ListServiceAccounts provides a mock function with given fields: ctx, project, perPage
+func (_m *MockIAMServiceInterface) ListServiceAccounts(ctx context.Context, project string, perPage int64) ([]*iam.ServiceAccount, error) {
+	ret := _m.Called(ctx, project, perPage)
+
+	var r0 []*iam.ServiceAccount
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []*iam.ServiceAccount); ok {
+		r0 = rf(ctx, project, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*iam.ServiceAccount)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, project, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUserManagedKeys provides a mock function with given fields: ctx, project, serviceAccount
+func (_m *MockIAMServiceInterface) ListUserManagedKeys(ctx context.Context, project string, serviceAccount string) ([]*iam.ServiceAccountKey, error) {
+	ret := _m.Called(ctx, project, serviceAccount)
+
+	var r0 []*iam.ServiceAccountKey
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) []*iam.ServiceAccountKey); ok {
+		r0 = rf(ctx, project, serviceAccount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*iam.ServiceAccountKey)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, project, serviceAccount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,37 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package services
+
+import context "context"
+import mock "github.com/stretchr/testify/mock"
+import monitoring "google.golang.org/api/monitoring/v3"
+
+// MockMonitoringServiceInterface is an autogenerated mock type for the MonitoringServiceInterface type
+type MockMonitoringServiceInterface struct {
+	mock.Mock
+}
+
+// ListTimeSeries provides a mock function with given fields: ctx, project, query, perPage
+func (_m *MockMonitoringServiceInterface) ListTimeSeries(ctx context.Context, project string, query TimeSeriesQuery, perPage int64) ([]*monitoring.TimeSeries, error) {
+	ret := _m.Called(ctx, project, query, perPage)
+
+	var r0 []*monitoring.TimeSeries
+	if rf, ok := ret.Get(0).(func(context.Context, string, TimeSeriesQuery, int64) []*monitoring.TimeSeries); ok {
+		r0 = rf(ctx, project, query, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*monitoring.TimeSeries)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, TimeSeriesQuery, int64) error); ok {
+		r1 = rf(ctx, project, query, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,60 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package services
+
+import context "context"
+import mock "github.com/stretchr/testify/mock"
+import pubsub "google.golang.org/api/pubsub/v1"
+
+// MockPubSubServiceInterface is an autogenerated mock type for the PubSubServiceInterface type
+type MockPubSubServiceInterface struct {
+	mock.Mock
+}
+
+// ListSubscriptions provides a mock function with given fields: ctx, project, perPage
+func (_m *MockPubSubServiceInterface) ListSubscriptions(ctx context.Context, project string, perPage int64) ([]*pubsub.Subscription, error) {
+	ret := _m.Called(ctx, project, perPage)
+
+	var r0 []*pubsub.Subscription
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []*pubsub.Subscription); ok {
+		r0 = rf(ctx, project, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*pubsub.Subscription)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, project, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTopics provides a mock function with given fields: ctx, project, perPage
+func (_m *MockPubSubServiceInterface) ListTopics(ctx context.Context, project string, perPage int64) ([]*pubsub.Topic, error) {
+	ret := _m.Called(ctx, project, perPage)
+
+	var r0 []*pubsub.Topic
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []*pubsub.Topic); ok {
+		r0 = rf(ctx, project, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*pubsub.Topic)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, project, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,106 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package services
+
+import cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
+import context "context"
+import mock "github.com/stretchr/testify/mock"
+
+// MockResourceManagerServiceInterface is an autogenerated mock type for the ResourceManagerServiceInterface type
+type MockResourceManagerServiceInterface struct {
+	mock.Mock
+}
+
+// GetIamPolicy provides a mock function with given fields: ctx, project
+func (_m *MockResourceManagerServiceInterface) GetIamPolicy(ctx context.Context, project string) (*cloudresourcemanager.Policy, error) {
+	ret := _m.Called(ctx, project)
+
+	var r0 *cloudresourcemanager.Policy
+	if rf, ok := ret.Get(0).(func(context.Context, string) *cloudresourcemanager.Policy); ok {
+		r0 = rf(ctx, project)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*cloudresourcemanager.Policy)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, project)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TestFolderIamPermissions provides a mock function with given fields: ctx, folder, permissions
+func (_m *MockResourceManagerServiceInterface) TestFolderIamPermissions(ctx context.Context, folder string, permissions []string) ([]string, error) {
+	ret := _m.Called(ctx, folder, permissions)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) []string); ok {
+		r0 = rf(ctx, folder, permissions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, folder, permissions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TestIamPermissions provides a mock function with given fields: ctx, project, permissions
+func (_m *MockResourceManagerServiceInterface) TestIamPermissions(ctx context.Context, project string, permissions []string) ([]string, error) {
+	ret := _m.Called(ctx, project, permissions)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) []string); ok {
+		r0 = rf(ctx, project, permissions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, project, permissions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TestOrganizationIamPermissions provides a mock function with given fields: ctx, organization, permissions
+func (_m *MockResourceManagerServiceInterface) TestOrganizationIamPermissions(ctx context.Context, organization string, permissions []string) ([]string, error) {
+	ret := _m.Called(ctx, organization, permissions)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) []string); ok {
+		r0 = rf(ctx, organization, permissions)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, organization, permissions)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,60 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package services
+
+import context "context"
+import mock "github.com/stretchr/testify/mock"
+import run "google.golang.org/api/run/v2"
+
+// MockRunServiceInterface is an autogenerated mock type for the RunServiceInterface type
+type MockRunServiceInterface struct {
+	mock.Mock
+}
+
+// GetRevision provides a mock function with given fields: ctx, project, name
+func (_m *MockRunServiceInterface) GetRevision(ctx context.Context, project string, name string) (*run.GoogleCloudRunV2Revision, error) {
+	ret := _m.Called(ctx, project, name)
+
+	var r0 *run.GoogleCloudRunV2Revision
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *run.GoogleCloudRunV2Revision); ok {
+		r0 = rf(ctx, project, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*run.GoogleCloudRunV2Revision)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, project, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListServices provides a mock function with given fields: ctx, project, region, perPage
+func (_m *MockRunServiceInterface) ListServices(ctx context.Context, project string, region string, perPage int64) ([]*run.GoogleCloudRunV2Service, error) {
+	ret := _m.Called(ctx, project, region, perPage)
+
+	var r0 []*run.GoogleCloudRunV2Service
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, int64) []*run.GoogleCloudRunV2Service); ok {
+		r0 = rf(ctx, project, region, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*run.GoogleCloudRunV2Service)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, int64) error); ok {
+		r1 = rf(ctx, project, region, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
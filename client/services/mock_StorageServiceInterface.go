@@ -0,0 +1,37 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package services
+
+import context "context"
+import mock "github.com/stretchr/testify/mock"
+import storage "google.golang.org/api/storage/v1"
+
+// MockStorageServiceInterface is an autogenerated mock type for the StorageServiceInterface type
+type MockStorageServiceInterface struct {
+	mock.Mock
+}
+
+// ListBuckets provides a mock function with given fields: ctx, project, perPage
+func (_m *MockStorageServiceInterface) ListBuckets(ctx context.Context, project string, perPage int64) ([]*storage.Bucket, error) {
+	ret := _m.Called(ctx, project, perPage)
+
+	var r0 []*storage.Bucket
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64) []*storage.Bucket); ok {
+		r0 = rf(ctx, project, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*storage.Bucket)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64) error); ok {
+		r1 = rf(ctx, project, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
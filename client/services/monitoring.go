@@ -0,0 +1,95 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/api/monitoring/v3"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tracing"
+)
+
+type TimeSeriesQuery struct {
+	Filter          string
+	StartTime       time.Time
+	EndTime         time.Time
+	AlignmentPeriod time.Duration
+	Aligner         string
+	Reducer         string
+	GroupBy         []string
+}
+
+type MonitoringServiceInterface interface {
+	ListTimeSeries(ctx context.Context, project string, query TimeSeriesQuery, perPage int64) ([]*monitoring.TimeSeries, error)
+}
+
+type MonitoringService struct {
+	service *monitoring.Service
+	caller  APICallerInterface
+}
+
+func (ms *MonitoringService) ListTimeSeries(ctx context.Context, project string, query TimeSeriesQuery, perPage int64) (timeSeries []*monitoring.TimeSeries, err error) {
+	ctx, span := tracing.Start(ctx, "monitoring.timeSeries.list", attribute.String("gcp.project", project))
+	defer func() { tracing.End(span, err) }()
+
+	if ms.service == nil {
+		return nil, fmt.Errorf("service not initialized")
+	}
+
+	timeSeries = make([]*monitoring.TimeSeries, 0)
+
+	tslc := ms.service.Projects.TimeSeries.List("projects/" + project)
+	tslc.Filter(query.Filter)
+	tslc.IntervalStartTime(query.StartTime.UTC().Format(time.RFC3339))
+	tslc.IntervalEndTime(query.EndTime.UTC().Format(time.RFC3339))
+	tslc.AggregationAlignmentPeriod(fmt.Sprintf("%ds", int64(query.AlignmentPeriod/time.Second)))
+	tslc.AggregationPerSeriesAligner(query.Aligner)
+	if query.Reducer != "" {
+		tslc.AggregationCrossSeriesReducer(query.Reducer)
+		tslc.AggregationGroupByFields(query.GroupBy...)
+	}
+	tslc.PageSize(perPage)
+
+	err = listPages(ctx, ms.caller, span, "monitoring.timeSeries.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := tslc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		if len(page.ExecutionErrors) > 0 {
+			messages := make([]string, 0)
+			for _, status := range page.ExecutionErrors {
+				messages = append(messages, status.Message)
+			}
+
+			return "", fmt.Errorf("time series data incomplete: %s", strings.Join(messages, "; "))
+		}
+
+		timeSeries = append(timeSeries, page.TimeSeries...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return timeSeries, nil
+}
+
+func NewMonitoringService(client *http.Client) (*MonitoringService, error) {
+	service, err := monitoring.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	ms := &MonitoringService{
+		service: service,
+		caller:  DefaultAPICaller,
+	}
+
+	return ms, nil
+}
@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/monitoring/v3"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tests"
+)
+
+func newTestMonitoringService(t *testing.T, api *tests.FakeAPI) *MonitoringService {
+	ms, err := NewMonitoringService(&http.Client{})
+	require.NoError(t, err)
+
+	ms.service.BasePath = api.URL + "/"
+	ms.caller, _ = newTestAPICaller(&APICallPolicy{})
+
+	return ms
+}
+
+func TestMonitoringService_ListTimeSeries(t *testing.T) {
+	api := tests.NewFakeAPI(t)
+	defer api.Close()
+
+	value := 0.25
+	api.Handle(http.MethodGet, "/v3/projects/fake-project/timeSeries", http.StatusOK, &monitoring.ListTimeSeriesResponse{
+		TimeSeries: []*monitoring.TimeSeries{
+			{
+				Resource: &monitoring.MonitoredResource{Labels: map[string]string{"zone": "us-east1-c"}},
+				Points:   []*monitoring.Point{{Value: &monitoring.TypedValue{DoubleValue: &value}}},
+			},
+		},
+	})
+
+	ms := newTestMonitoringService(t, api)
+
+	end := time.Date(2026, 10, 19, 12, 5, 0, 0, time.UTC)
+	query := TimeSeriesQuery{
+		Filter:          `metric.type = "compute.googleapis.com/instance/cpu/utilization"`,
+		StartTime:       end.Add(-5 * time.Minute),
+		EndTime:         end,
+		AlignmentPeriod: 5 * time.Minute,
+		Aligner:         "ALIGN_MEAN",
+		Reducer:         "REDUCE_MEAN",
+		GroupBy:         []string{"resource.labels.zone"},
+	}
+
+	timeSeries, err := ms.ListTimeSeries(context.Background(), "fake-project", query, 100)
+	require.NoError(t, err)
+	require.Len(t, timeSeries, 1)
+	assert.Equal(t, "us-east1-c", timeSeries[0].Resource.Labels["zone"])
+
+	requests := api.Requests()
+	require.Len(t, requests, 1)
+
+	params := requests[0].URL.Query()
+	assert.Equal(t, query.Filter, params.Get("filter"))
+	assert.Equal(t, "2026-10-19T12:00:00Z", params.Get("interval.startTime"))
+	assert.Equal(t, "2026-10-19T12:05:00Z", params.Get("interval.endTime"))
+	assert.Equal(t, "300s", params.Get("aggregation.alignmentPeriod"))
+	assert.Equal(t, "ALIGN_MEAN", params.Get("aggregation.perSeriesAligner"))
+	assert.Equal(t, "REDUCE_MEAN", params.Get("aggregation.crossSeriesReducer"))
+	assert.Equal(t, []string{"resource.labels.zone"}, params["aggregation.groupByFields"])
+	assert.Equal(t, "100", params.Get("pageSize"))
+}
+
+func TestMonitoringService_ListTimeSeries_executionErrors(t *testing.T) {
+	api := tests.NewFakeAPI(t)
+	defer api.Close()
+
+	api.Handle(http.MethodGet, "/v3/projects/fake-project/timeSeries", http.StatusOK, &monitoring.ListTimeSeriesResponse{
+		ExecutionErrors: []*monitoring.Status{{Message: "fake-execution-error"}},
+	})
+
+	ms := newTestMonitoringService(t, api)
+
+	_, err := ms.ListTimeSeries(context.Background(), "fake-project", TimeSeriesQuery{AlignmentPeriod: time.Minute}, 100)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "time series data incomplete: fake-execution-error")
+}
+
+func TestMonitoringService_notInitialized(t *testing.T) {
+	ms := &MonitoringService{}
+
+	_, err := ms.ListTimeSeries(context.Background(), "fake-project", TimeSeriesQuery{}, 100)
+	assert.EqualError(t, err, "service not initialized")
+}
@@ -0,0 +1,41 @@
+package services
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tracing"
+)
+
+func listPages(ctx context.Context, caller APICallerInterface, span trace.Span, pageSpanName string, project string, fetchPage func(ctx context.Context, pageToken string) (string, error)) error {
+	pageToken := ""
+	for pageNumber := 1; ; pageNumber++ {
+		nextPageToken, err := listPage(ctx, caller, pageSpanName, project, pageToken, pageNumber, fetchPage)
+		if err != nil {
+			return err
+		}
+
+		if nextPageToken == "" {
+			span.SetAttributes(attribute.Int("gcp.pages", pageNumber))
+			return nil
+		}
+
+		pageToken = nextPageToken
+	}
+}
+
+func listPage(ctx context.Context, caller APICallerInterface, pageSpanName string, project string, pageToken string, pageNumber int, fetchPage func(ctx context.Context, pageToken string) (string, error)) (nextPageToken string, err error) {
+	ctx, span := tracing.Start(ctx, pageSpanName, attribute.Int("gcp.page", pageNumber))
+	defer func() { tracing.End(span, err) }()
+
+	err = caller.Call(ctx, project, func() error {
+		var err error
+		nextPageToken, err = fetchPage(ctx, pageToken)
+
+		return err
+	})
+
+	return nextPageToken, err
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/api/pubsub/v1"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tracing"
+)
+
+type PubSubServiceInterface interface {
+	ListTopics(ctx context.Context, project string, perPage int64) ([]*pubsub.Topic, error)
+	ListSubscriptions(ctx context.Context, project string, perPage int64) ([]*pubsub.Subscription, error)
+}
+
+type PubSubService struct {
+	service *pubsub.Service
+	caller  APICallerInterface
+}
+
+func (ps *PubSubService) ListTopics(ctx context.Context, project string, perPage int64) (topics []*pubsub.Topic, err error) {
+	ctx, span := tracing.Start(ctx, "pubsub.topics.list", attribute.String("gcp.project", project))
+	defer func() { tracing.End(span, err) }()
+
+	if ps.service == nil {
+		return nil, fmt.Errorf("service not initialized")
+	}
+
+	topics = make([]*pubsub.Topic, 0)
+
+	tlc := ps.service.Projects.Topics.List("projects/" + project)
+	tlc.PageSize(perPage)
+
+	err = listPages(ctx, ps.caller, span, "pubsub.topics.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := tlc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		topics = append(topics, page.Topics...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return topics, nil
+}
+
+func (ps *PubSubService) ListSubscriptions(ctx context.Context, project string, perPage int64) (subscriptions []*pubsub.Subscription, err error) {
+	ctx, span := tracing.Start(ctx, "pubsub.subscriptions.list", attribute.String("gcp.project", project))
+	defer func() { tracing.End(span, err) }()
+
+	if ps.service == nil {
+		return nil, fmt.Errorf("service not initialized")
+	}
+
+	subscriptions = make([]*pubsub.Subscription, 0)
+
+	slc := ps.service.Projects.Subscriptions.List("projects/" + project)
+	slc.PageSize(perPage)
+
+	err = listPages(ctx, ps.caller, span, "pubsub.subscriptions.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := slc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		subscriptions = append(subscriptions, page.Subscriptions...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return subscriptions, nil
+}
+
+func NewPubSubService(client *http.Client) (*PubSubService, error) {
+	service, err := pubsub.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	ps := &PubSubService{
+		service: service,
+		caller:  DefaultAPICaller,
+	}
+
+	return ps, nil
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPubSubService_ListTopics(t *testing.T) {
+	rt := &sequenceRoundTripper{
+		responses: []*http.Response{
+			newJSONResponse(http.StatusOK, `{"topics": [{"name": "projects/fake-project/topics/topic-1"}], "nextPageToken": "page-2"}`),
+			newJSONResponse(http.StatusOK, `{"topics": [{"name": "projects/fake-project/topics/topic-2"}]}`),
+		},
+	}
+
+	ps, err := NewPubSubService(&http.Client{Transport: rt})
+	require.NoError(t, err)
+	ps.caller, _ = newTestAPICaller(&APICallPolicy{})
+
+	topics, err := ps.ListTopics(context.Background(), "fake-project", 1)
+	require.NoError(t, err)
+	require.Len(t, topics, 2)
+	assert.Equal(t, "projects/fake-project/topics/topic-1", topics[0].Name)
+	assert.Equal(t, "projects/fake-project/topics/topic-2", topics[1].Name)
+
+	require.Len(t, rt.requests, 2)
+	assert.Equal(t, "/v1/projects/fake-project/topics", rt.requests[0].URL.Path)
+	assert.Equal(t, "page-2", rt.requests[1].URL.Query().Get("pageToken"))
+}
+
+func TestPubSubService_ListSubscriptions(t *testing.T) {
+	rt := &sequenceRoundTripper{
+		responses: []*http.Response{
+			newJSONResponse(http.StatusOK, `{"subscriptions": [{"name": "projects/fake-project/subscriptions/sub-1"}], "nextPageToken": "page-2"}`),
+			newJSONResponse(http.StatusOK, `{"subscriptions": [{"name": "projects/fake-project/subscriptions/sub-2"}]}`),
+		},
+	}
+
+	ps, err := NewPubSubService(&http.Client{Transport: rt})
+	require.NoError(t, err)
+	ps.caller, _ = newTestAPICaller(&APICallPolicy{})
+
+	subscriptions, err := ps.ListSubscriptions(context.Background(), "fake-project", 1)
+	require.NoError(t, err)
+	require.Len(t, subscriptions, 2)
+	assert.Equal(t, "projects/fake-project/subscriptions/sub-1", subscriptions[0].Name)
+	assert.Equal(t, "projects/fake-project/subscriptions/sub-2", subscriptions[1].Name)
+
+	require.Len(t, rt.requests, 2)
+	assert.Equal(t, "/v1/projects/fake-project/subscriptions", rt.requests[0].URL.Path)
+	assert.Equal(t, "page-2", rt.requests[1].URL.Query().Get("pageToken"))
+}
+
+func TestPubSubService_notInitialized(t *testing.T) {
+	ps := &PubSubService{caller: DefaultAPICaller}
+
+	_, err := ps.ListTopics(context.Background(), "fake-project", 10)
+	assert.EqualError(t, err, "service not initialized")
+
+	_, err = ps.ListSubscriptions(context.Background(), "fake-project", 10)
+	assert.EqualError(t, err, "service not initialized")
+}
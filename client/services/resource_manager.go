@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/api/cloudresourcemanager/v1"
+	cloudresourcemanagerv2 "google.golang.org/api/cloudresourcemanager/v2"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tracing"
+)
+
+type ResourceManagerServiceInterface interface {
+	TestIamPermissions(ctx context.Context, project string, permissions []string) ([]string, error)
+	TestFolderIamPermissions(ctx context.Context, folder string, permissions []string) ([]string, error)
+	TestOrganizationIamPermissions(ctx context.Context, organization string, permissions []string) ([]string, error)
+	GetIamPolicy(ctx context.Context, project string) (*cloudresourcemanager.Policy, error)
+}
+
+type ResourceManagerService struct {
+	service        *cloudresourcemanager.Service
+	foldersService *cloudresourcemanagerv2.Service
+	caller         APICallerInterface
+}
+
+func (rms *ResourceManagerService) TestIamPermissions(ctx context.Context, project string, permissions []string) (granted []string, err error) {
+	ctx, span := tracing.Start(ctx, "cloudresourcemanager.projects.testIamPermissions", attribute.String("gcp.project", project))
+	defer func() { tracing.End(span, err) }()
+
+	if rms.service == nil {
+		return nil, fmt.Errorf("service not initialized")
+	}
+
+	request := &cloudresourcemanager.TestIamPermissionsRequest{Permissions: permissions}
+
+	tipc := rms.service.Projects.TestIamPermissions(project, request)
+	tipc.Context(ctx)
+
+	var response *cloudresourcemanager.TestIamPermissionsResponse
+	err = rms.caller.Call(ctx, project, func() error {
+		var err error
+		response, err = tipc.Do()
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Permissions, nil
+}
+
+// TestFolderIamPermissions expects the folder as folders/<number>
+func (rms *ResourceManagerService) TestFolderIamPermissions(ctx context.Context, folder string, permissions []string) (granted []string, err error) {
+	ctx, span := tracing.Start(ctx, "cloudresourcemanager.folders.testIamPermissions", attribute.String("gcp.folder", folder))
+	defer func() { tracing.End(span, err) }()
+
+	if rms.foldersService == nil {
+		return nil, fmt.Errorf("service not initialized")
+	}
+
+	request := &cloudresourcemanagerv2.TestIamPermissionsRequest{Permissions: permissions}
+
+	tipc := rms.foldersService.Folders.TestIamPermissions(folder, request)
+	tipc.Context(ctx)
+
+	var response *cloudresourcemanagerv2.TestIamPermissionsResponse
+	err = rms.caller.Call(ctx, folder, func() error {
+		var err error
+		response, err = tipc.Do()
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Permissions, nil
+}
+
+// TestOrganizationIamPermissions expects the organization as organizations/<number>
+func (rms *ResourceManagerService) TestOrganizationIamPermissions(ctx context.Context, organization string, permissions []string) (granted []string, err error) {
+	ctx, span := tracing.Start(ctx, "cloudresourcemanager.organizations.testIamPermissions", attribute.String("gcp.organization", organization))
+	defer func() { tracing.End(span, err) }()
+
+	if rms.service == nil {
+		return nil, fmt.Errorf("service not initialized")
+	}
+
+	request := &cloudresourcemanager.TestIamPermissionsRequest{Permissions: permissions}
+
+	tipc := rms.service.Organizations.TestIamPermissions(organization, request)
+	tipc.Context(ctx)
+
+	var response *cloudresourcemanager.TestIamPermissionsResponse
+	err = rms.caller.Call(ctx, organization, func() error {
+		var err error
+		response, err = tipc.Do()
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Permissions, nil
+}
+
+func (rms *ResourceManagerService) GetIamPolicy(ctx context.Context, project string) (policy *cloudresourcemanager.Policy, err error) {
+	ctx, span := tracing.Start(ctx, "cloudresourcemanager.projects.getIamPolicy", attribute.String("gcp.project", project))
+	defer func() { tracing.End(span, err) }()
+
+	if rms.service == nil {
+		return nil, fmt.Errorf("service not initialized")
+	}
+
+	request := &cloudresourcemanager.GetIamPolicyRequest{
+		Options: &cloudresourcemanager.GetPolicyOptions{RequestedPolicyVersion: 3},
+	}
+
+	gipc := rms.service.Projects.GetIamPolicy(project, request)
+	gipc.Context(ctx)
+
+	err = rms.caller.Call(ctx, project, func() error {
+		var err error
+		policy, err = gipc.Do()
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return policy, nil
+}
+
+func NewResourceManagerService(client *http.Client) (*ResourceManagerService, error) {
+	service, err := cloudresourcemanager.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	foldersService, err := cloudresourcemanagerv2.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	rms := &ResourceManagerService{
+		service:        service,
+		foldersService: foldersService,
+		caller:         DefaultAPICaller,
+	}
+
+	return rms, nil
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResourceManagerService_TestIamPermissions(t *testing.T) {
+	rt := &sequenceRoundTripper{
+		responses: []*http.Response{
+			newJSONResponse(http.StatusOK, `{"permissions": ["compute.instances.list"]}`),
+		},
+	}
+
+	rms, err := NewResourceManagerService(&http.Client{Transport: rt})
+	require.NoError(t, err)
+	rms.caller, _ = newTestAPICaller(&APICallPolicy{})
+
+	granted, err := rms.TestIamPermissions(context.Background(), "fake-project", []string{"compute.instances.list", "compute.regions.get"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"compute.instances.list"}, granted)
+
+	require.Len(t, rt.requests, 1)
+	assert.Equal(t, http.MethodPost, rt.requests[0].Method)
+	assert.Contains(t, rt.requests[0].URL.Path, "/projects/fake-project:testIamPermissions")
+
+	body, err := ioutil.ReadAll(rt.requests[0].Body)
+	require.NoError(t, err)
+
+	var request map[string][]string
+	require.NoError(t, json.Unmarshal(body, &request))
+	assert.Equal(t, []string{"compute.instances.list", "compute.regions.get"}, request["permissions"])
+}
+
+func TestResourceManagerService_TestIamPermissions_notInitialized(t *testing.T) {
+	rms := &ResourceManagerService{caller: DefaultAPICaller}
+
+	_, err := rms.TestIamPermissions(context.Background(), "fake-project", []string{"compute.instances.list"})
+	assert.EqualError(t, err, "service not initialized")
+}
+
+func TestResourceManagerService_TestFolderIamPermissions(t *testing.T) {
+	rt := &sequenceRoundTripper{
+		responses: []*http.Response{
+			newJSONResponse(http.StatusOK, `{"permissions": ["cloudasset.assets.searchAllResources"]}`),
+		},
+	}
+
+	rms, err := NewResourceManagerService(&http.Client{Transport: rt})
+	require.NoError(t, err)
+	rms.caller, _ = newTestAPICaller(&APICallPolicy{})
+
+	granted, err := rms.TestFolderIamPermissions(context.Background(), "folders/123", []string{"cloudasset.assets.searchAllResources"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cloudasset.assets.searchAllResources"}, granted)
+
+	require.Len(t, rt.requests, 1)
+	assert.Equal(t, http.MethodPost, rt.requests[0].Method)
+	assert.Contains(t, rt.requests[0].URL.Path, "/v2/folders/123:testIamPermissions")
+}
+
+func TestResourceManagerService_TestOrganizationIamPermissions(t *testing.T) {
+	rt := &sequenceRoundTripper{
+		responses: []*http.Response{
+			newJSONResponse(http.StatusOK, `{"permissions": ["cloudasset.assets.searchAllResources"]}`),
+		},
+	}
+
+	rms, err := NewResourceManagerService(&http.Client{Transport: rt})
+	require.NoError(t, err)
+	rms.caller, _ = newTestAPICaller(&APICallPolicy{})
+
+	granted, err := rms.TestOrganizationIamPermissions(context.Background(), "organizations/456", []string{"cloudasset.assets.searchAllResources"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cloudasset.assets.searchAllResources"}, granted)
+
+	require.Len(t, rt.requests, 1)
+	assert.Equal(t, http.MethodPost, rt.requests[0].Method)
+	assert.Contains(t, rt.requests[0].URL.Path, "/v1/organizations/456:testIamPermissions")
+}
+
+func TestResourceManagerService_TestFolderIamPermissions_notInitialized(t *testing.T) {
+	rms := &ResourceManagerService{caller: DefaultAPICaller}
+
+	_, err := rms.TestFolderIamPermissions(context.Background(), "folders/123", []string{"cloudasset.assets.searchAllResources"})
+	assert.EqualError(t, err, "service not initialized")
+}
+
+func TestResourceManagerService_GetIamPolicy(t *testing.T) {
+	rt := &sequenceRoundTripper{
+		responses: []*http.Response{
+			newJSONResponse(http.StatusOK, `{"version": 3, "bindings": [{"role": "roles/owner", "members": ["user:owner@example.com"]}]}`),
+		},
+	}
+
+	rms, err := NewResourceManagerService(&http.Client{Transport: rt})
+	require.NoError(t, err)
+	rms.caller, _ = newTestAPICaller(&APICallPolicy{})
+
+	policy, err := rms.GetIamPolicy(context.Background(), "fake-project")
+	require.NoError(t, err)
+	require.Len(t, policy.Bindings, 1)
+	assert.Equal(t, "roles/owner", policy.Bindings[0].Role)
+	assert.Equal(t, []string{"user:owner@example.com"}, policy.Bindings[0].Members)
+
+	require.Len(t, rt.requests, 1)
+	assert.Equal(t, http.MethodPost, rt.requests[0].Method)
+	assert.Contains(t, rt.requests[0].URL.Path, "/projects/fake-project:getIamPolicy")
+
+	body, err := ioutil.ReadAll(rt.requests[0].Body)
+	require.NoError(t, err)
+
+	var request map[string]map[string]int
+	require.NoError(t, json.Unmarshal(body, &request))
+	assert.Equal(t, 3, request["options"]["requestedPolicyVersion"])
+}
+
+func TestResourceManagerService_GetIamPolicy_notInitialized(t *testing.T) {
+	rms := &ResourceManagerService{caller: DefaultAPICaller}
+
+	_, err := rms.GetIamPolicy(context.Background(), "fake-project")
+	assert.EqualError(t, err, "service not initialized")
+}
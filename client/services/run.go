@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/api/run/v2"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tracing"
+)
+
+type RunServiceInterface interface {
+	ListServices(ctx context.Context, project string, region string, perPage int64) ([]*run.GoogleCloudRunV2Service, error)
+	GetRevision(ctx context.Context, project string, name string) (*run.GoogleCloudRunV2Revision, error)
+}
+
+type RunService struct {
+	service *run.Service
+	caller  APICallerInterface
+}
+
+func (rs *RunService) ListServices(ctx context.Context, project string, region string, perPage int64) (services []*run.GoogleCloudRunV2Service, err error) {
+	ctx, span := tracing.Start(ctx, "run.services.list",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.region", region),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	if rs.service == nil {
+		return nil, fmt.Errorf("service not initialized")
+	}
+
+	services = make([]*run.GoogleCloudRunV2Service, 0)
+
+	slc := rs.service.Projects.Locations.Services.List(fmt.Sprintf("projects/%s/locations/%s", project, region))
+	slc.PageSize(perPage)
+
+	err = listPages(ctx, rs.caller, span, "run.services.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := slc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		services = append(services, page.Services...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return services, nil
+}
+
+// GetRevision expects the full resource name of the revision, as set in
+// the LatestReadyRevision of a service
+func (rs *RunService) GetRevision(ctx context.Context, project string, name string) (revision *run.GoogleCloudRunV2Revision, err error) {
+	ctx, span := tracing.Start(ctx, "run.revisions.get",
+		attribute.String("gcp.project", project),
+		attribute.String("gcp.revision", name),
+	)
+	defer func() { tracing.End(span, err) }()
+
+	if rs.service == nil {
+		return nil, fmt.Errorf("service not initialized")
+	}
+
+	rgc := rs.service.Projects.Locations.Services.Revisions.Get(name)
+	rgc.Context(ctx)
+
+	err = rs.caller.Call(ctx, project, func() error {
+		var err error
+		revision, err = rgc.Do()
+
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return revision, nil
+}
+
+func NewRunService(client *http.Client) (*RunService, error) {
+	service, err := run.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := &RunService{
+		service: service,
+		caller:  DefaultAPICaller,
+	}
+
+	return rs, nil
+}
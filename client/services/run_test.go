@@ -0,0 +1,63 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunService_ListServices(t *testing.T) {
+	rt := &sequenceRoundTripper{
+		responses: []*http.Response{
+			newJSONResponse(http.StatusOK, `{"services": [{"name": "projects/fake-project/locations/us-east1/services/service-1"}], "nextPageToken": "page-2"}`),
+			newJSONResponse(http.StatusOK, `{"services": [{"name": "projects/fake-project/locations/us-east1/services/service-2"}]}`),
+		},
+	}
+
+	rs, err := NewRunService(&http.Client{Transport: rt})
+	require.NoError(t, err)
+	rs.caller, _ = newTestAPICaller(&APICallPolicy{})
+
+	services, err := rs.ListServices(context.Background(), "fake-project", "us-east1", 1)
+	require.NoError(t, err)
+	require.Len(t, services, 2)
+	assert.Equal(t, "projects/fake-project/locations/us-east1/services/service-1", services[0].Name)
+	assert.Equal(t, "projects/fake-project/locations/us-east1/services/service-2", services[1].Name)
+
+	require.Len(t, rt.requests, 2)
+	assert.Equal(t, "/v2/projects/fake-project/locations/us-east1/services", rt.requests[0].URL.Path)
+	assert.Equal(t, "page-2", rt.requests[1].URL.Query().Get("pageToken"))
+}
+
+func TestRunService_GetRevision(t *testing.T) {
+	rt := &sequenceRoundTripper{
+		responses: []*http.Response{
+			newJSONResponse(http.StatusOK, `{"name": "projects/fake-project/locations/us-east1/services/service-1/revisions/revision-1", "createTime": "2026-10-19T10:00:00Z"}`),
+		},
+	}
+
+	rs, err := NewRunService(&http.Client{Transport: rt})
+	require.NoError(t, err)
+	rs.caller, _ = newTestAPICaller(&APICallPolicy{})
+
+	revision, err := rs.GetRevision(context.Background(), "fake-project", "projects/fake-project/locations/us-east1/services/service-1/revisions/revision-1")
+	require.NoError(t, err)
+	assert.Equal(t, "2026-10-19T10:00:00Z", revision.CreateTime)
+
+	require.Len(t, rt.requests, 1)
+	assert.Equal(t, http.MethodGet, rt.requests[0].Method)
+	assert.Equal(t, "/v2/projects/fake-project/locations/us-east1/services/service-1/revisions/revision-1", rt.requests[0].URL.Path)
+}
+
+func TestRunService_notInitialized(t *testing.T) {
+	rs := &RunService{caller: DefaultAPICaller}
+
+	_, err := rs.ListServices(context.Background(), "fake-project", "us-east1", 10)
+	assert.EqualError(t, err, "service not initialized")
+
+	_, err = rs.GetRevision(context.Background(), "fake-project", "projects/fake-project/locations/us-east1/services/service-1/revisions/revision-1")
+	assert.EqualError(t, err, "service not initialized")
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/api/sqladmin/v1"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tracing"
+)
+
+type SQLAdminServiceInterface interface {
+	ListInstances(ctx context.Context, project string, perPage int64) ([]*sqladmin.DatabaseInstance, error)
+}
+
+type SQLAdminService struct {
+	service *sqladmin.Service
+	caller  APICallerInterface
+}
+
+func (sas *SQLAdminService) ListInstances(ctx context.Context, project string, perPage int64) (instances []*sqladmin.DatabaseInstance, err error) {
+	ctx, span := tracing.Start(ctx, "sqladmin.instances.list", attribute.String("gcp.project", project))
+	defer func() { tracing.End(span, err) }()
+
+	if sas.service == nil {
+		return nil, fmt.Errorf("service not initialized")
+	}
+
+	instances = make([]*sqladmin.DatabaseInstance, 0)
+
+	ilc := sas.service.Instances.List(project)
+	ilc.MaxResults(perPage)
+
+	err = listPages(ctx, sas.caller, span, "sqladmin.instances.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := ilc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		instances = append(instances, page.Items...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return instances, nil
+}
+
+func NewSQLAdminService(client *http.Client) (*SQLAdminService, error) {
+	service, err := sqladmin.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	sas := &SQLAdminService{
+		service: service,
+		caller:  DefaultAPICaller,
+	}
+
+	return sas, nil
+}
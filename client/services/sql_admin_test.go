@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSQLAdminService_ListInstances(t *testing.T) {
+	rt := &sequenceRoundTripper{
+		responses: []*http.Response{
+			newJSONResponse(http.StatusOK, `{"items": [{"name": "db-1"}], "nextPageToken": "page-2"}`),
+			newJSONResponse(http.StatusOK, `{"items": [{"name": "db-2"}]}`),
+		},
+	}
+
+	sas, err := NewSQLAdminService(&http.Client{Transport: rt})
+	require.NoError(t, err)
+	sas.caller, _ = newTestAPICaller(&APICallPolicy{})
+
+	instances, err := sas.ListInstances(context.Background(), "fake-project", 1)
+	require.NoError(t, err)
+	require.Len(t, instances, 2)
+	assert.Equal(t, "db-1", instances[0].Name)
+	assert.Equal(t, "db-2", instances[1].Name)
+
+	require.Len(t, rt.requests, 2)
+	assert.Contains(t, rt.requests[0].URL.Path, "/projects/fake-project/instances")
+	assert.Equal(t, "page-2", rt.requests[1].URL.Query().Get("pageToken"))
+}
+
+func TestSQLAdminService_ListInstances_notInitialized(t *testing.T) {
+	sas := &SQLAdminService{caller: DefaultAPICaller}
+
+	_, err := sas.ListInstances(context.Background(), "fake-project", 10)
+	assert.EqualError(t, err, "service not initialized")
+}
@@ -0,0 +1,65 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/api/storage/v1"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tracing"
+)
+
+type StorageServiceInterface interface {
+	ListBuckets(ctx context.Context, project string, perPage int64) ([]*storage.Bucket, error)
+}
+
+type StorageService struct {
+	service *storage.Service
+	caller  APICallerInterface
+}
+
+func (ss *StorageService) ListBuckets(ctx context.Context, project string, perPage int64) (buckets []*storage.Bucket, err error) {
+	ctx, span := tracing.Start(ctx, "storage.buckets.list", attribute.String("gcp.project", project))
+	defer func() { tracing.End(span, err) }()
+
+	if ss.service == nil {
+		return nil, fmt.Errorf("service not initialized")
+	}
+
+	buckets = make([]*storage.Bucket, 0)
+
+	blc := ss.service.Buckets.List(project)
+	blc.MaxResults(perPage)
+
+	err = listPages(ctx, ss.caller, span, "storage.buckets.list.page", project, func(ctx context.Context, pageToken string) (string, error) {
+		page, err := blc.PageToken(pageToken).Context(ctx).Do()
+		if err != nil {
+			return "", err
+		}
+
+		buckets = append(buckets, page.Items...)
+
+		return page.NextPageToken, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buckets, nil
+}
+
+func NewStorageService(client *http.Client) (*StorageService, error) {
+	service, err := storage.New(client)
+	if err != nil {
+		return nil, err
+	}
+
+	ss := &StorageService{
+		service: service,
+		caller:  DefaultAPICaller,
+	}
+
+	return ss, nil
+}
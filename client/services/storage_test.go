@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStorageService_ListBuckets(t *testing.T) {
+	rt := &sequenceRoundTripper{
+		responses: []*http.Response{
+			newJSONResponse(http.StatusOK, `{"items": [{"name": "bucket-1"}], "nextPageToken": "page-2"}`),
+			newJSONResponse(http.StatusOK, `{"items": [{"name": "bucket-2"}]}`),
+		},
+	}
+
+	ss, err := NewStorageService(&http.Client{Transport: rt})
+	require.NoError(t, err)
+	ss.caller, _ = newTestAPICaller(&APICallPolicy{})
+
+	buckets, err := ss.ListBuckets(context.Background(), "fake-project", 1)
+	require.NoError(t, err)
+	require.Len(t, buckets, 2)
+	assert.Equal(t, "bucket-1", buckets[0].Name)
+	assert.Equal(t, "bucket-2", buckets[1].Name)
+
+	require.Len(t, rt.requests, 2)
+	assert.Equal(t, "fake-project", rt.requests[0].URL.Query().Get("project"))
+	assert.Equal(t, "page-2", rt.requests[1].URL.Query().Get("pageToken"))
+}
+
+func TestStorageService_ListBuckets_notInitialized(t *testing.T) {
+	ss := &StorageService{caller: DefaultAPICaller}
+
+	_, err := ss.ListBuckets(context.Background(), "fake-project", 10)
+	assert.EqualError(t, err, "service not initialized")
+}
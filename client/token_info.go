@@ -0,0 +1,99 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+var TokenInfoURL = "https://oauth2.googleapis.com/tokeninfo"
+
+type TokenInfo struct {
+	Email     string   `json:"email,omitempty"`
+	Subject   string   `json:"subject,omitempty"`
+	Audience  string   `json:"audience,omitempty"`
+	Scopes    []string `json:"scopes,omitempty"`
+	ExpiresAt string   `json:"expires_at,omitempty"`
+	ExpiresIn int64    `json:"expires_in"`
+}
+
+type tokenInfoResponse struct {
+	Email     string `json:"email"`
+	Sub       string `json:"sub"`
+	Aud       string `json:"aud"`
+	Scope     string `json:"scope"`
+	Exp       string `json:"exp"`
+	ExpiresIn string `json:"expires_in"`
+
+	ErrorDescription string `json:"error_description"`
+}
+
+func GetTokenInfo(ctx context.Context, client HTTPClientInterface, token *oauth2.Token) (*TokenInfo, error) {
+	parameter := "access_token"
+	value := token.AccessToken
+	if idToken, ok := token.Extra("id_token").(string); ok && idToken != "" {
+		parameter = "id_token"
+		value = idToken
+	}
+
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s?%s=%s", TokenInfoURL, parameter, url.QueryEscape(value)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not prepare HTTP Request: %v", err)
+	}
+
+	response, err := client.Do(request.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error during HTTP Request: %v", err)
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error while reading response body: %v", err)
+	}
+
+	var tokenInfoResp tokenInfoResponse
+	err = json.Unmarshal(body, &tokenInfoResp)
+	if err != nil {
+		return nil, fmt.Errorf("error while parsing response body: %v", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("tokeninfo request failed with status %d: %s", response.StatusCode, tokenInfoResp.ErrorDescription)
+	}
+
+	return tokenInfoResp.tokenInfo(time.Now()), nil
+}
+
+func (tir *tokenInfoResponse) tokenInfo(now time.Time) *TokenInfo {
+	info := &TokenInfo{
+		Email:    tir.Email,
+		Subject:  tir.Sub,
+		Audience: tir.Aud,
+	}
+
+	if tir.Scope != "" {
+		info.Scopes = strings.Fields(tir.Scope)
+	}
+
+	exp, err := strconv.ParseInt(tir.Exp, 10, 64)
+	if err == nil {
+		expiresAt := time.Unix(exp, 0)
+		info.ExpiresAt = expiresAt.UTC().Format(time.RFC3339)
+		info.ExpiresIn = int64(expiresAt.Sub(now).Seconds())
+	}
+
+	if expiresIn, err := strconv.ParseInt(tir.ExpiresIn, 10, 64); err == nil {
+		info.ExpiresIn = expiresIn
+	}
+
+	return info
+}
@@ -0,0 +1,94 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func runWithTokenInfoServer(t *testing.T, handler http.HandlerFunc, test func(t *testing.T)) {
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	oldTokenInfoURL := TokenInfoURL
+	defer func() { TokenInfoURL = oldTokenInfoURL }()
+	TokenInfoURL = server.URL
+
+	test(t)
+}
+
+func TestGetTokenInfo_accessToken(t *testing.T) {
+	handler := func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "fake-token", r.URL.Query().Get("access_token"))
+
+		rw.Write([]byte(`{
+  "aud": "123",
+  "sub": "456",
+  "scope": "https://www.googleapis.com/auth/cloud-platform https://www.googleapis.com/auth/compute.readonly",
+  "exp": "1500003600",
+  "expires_in": "3599",
+  "email": "service-account@example.com"
+}`))
+	}
+
+	runWithTokenInfoServer(t, handler, func(t *testing.T) {
+		info, err := GetTokenInfo(context.Background(), http.DefaultClient, &oauth2.Token{AccessToken: "fake-token"})
+		require.NoError(t, err)
+
+		assert.Equal(t, "service-account@example.com", info.Email)
+		assert.Equal(t, "456", info.Subject)
+		assert.Equal(t, []string{"https://www.googleapis.com/auth/cloud-platform", "https://www.googleapis.com/auth/compute.readonly"}, info.Scopes)
+		assert.Equal(t, "2017-07-14T03:40:00Z", info.ExpiresAt)
+		assert.Equal(t, int64(3599), info.ExpiresIn)
+	})
+}
+
+func TestGetTokenInfo_IDToken(t *testing.T) {
+	expiry := time.Now().Add(time.Hour).Unix()
+
+	handler := func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "fake-id-token", r.URL.Query().Get("id_token"))
+		assert.Empty(t, r.URL.Query().Get("access_token"))
+
+		fmt.Fprintf(rw, `{"aud": "https://example.com", "email": "service-account@example.com", "exp": "%d"}`, expiry)
+	}
+
+	runWithTokenInfoServer(t, handler, func(t *testing.T) {
+		token := (&oauth2.Token{AccessToken: "fake-id-token"}).WithExtra(map[string]interface{}{"id_token": "fake-id-token"})
+
+		info, err := GetTokenInfo(context.Background(), http.DefaultClient, token)
+		require.NoError(t, err)
+
+		assert.Equal(t, "https://example.com", info.Audience)
+		assert.Empty(t, info.Scopes)
+		assert.InDelta(t, 3600, info.ExpiresIn, 5)
+	})
+}
+
+func TestGetTokenInfo_invalidToken(t *testing.T) {
+	handler := func(rw http.ResponseWriter, r *http.Request) {
+		rw.WriteHeader(http.StatusBadRequest)
+		rw.Write([]byte(`{"error": "invalid_token", "error_description": "Invalid Value"}`))
+	}
+
+	runWithTokenInfoServer(t, handler, func(t *testing.T) {
+		_, err := GetTokenInfo(context.Background(), http.DefaultClient, &oauth2.Token{AccessToken: "fake-token"})
+
+		require.Error(t, err)
+		assert.Equal(t, "tokeninfo request failed with status 400: Invalid Value", err.Error())
+	})
+}
+
+func TestTokenInfoResponse_tokenInfo_remainingLifetime(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	response := &tokenInfoResponse{Exp: "1500000600"}
+
+	assert.Equal(t, int64(600), response.tokenInfo(now).ExpiresIn)
+}
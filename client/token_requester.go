@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 
 	"golang.org/x/oauth2"
@@ -15,6 +16,8 @@ import (
 	"golang.org/x/oauth2/jwt"
 
 	"github.com/Sirupsen/logrus"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/version"
 )
 
 const (
@@ -52,14 +55,24 @@ type tokenResponse struct {
 	AccessToken string `json:"access_token"`
 	TokenType   string `json:"token_type"`
 	ExpiresIn   int64  `json:"expires_in"`
+	IDToken     string `json:"id_token"`
 }
 
 func (tresp *tokenResponse) isValid() bool {
+	if tresp.IDToken != "" {
+		return true
+	}
+
 	return tresp.AccessToken != "" &&
 		tresp.TokenType != "" &&
 		tresp.ExpiresIn != 0
 }
 
+type TokenOptions struct {
+	Scopes   []string
+	Audience string
+}
+
 type TokenRequesterInterface interface {
 	Token() *oauth2.Token
 	RequestToken() error
@@ -70,6 +83,7 @@ type TokenRequester struct {
 
 	config     *jwt.Config
 	privateKey *rsa.PrivateKey
+	options    TokenOptions
 
 	client HTTPClientInterface
 	jwsEnc JWSEncoderInterface
@@ -99,6 +113,10 @@ func (tr *TokenRequester) RequestToken() error {
 		return err
 	}
 
+	if tokenResponse.IDToken != "" {
+		return tr.saveIDToken(tokenResponse.IDToken)
+	}
+
 	tr.token = &oauth2.Token{
 		AccessToken: tokenResponse.AccessToken,
 		TokenType:   tokenResponse.TokenType,
@@ -108,6 +126,22 @@ func (tr *TokenRequester) RequestToken() error {
 	return nil
 }
 
+func (tr *TokenRequester) saveIDToken(idToken string) error {
+	claims, err := jws.Decode(idToken)
+	if err != nil {
+		return fmt.Errorf("error while decoding ID token: %v", err)
+	}
+
+	token := &oauth2.Token{
+		AccessToken: idToken,
+		TokenType:   "Bearer",
+		Expiry:      time.Unix(claims.Exp, 0),
+	}
+	tr.token = token.WithExtra(map[string]interface{}{"id_token": idToken})
+
+	return nil
+}
+
 func (tr *TokenRequester) prepareRequest() (*http.Request, error) {
 	logrus.Debugln("Preparing request")
 	jwsHeader := &jws.Header{
@@ -126,6 +160,15 @@ func (tr *TokenRequester) prepareRequest() (*http.Request, error) {
 		Iat:   iat.Unix(),
 	}
 
+	if len(tr.options.Scopes) > 0 {
+		jwsClaim.Scope = strings.Join(tr.options.Scopes, " ")
+	}
+
+	if tr.options.Audience != "" {
+		jwsClaim.Scope = ""
+		jwsClaim.PrivateClaims = map[string]interface{}{"target_audience": tr.options.Audience}
+	}
+
 	logrus.Debugln("Encoding JWT assertion")
 	jwsAssertion, err := tr.jwsEnc.Encode(jwsHeader, jwsClaim, tr.privateKey)
 	if err != nil {
@@ -140,6 +183,7 @@ func (tr *TokenRequester) prepareRequest() (*http.Request, error) {
 	}
 
 	tokenRequest.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	tokenRequest.Header.Add("User-Agent", version.AppVersion.UserAgent())
 
 	return tokenRequest, nil
 }
@@ -164,6 +208,11 @@ func (tr *TokenRequester) parseResponse(response *http.Response) (tokenResponse,
 		return tokenResponse{}, fmt.Errorf("error while parsing response body: expected values are empty")
 	}
 
+	if tokenResp.IDToken != "" {
+		logrus.Info("Received new ID token")
+		return tokenResp, nil
+	}
+
 	logrus.WithFields(logrus.Fields{
 		"TokenType": tokenResp.TokenType,
 		"ExpiresIn": tokenResp.ExpiresIn,
@@ -172,16 +221,17 @@ func (tr *TokenRequester) parseResponse(response *http.Response) (tokenResponse,
 	return tokenResp, nil
 }
 
-func NewTokenRequester(config *jwt.Config, privateKey *rsa.PrivateKey) *TokenRequester {
+func NewTokenRequester(config *jwt.Config, privateKey *rsa.PrivateKey, options TokenOptions) *TokenRequester {
 	return &TokenRequester{
 		config:     config,
 		privateKey: privateKey,
+		options:    options,
 		client:     http.DefaultClient,
 		jwsEnc:     &JWSEncoder{},
 		httpRB:     &HTTPRequestBuilder{},
 	}
 }
 
-var tokenRequesterFactory = func(config *jwt.Config, privateKey *rsa.PrivateKey) TokenRequesterInterface {
-	return NewTokenRequester(config, privateKey)
+var tokenRequesterFactory = func(config *jwt.Config, privateKey *rsa.PrivateKey, options TokenOptions) TokenRequesterInterface {
+	return NewTokenRequester(config, privateKey, options)
 }
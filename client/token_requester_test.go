@@ -16,9 +16,11 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"golang.org/x/oauth2/jws"
 	"golang.org/x/oauth2/jwt"
 
 	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tests"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/version"
 )
 
 func getTokenRequester(t *testing.T) (*TokenRequester, *jwt.Config, *rsa.PrivateKey) {
@@ -34,7 +36,7 @@ func getTokenRequester(t *testing.T) (*TokenRequester, *jwt.Config, *rsa.Private
 	require.NoError(t, err)
 	require.IsType(t, &rsa.PrivateKey{}, privateKey)
 
-	return NewTokenRequester(config, privateKey), config, privateKey
+	return NewTokenRequester(config, privateKey, TokenOptions{}), config, privateKey
 }
 
 func TestTokenRequester_RequestToken_jwsEncodingFailure(t *testing.T) {
@@ -106,6 +108,7 @@ func TestTokenRequester_RequestToken_httpRequestFailure(t *testing.T) {
 
 			require.Error(t, err)
 			assert.Contains(t, err.Error(), "error during HTTP Request: fake-http-error")
+			assert.Equal(t, version.AppVersion.UserAgent(), request.Header.Get("User-Agent"))
 		})
 	})
 }
@@ -203,3 +206,66 @@ func TestTokenRequester_RequestToken(t *testing.T) {
 		})
 	})
 }
+
+func TestTokenRequester_RequestToken_options(t *testing.T) {
+	examples := map[string]struct {
+		options               TokenOptions
+		expectedScope         string
+		expectedPrivateClaims map[string]interface{}
+	}{
+		"default": {
+			options:       TokenOptions{},
+			expectedScope: ClaimScope,
+		},
+		"custom-scopes": {
+			options:       TokenOptions{Scopes: []string{"scope-1", "scope-2"}},
+			expectedScope: "scope-1 scope-2",
+		},
+		"audience": {
+			options:               TokenOptions{Audience: "https://example.com"},
+			expectedScope:         "",
+			expectedPrivateClaims: map[string]interface{}{"target_audience": "https://example.com"},
+		},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			tr, _, privateKey := getTokenRequester(t)
+			tr.options = example.options
+
+			jwsEnc := &MockJWSEncoderInterface{}
+			jwsEnc.On("Encode", mock.Anything, mock.MatchedBy(func(claims *jws.ClaimSet) bool {
+				return claims.Scope == example.expectedScope &&
+					assert.ObjectsAreEqual(example.expectedPrivateClaims, claims.PrivateClaims)
+			}), privateKey).Return("", fmt.Errorf("fake-jwsenc-error")).Once()
+			defer jwsEnc.AssertExpectations(t)
+			tr.jwsEnc = jwsEnc
+
+			err := tr.RequestToken()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestTokenRequester_RequestToken_IDToken(t *testing.T) {
+	tr, _, privateKey := getTokenRequester(t)
+
+	expiry := time.Now().Add(time.Hour).Unix()
+	idToken, err := jws.Encode(&jws.Header{Algorithm: "RS256", Typ: "JWT"}, &jws.ClaimSet{Aud: "https://example.com", Exp: expiry}, privateKey)
+	require.NoError(t, err)
+
+	runWithJwsEnc(t, tr, privateKey, func(t *testing.T, jwsEnc *MockJWSEncoderInterface, jwsAssertion string) {
+		runWithHTTPRB(t, tr, jwsAssertion, func(t *testing.T, httpRB *MockHTTPRequestBuilderInterface, request *http.Request) {
+			runWithHTTPClient(t, tr, request, func(t *testing.T, httpClient *MockHTTPClientInterface, response *http.Response) {
+				response.Body = ioutil.NopCloser(bytes.NewBufferString(fmt.Sprintf(`{"id_token": %q}`, idToken)))
+
+				err := tr.RequestToken()
+
+				require.NoError(t, err)
+				assert.Equal(t, idToken, tr.Token().AccessToken)
+				assert.Equal(t, idToken, tr.Token().Extra("id_token"))
+				assert.Equal(t, expiry, tr.Token().Expiry.Unix())
+			})
+		})
+	})
+}
@@ -12,6 +12,7 @@ import (
 
 type GCPServiceAccountTokenSource struct {
 	serviceAccountFilePath string
+	options                TokenOptions
 
 	config     *jwt.Config
 	privateKey *rsa.PrivateKey
@@ -72,7 +73,7 @@ func (ts *GCPServiceAccountTokenSource) parseKey() error {
 }
 
 func (ts *GCPServiceAccountTokenSource) requestForToken() error {
-	tr := tokenRequesterFactory(ts.config, ts.privateKey)
+	tr := tokenRequesterFactory(ts.config, ts.privateKey, ts.options)
 	err := tr.RequestToken()
 	if err != nil {
 		return err
@@ -84,7 +85,12 @@ func (ts *GCPServiceAccountTokenSource) requestForToken() error {
 }
 
 func NewGCPServiceAccountTokenSource(serviceAccountFilePath string) *GCPServiceAccountTokenSource {
+	return NewGCPServiceAccountTokenSourceWithOptions(serviceAccountFilePath, TokenOptions{})
+}
+
+func NewGCPServiceAccountTokenSourceWithOptions(serviceAccountFilePath string, options TokenOptions) *GCPServiceAccountTokenSource {
 	return &GCPServiceAccountTokenSource{
 		serviceAccountFilePath: serviceAccountFilePath,
+		options:                options,
 	}
 }
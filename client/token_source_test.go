@@ -39,7 +39,7 @@ func runWithGCPServiceAccountTokenSource(t *testing.T,
 	}
 
 	tr := &MockTokenRequesterInterface{}
-	tokenRequesterFactory = func(config *jwt.Config, privateKey *rsa.PrivateKey) TokenRequesterInterface {
+	tokenRequesterFactory = func(config *jwt.Config, privateKey *rsa.PrivateKey, options TokenOptions) TokenRequesterInterface {
 		assert.Equal(t, expectedJWTConfig, config)
 		assert.Equal(t, expectedPrivateKey, privateKey)
 
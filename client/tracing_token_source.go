@@ -0,0 +1,30 @@
+package client
+
+import (
+	"context"
+
+	"golang.org/x/oauth2"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tracing"
+)
+
+type TracingTokenSource struct {
+	base oauth2.TokenSource
+}
+
+// Token records the token refresh as a separate trace; oauth2.TokenSource
+// doesn't pass the context of the request that needs the token
+func (ts *TracingTokenSource) Token() (*oauth2.Token, error) {
+	_, span := tracing.Start(context.Background(), "oauth2.Token")
+
+	token, err := ts.base.Token()
+	tracing.End(span, err)
+
+	return token, err
+}
+
+func NewTracingTokenSource(base oauth2.TokenSource) *TracingTokenSource {
+	return &TracingTokenSource{
+		base: base,
+	}
+}
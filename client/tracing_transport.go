@@ -0,0 +1,55 @@
+package client
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tracing"
+)
+
+type TracingTransport struct {
+	base http.RoundTripper
+}
+
+func (t *TracingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	ctx, span := tracing.Tracer().Start(
+		request.Context(),
+		fmt.Sprintf("HTTP %s", request.Method),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("http.method", request.Method),
+			attribute.String("http.host", request.URL.Host),
+			attribute.String("http.path", request.URL.Path),
+		),
+	)
+	defer span.End()
+
+	response, err := t.base.RoundTrip(request.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return response, err
+	}
+
+	span.SetAttributes(attribute.Int("http.status_code", response.StatusCode))
+	if response.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, response.Status)
+	}
+
+	return response, nil
+}
+
+func NewTracingTransport(base http.RoundTripper) *TracingTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &TracingTransport{
+		base: base,
+	}
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"golang.org/x/oauth2"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tests"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tracing"
+)
+
+func TestTracingTransport_RoundTrip(t *testing.T) {
+	examples := map[string]struct {
+		statusCode         int
+		expectedStatusCode codes.Code
+	}{
+		"success": {statusCode: http.StatusOK, expectedStatusCode: codes.Unset},
+		"failure": {statusCode: http.StatusForbidden, expectedStatusCode: codes.Error},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			tests.RunWithSpanRecorder(t, func(t *testing.T, recorder *tracetest.SpanRecorder) {
+				server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+					rw.WriteHeader(example.statusCode)
+				}))
+				defer server.Close()
+
+				ctx, parent := tracing.Start(context.Background(), "parent")
+
+				request, err := http.NewRequest(http.MethodGet, server.URL+"/compute/v1/projects", nil)
+				require.NoError(t, err)
+
+				response, err := NewTracingTransport(nil).RoundTrip(request.WithContext(ctx))
+				require.NoError(t, err)
+				response.Body.Close()
+				parent.End()
+
+				spans := tests.FindSpans(recorder, "HTTP GET")
+				require.Len(t, spans, 1)
+
+				attributes := tests.SpanAttributes(spans[0])
+				assert.Equal(t, int64(example.statusCode), attributes["http.status_code"].AsInt64())
+				assert.Equal(t, "/compute/v1/projects", attributes["http.path"].AsString())
+				assert.Equal(t, example.expectedStatusCode, spans[0].Status().Code)
+				assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+			})
+		})
+	}
+}
+
+type fakeTokenSource struct {
+	err error
+}
+
+func (fts *fakeTokenSource) Token() (*oauth2.Token, error) {
+	if fts.err != nil {
+		return nil, fts.err
+	}
+
+	return &oauth2.Token{AccessToken: "fake-token"}, nil
+}
+
+func TestTracingTokenSource_Token(t *testing.T) {
+	tests.RunWithSpanRecorder(t, func(t *testing.T, recorder *tracetest.SpanRecorder) {
+		token, err := NewTracingTokenSource(&fakeTokenSource{}).Token()
+		require.NoError(t, err)
+		assert.Equal(t, "fake-token", token.AccessToken)
+
+		_, err = NewTracingTokenSource(&fakeTokenSource{err: errors.New("test-error")}).Token()
+		assert.Error(t, err)
+
+		spans := tests.FindSpans(recorder, "oauth2.Token")
+		require.Len(t, spans, 2)
+		assert.Equal(t, codes.Unset, spans[0].Status().Code)
+		assert.Equal(t, codes.Error, spans[1].Status().Code)
+	})
+}
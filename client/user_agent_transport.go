@@ -0,0 +1,39 @@
+package client
+
+import (
+	"net/http"
+)
+
+type UserAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+func (t *UserAgentTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	newRequest := new(http.Request)
+	*newRequest = *request
+
+	newRequest.Header = make(http.Header, len(request.Header))
+	for name, values := range request.Header {
+		newRequest.Header[name] = append([]string(nil), values...)
+	}
+
+	userAgent := t.userAgent
+	if existing := request.Header.Get("User-Agent"); existing != "" {
+		userAgent = userAgent + " " + existing
+	}
+	newRequest.Header.Set("User-Agent", userAgent)
+
+	return t.base.RoundTrip(newRequest)
+}
+
+func NewUserAgentTransport(base http.RoundTripper, userAgent string) *UserAgentTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &UserAgentTransport{
+		base:      base,
+		userAgent: userAgent,
+	}
+}
@@ -0,0 +1,44 @@
+package client
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUserAgentTransport_RoundTrip(t *testing.T) {
+	examples := map[string]struct {
+		existingUserAgent string
+		expectedUserAgent string
+	}{
+		"without-existing-user-agent": {existingUserAgent: "", expectedUserAgent: "gcp-exporter 1.2.3"},
+		"with-existing-user-agent":    {existingUserAgent: "google-api-go-client/0.5", expectedUserAgent: "gcp-exporter 1.2.3 google-api-go-client/0.5"},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			var receivedUserAgent string
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				receivedUserAgent = r.Header.Get("User-Agent")
+			}))
+			defer server.Close()
+
+			request, err := http.NewRequest(http.MethodGet, server.URL, nil)
+			require.NoError(t, err)
+			if example.existingUserAgent != "" {
+				request.Header.Set("User-Agent", example.existingUserAgent)
+			}
+
+			c := &http.Client{Transport: NewUserAgentTransport(nil, "gcp-exporter 1.2.3")}
+			response, err := c.Do(request)
+			require.NoError(t, err)
+			response.Body.Close()
+
+			assert.Equal(t, example.expectedUserAgent, receivedUserAgent)
+			assert.Equal(t, example.existingUserAgent, request.Header.Get("User-Agent"), "original request should not be modified")
+		})
+	}
+}
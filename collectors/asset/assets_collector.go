@@ -0,0 +1,267 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/cloudasset/v1"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+)
+
+const (
+	AssetsCollectorName = "asset-collector"
+)
+
+type assetsCount struct {
+	labelValues []string
+	count       int
+}
+
+type assetsCounterInterface interface {
+	Add(string, *Query, []*cloudasset.ResourceSearchResult)
+	Collect(chan<- prometheus.Metric)
+}
+
+type assetsCounter struct {
+	counts map[*Query]map[string]*assetsCount
+	lock   sync.RWMutex
+}
+
+func (ac *assetsCounter) Add(scope string, query *Query, results []*cloudasset.ResourceSearchResult) {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+
+	counts, ok := ac.counts[query]
+	if !ok {
+		counts = make(map[string]*assetsCount)
+		ac.counts[query] = counts
+	}
+
+	for _, result := range results {
+		labelValues := []string{scope}
+		for _, field := range query.GroupBy {
+			labelValues = append(labelValues, fieldValue(result, field))
+		}
+
+		key := strings.Join(labelValues, "\xff")
+
+		count, ok := counts[key]
+		if !ok {
+			count = &assetsCount{labelValues: labelValues}
+			counts[key] = count
+		}
+
+		count.count++
+	}
+}
+
+func fieldValue(result *cloudasset.ResourceSearchResult, field string) string {
+	switch field {
+	case "asset_type":
+		return result.AssetType
+	case "location":
+		return result.Location
+	case "state":
+		return result.State
+	case "project_number":
+		return strings.TrimPrefix(result.Project, "projects/")
+	case "parent_asset_type":
+		return result.ParentAssetType
+	}
+
+	return result.Labels[strings.TrimPrefix(field, labelsFieldPrefix)]
+}
+
+func (ac *assetsCounter) Collect(ch chan<- prometheus.Metric) {
+	for query, counts := range ac.counts {
+		for _, count := range counts {
+			ch <- prometheus.MustNewConstMetric(
+				query.desc,
+				prometheus.GaugeValue,
+				float64(count.count),
+				count.labelValues...,
+			)
+		}
+	}
+}
+
+var newAssetsCounter = func() assetsCounterInterface {
+	return &assetsCounter{
+		counts: make(map[*Query]map[string]*assetsCount),
+	}
+}
+
+type AssetsCollector struct {
+	*compute.Common
+
+	ConfigFile string `long:"asset-config-file" env:"GCP_EXPORTER_ASSET_CONFIG_FILE" description:"Path to file with Cloud Asset Inventory queries exported by asset collector"`
+
+	config  *Config
+	service services.CloudAssetServiceInterface
+	assets  assetsCounterInterface
+
+	initialized    bool
+	initalizedLock sync.RWMutex
+}
+
+func (c *AssetsCollector) GetName() string {
+	return AssetsCollectorName
+}
+
+func (c *AssetsCollector) GetData(ctx context.Context) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("asset collector not initialized")
+	}
+
+	if c.service == nil {
+		return fmt.Errorf("asset collector cloudasset.Service is not initialized")
+	}
+
+	count := newAssetsCounter()
+	for _, query := range c.config.Queries {
+		for _, scope := range query.scopes(c.GetProjects()) {
+			logrus.WithFields(logrus.Fields{
+				"scope": scope,
+				"query": query.Name,
+			}).Debugf("Searching resources")
+
+			results, err := c.service.SearchAllResources(ctx, scope, query.AssetTypes, query.Query, compute.PerPage)
+			if err != nil {
+				return fmt.Errorf("error while requesting resources data for query %s: %v", query.Name, err)
+			}
+
+			logrus.WithField("count", len(results)).Debugln("Found resources")
+
+			count.Add(scope, query, results)
+		}
+	}
+
+	c.assets = count
+
+	return nil
+}
+
+func (c *AssetsCollector) isInitialized() bool {
+	c.initalizedLock.RLock()
+	defer c.initalizedLock.RUnlock()
+
+	return c.initialized
+}
+
+func (c *AssetsCollector) Describe(ch chan<- *prometheus.Desc) {
+	if c.config == nil {
+		return
+	}
+
+	for _, query := range c.config.Queries {
+		ch <- query.desc
+	}
+}
+
+func (c *AssetsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.assets.Collect(ch)
+}
+
+func (c *AssetsCollector) Configuration() map[string]string {
+	configuration := map[string]string{
+		"projects":    strings.Join(c.GetProjects(), ","),
+		"config-file": c.ConfigFile,
+	}
+
+	if c.config != nil {
+		names := make([]string, 0)
+		for _, query := range c.config.Queries {
+			names = append(names, query.Name)
+		}
+
+		configuration["queries"] = strings.Join(names, ",")
+	}
+
+	return configuration
+}
+
+func (c *AssetsCollector) Targets() int {
+	if c.config == nil {
+		return 0
+	}
+
+	targets := 0
+	for _, query := range c.config.Queries {
+		targets += len(query.scopes(c.GetProjects()))
+	}
+
+	return targets
+}
+
+// GetScopes returns the search scopes of all queries, on which the required
+// permissions are checked
+func (c *AssetsCollector) GetScopes() []string {
+	scopes := make([]string, 0)
+	if c.config == nil {
+		return scopes
+	}
+
+	seen := make(map[string]bool)
+	for _, query := range c.config.Queries {
+		for _, scope := range query.scopes(c.GetProjects()) {
+			if seen[scope] {
+				continue
+			}
+
+			seen[scope] = true
+			scopes = append(scopes, scope)
+		}
+	}
+
+	return scopes
+}
+
+func (c *AssetsCollector) RequiredPermissions() []string {
+	return []string{"cloudasset.assets.searchAllResources"}
+}
+
+func (c *AssetsCollector) Init(client *http.Client) error {
+	var err error
+
+	if c.ConfigFile == "" {
+		return fmt.Errorf("asset collector requires --asset-config-file to be set")
+	}
+
+	c.config, err = LoadConfig(c.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	c.service, err = services.NewCloudAssetService(client)
+	if err != nil {
+		return fmt.Errorf("error while initializing cloudAssetService: %v", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"projects": strings.Join(c.GetProjects(), ","),
+		"queries":  len(c.config.Queries),
+	}).Info("Registered collector")
+
+	c.initalizedLock.Lock()
+	defer c.initalizedLock.Unlock()
+
+	c.initialized = true
+
+	return nil
+}
+
+func NewAssetsCollector(c *compute.Common) *AssetsCollector {
+	return &AssetsCollector{
+		Common:      c,
+		assets:      newAssetsCounter(),
+		initialized: false,
+	}
+}
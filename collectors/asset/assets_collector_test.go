@@ -0,0 +1,207 @@
+package asset
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/cloudasset/v1"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tests"
+)
+
+func newTestQuery(t *testing.T, query *Query) *Query {
+	require.NoError(t, query.validate())
+
+	return query
+}
+
+func TestAssetsCounter_Add(t *testing.T) {
+	query := newTestQuery(t, &Query{
+		Name:    "test",
+		GroupBy: []string{"asset_type", "state", "project_number", "labels.env"},
+	})
+
+	results := []*cloudasset.ResourceSearchResult{
+		{
+			AssetType: "compute.googleapis.com/Instance",
+			State:     "RUNNING",
+			Project:   "projects/123",
+			Labels:    map[string]string{"env": "prd"},
+		},
+		{
+			AssetType: "compute.googleapis.com/Instance",
+			State:     "RUNNING",
+			Project:   "projects/123",
+			Labels:    map[string]string{"env": "prd", "team": "a"},
+		},
+		{
+			AssetType: "compute.googleapis.com/Instance",
+			State:     "TERMINATED",
+			Project:   "projects/123",
+		},
+	}
+
+	c := newAssetsCounter().(*assetsCounter)
+	c.Add("folders/1", query, results)
+
+	require.Len(t, c.counts[query], 2)
+
+	running := c.counts[query]["folders/1\xffcompute.googleapis.com/Instance\xffRUNNING\xff123\xffprd"]
+	require.NotNil(t, running)
+	assert.Equal(t, []string{"folders/1", "compute.googleapis.com/Instance", "RUNNING", "123", "prd"}, running.labelValues)
+	assert.Equal(t, 2, running.count)
+
+	terminated := c.counts[query]["folders/1\xffcompute.googleapis.com/Instance\xffTERMINATED\xff123\xff"]
+	require.NotNil(t, terminated)
+	assert.Equal(t, 1, terminated.count)
+}
+
+func TestAssetsCounter_Collect(t *testing.T) {
+	ch := make(chan prometheus.Metric, 50)
+	defer close(ch)
+
+	query := newTestQuery(t, &Query{Name: "test", GroupBy: []string{"location"}})
+
+	c := newAssetsCounter().(*assetsCounter)
+	c.Add("projects/project-1", query, []*cloudasset.ResourceSearchResult{
+		{Location: "us-east1"},
+		{Location: "global"},
+	})
+
+	c.Collect(ch)
+
+	assert.Len(t, ch, 2)
+}
+
+func TestAssetsCollector_GetName(t *testing.T) {
+	collector := NewAssetsCollector(&compute.Common{})
+	assert.Equal(t, "asset-collector", collector.GetName())
+}
+
+func TestAssetsCollector_Init(t *testing.T) {
+	tests.RunOnTempDir(t, "asset-config", func(t *testing.T, dir string) {
+		collector := NewAssetsCollector(&compute.Common{Projects: []string{"fake-project-1", "fake-project-2"}})
+		collector.ConfigFile = writeConfig(t, dir, "queries:\n  - name: test_1\n  - name: test_2\n    scope: folders/1\n  - name: test_3\n")
+
+		err := collector.Init(http.DefaultClient)
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, collector.Targets())
+		assert.Equal(t, []string{"projects/fake-project-1", "projects/fake-project-2", "folders/1"}, collector.GetScopes())
+		assert.Equal(t, "test_1,test_2,test_3", collector.Configuration()["queries"])
+	})
+}
+
+func TestAssetsCollector_Init_missingConfigFile(t *testing.T) {
+	collector := NewAssetsCollector(&compute.Common{})
+	err := collector.Init(http.DefaultClient)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "asset collector requires --asset-config-file to be set")
+}
+
+func TestAssetsCollector_GetData_withoutInitialize(t *testing.T) {
+	collector := NewAssetsCollector(&compute.Common{})
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "asset collector not initialized")
+}
+
+func TestAssetsCollector_GetData(t *testing.T) {
+	p1 := "fake-project-1"
+
+	query1 := newTestQuery(t, &Query{
+		Name:       "test_1",
+		AssetTypes: []string{"compute.googleapis.com/Instance"},
+		Query:      "state:RUNNING",
+	})
+	query2 := newTestQuery(t, &Query{Name: "test_2", Scope: "organizations/1"})
+
+	collector := NewAssetsCollector(&compute.Common{Projects: []string{p1}})
+	collector.config = &Config{Queries: []*Query{query1, query2}}
+
+	list1 := []*cloudasset.ResourceSearchResult{{Name: "resource-1"}}
+	list2 := make([]*cloudasset.ResourceSearchResult, 0)
+
+	service := &services.MockCloudAssetServiceInterface{}
+	service.On("SearchAllResources", mock.Anything, "projects/fake-project-1", []string{"compute.googleapis.com/Instance"}, "state:RUNNING", int64(compute.PerPage)).Return(list1, nil).Once()
+	service.On("SearchAllResources", mock.Anything, "organizations/1", []string(nil), "", int64(compute.PerPage)).Return(list2, nil).Once()
+	collector.service = service
+
+	ct := &mockAssetsCounterInterface{}
+	ct.On("Add", "projects/fake-project-1", query1, list1).Once()
+	ct.On("Add", "organizations/1", query2, list2).Once()
+
+	newAssetsCounter = func() assetsCounterInterface {
+		return ct
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.NoError(t, err)
+	service.AssertExpectations(t)
+	ct.AssertExpectations(t)
+}
+
+func TestAssetsCollector_GetData_SearchAllResourcesError(t *testing.T) {
+	query := newTestQuery(t, &Query{Name: "test"})
+
+	collector := NewAssetsCollector(&compute.Common{Projects: []string{"fake-project-1"}})
+	collector.config = &Config{Queries: []*Query{query}}
+
+	service := &services.MockCloudAssetServiceInterface{}
+	service.On("SearchAllResources", mock.Anything, "projects/fake-project-1", mock.Anything, mock.Anything, mock.Anything).Return(nil, fmt.Errorf("fake-search-error")).Once()
+	collector.service = service
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error while requesting resources data for query test: fake-search-error")
+	service.AssertExpectations(t)
+}
+
+func TestAssetsCollector_Describe(t *testing.T) {
+	ch := make(chan<- *prometheus.Desc, 50)
+	defer close(ch)
+
+	collector := NewAssetsCollector(&compute.Common{})
+	collector.config = &Config{Queries: []*Query{
+		newTestQuery(t, &Query{Name: "test_1"}),
+		newTestQuery(t, &Query{Name: "test_2"}),
+	}}
+	collector.Describe(ch)
+
+	assert.Len(t, ch, 2)
+}
+
+func TestAssetsCollector_Collect(t *testing.T) {
+	ch := make(chan<- prometheus.Metric, 50)
+	defer close(ch)
+
+	ct := &mockAssetsCounterInterface{}
+	ct.On("Collect", ch).Once()
+
+	newAssetsCounter = func() assetsCounterInterface {
+		return ct
+	}
+
+	collector := NewAssetsCollector(&compute.Common{})
+	collector.Collect(ch)
+
+	ct.AssertExpectations(t)
+}
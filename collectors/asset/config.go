@@ -0,0 +1,149 @@
+package asset
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/helpers"
+)
+
+const (
+	metricNamePrefix = "gcp_exporter_assets_"
+
+	labelsFieldPrefix = "labels."
+	labelsLabelPrefix = "label_"
+)
+
+var scopePrefixes = []string{
+	"projects/",
+	"folders/",
+	"organizations/",
+}
+
+var groupByFields = map[string]bool{
+	"asset_type":        true,
+	"location":          true,
+	"state":             true,
+	"project_number":    true,
+	"parent_asset_type": true,
+}
+
+type Query struct {
+	Name       string   `yaml:"name"`
+	Help       string   `yaml:"help"`
+	Scope      string   `yaml:"scope"`
+	AssetTypes []string `yaml:"asset_types"`
+	Query      string   `yaml:"query"`
+	GroupBy    []string `yaml:"group_by"`
+
+	labelNames []string
+	desc       *prometheus.Desc
+}
+
+func (q *Query) validate() error {
+	if q.Name == "" {
+		return fmt.Errorf("name must be set")
+	}
+
+	if !model.IsValidMetricName(model.LabelValue(metricNamePrefix + q.Name)) {
+		return fmt.Errorf("invalid name %q", q.Name)
+	}
+
+	if q.Scope != "" && !isValidScope(q.Scope) {
+		return fmt.Errorf("invalid scope %q; expected projects/<id>, folders/<number> or organizations/<number>", q.Scope)
+	}
+
+	q.labelNames = make([]string, 0)
+
+	names := map[string]bool{"scope": true}
+	for _, field := range q.GroupBy {
+		name, ok := labelNameFromField(field)
+		if !ok {
+			return fmt.Errorf("invalid group_by field %q", field)
+		}
+
+		if names[name] {
+			return fmt.Errorf("group_by fields map to duplicated label name %q", name)
+		}
+		names[name] = true
+
+		q.labelNames = append(q.labelNames, name)
+	}
+
+	help := q.Help
+	if help == "" {
+		help = fmt.Sprintf("Number of Cloud Asset Inventory resources matching query %s", q.Name)
+	}
+
+	q.desc = prometheus.NewDesc(metricNamePrefix+q.Name, help, append([]string{"scope"}, q.labelNames...), nil)
+
+	return nil
+}
+
+// scopes returns the search scopes of the query; queries without a scope
+// are run for each of the given projects
+func (q *Query) scopes(projects []string) []string {
+	if q.Scope != "" {
+		return []string{q.Scope}
+	}
+
+	scopes := make([]string, 0, len(projects))
+	for _, project := range projects {
+		scopes = append(scopes, "projects/"+project)
+	}
+
+	return scopes
+}
+
+func isValidScope(scope string) bool {
+	for _, prefix := range scopePrefixes {
+		if strings.HasPrefix(scope, prefix) && len(scope) > len(prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func labelNameFromField(field string) (string, bool) {
+	if groupByFields[field] {
+		return field, true
+	}
+
+	if !strings.HasPrefix(field, labelsFieldPrefix) || len(field) == len(labelsFieldPrefix) {
+		return "", false
+	}
+
+	return labelsLabelPrefix + helpers.SanitizeLabelName(strings.TrimPrefix(field, labelsFieldPrefix)), true
+}
+
+type Config struct {
+	Queries []*Query `yaml:"queries"`
+}
+
+func (c *Config) validate() error {
+	names := make([]string, 0, len(c.Queries))
+	for i, query := range c.Queries {
+		err := query.validate()
+		if err != nil {
+			return fmt.Errorf("query %d: %v", i, err)
+		}
+
+		names = append(names, query.Name)
+	}
+
+	return helpers.ValidateQueryNames(names)
+}
+
+func LoadConfig(path string) (*Config, error) {
+	config := &Config{}
+	err := helpers.LoadYAMLConfig("asset", path, config, config.validate)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
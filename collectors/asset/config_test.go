@@ -0,0 +1,115 @@
+package asset
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tests"
+)
+
+func writeConfig(t *testing.T, dir string, content string) string {
+	file := filepath.Join(dir, "asset.yml")
+	require.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+
+	return file
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests.RunOnTempDir(t, "asset-config", func(t *testing.T, dir string) {
+		file := writeConfig(t, dir, `
+queries:
+  - name: compute_resources
+    scope: organizations/123
+    asset_types:
+      - compute.googleapis.com/Instance
+      - compute.googleapis.com/Disk
+    query: state:RUNNING OR state:READY
+    group_by:
+      - asset_type
+      - location
+      - labels.cost-center
+  - name: all_resources
+    help: Number of all resources
+`)
+
+		config, err := LoadConfig(file)
+		require.NoError(t, err)
+		require.Len(t, config.Queries, 2)
+
+		q1 := config.Queries[0]
+		assert.Equal(t, []string{"asset_type", "location", "label_cost_center"}, q1.labelNames)
+		assert.Equal(t, []string{"organizations/123"}, q1.scopes([]string{"project-1", "project-2"}))
+		assert.Contains(t, q1.desc.String(), `fqName: "gcp_exporter_assets_compute_resources"`)
+		assert.Contains(t, q1.desc.String(), `variableLabels: [scope asset_type location label_cost_center]`)
+
+		q2 := config.Queries[1]
+		assert.Empty(t, q2.labelNames)
+		assert.Equal(t, []string{"projects/project-1", "projects/project-2"}, q2.scopes([]string{"project-1", "project-2"}))
+		assert.Contains(t, q2.desc.String(), `help: "Number of all resources"`)
+		assert.Contains(t, q2.desc.String(), `variableLabels: [scope]`)
+	})
+}
+
+func TestLoadConfig_invalid(t *testing.T) {
+	examples := map[string]struct {
+		content       string
+		expectedError string
+	}{
+		"unknown field": {
+			content:       "queries:\n  - name: test\n    unknown: true\n",
+			expectedError: "could not parse asset config file",
+		},
+		"no queries": {
+			content:       "queries: []\n",
+			expectedError: "no queries defined",
+		},
+		"missing name": {
+			content:       "queries:\n  - query: state:RUNNING\n",
+			expectedError: "query 0: name must be set",
+		},
+		"invalid name": {
+			content:       "queries:\n  - name: compute-instances\n",
+			expectedError: `query 0: invalid name "compute-instances"`,
+		},
+		"invalid scope": {
+			content:       "queries:\n  - name: test\n    scope: project-1\n",
+			expectedError: `query 0: invalid scope "project-1"`,
+		},
+		"invalid group by field": {
+			content:       "queries:\n  - name: test\n    group_by: [name]\n",
+			expectedError: `query 0: invalid group_by field "name"`,
+		},
+		"empty label key": {
+			content:       "queries:\n  - name: test\n    group_by: [labels.]\n",
+			expectedError: `query 0: invalid group_by field "labels."`,
+		},
+		"duplicated group by label": {
+			content:       "queries:\n  - name: test\n    group_by: [labels.cost-center, labels.cost_center]\n",
+			expectedError: `query 0: group_by fields map to duplicated label name "label_cost_center"`,
+		},
+		"duplicated name": {
+			content:       "queries:\n  - name: test\n  - name: test\n",
+			expectedError: `query 1: duplicated name "test"`,
+		},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			tests.RunOnTempDir(t, "asset-config", func(t *testing.T, dir string) {
+				_, err := LoadConfig(writeConfig(t, dir, example.content))
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), example.expectedError)
+			})
+		})
+	}
+}
+
+func TestLoadConfig_missingFile(t *testing.T) {
+	_, err := LoadConfig("/non/existing/asset.yml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not read asset config file")
+}
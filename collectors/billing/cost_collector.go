@@ -0,0 +1,404 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+)
+
+const (
+	CostCollectorName = "billing-collector"
+
+	DefaultInterval = 6 * 60 * 60
+
+	tablePlaceholder = "{table}"
+
+	monthToDateCostColumn = "month_to_date_cost"
+	yesterdayCostColumn   = "yesterday_cost"
+)
+
+const defaultQuery = `SELECT
+  project.id AS project,
+  service.description AS service,
+  sku.description AS sku,
+  currency,
+  SUM(IF(invoice.month = FORMAT_DATE("%Y%m", CURRENT_DATE()),
+    cost + IFNULL((SELECT SUM(c.amount) FROM UNNEST(credits) c), 0), 0)) AS month_to_date_cost,
+  SUM(IF(DATE(usage_start_time) = DATE_SUB(CURRENT_DATE(), INTERVAL 1 DAY),
+    cost + IFNULL((SELECT SUM(c.amount) FROM UNNEST(credits) c), 0), 0)) AS yesterday_cost
+FROM ` + "`" + tablePlaceholder + "`" + `
+WHERE DATE(_PARTITIONTIME) >= DATE_SUB(DATE_TRUNC(CURRENT_DATE(), MONTH), INTERVAL 1 DAY)
+GROUP BY project, service, sku, currency`
+
+var defaultLabels = []string{
+	"project=project",
+	"service=service",
+	"sku=sku",
+	"currency=currency",
+}
+
+var timeNow = time.Now
+
+var (
+	lastQueryTimestamp = prometheus.NewDesc(
+		"gcp_exporter_billing_last_query_timestamp_seconds",
+		"Time when billing export was successfully queried for the last time",
+		nil,
+		nil,
+	)
+)
+
+type costDescs struct {
+	monthToDate *prometheus.Desc
+	yesterday   *prometheus.Desc
+}
+
+func newCostDescs(labelNames []string) *costDescs {
+	return &costDescs{
+		monthToDate: prometheus.NewDesc(
+			"gcp_exporter_billing_month_to_date_cost",
+			"Cost of the current invoice month, including credits, from billing export",
+			labelNames,
+			nil,
+		),
+		yesterday: prometheus.NewDesc(
+			"gcp_exporter_billing_yesterday_cost",
+			"Cost of usage from the previous day, including credits, from billing export",
+			labelNames,
+			nil,
+		),
+	}
+}
+
+type costValue struct {
+	labelValues []string
+	monthToDate float64
+	yesterday   float64
+}
+
+type costCounterInterface interface {
+	Add([]string, float64, float64)
+	Collect(chan<- prometheus.Metric)
+}
+
+type costCounter struct {
+	descs *costDescs
+	costs map[string]*costValue
+	lock  sync.RWMutex
+}
+
+func (cc *costCounter) Add(labelValues []string, monthToDate float64, yesterday float64) {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+
+	key := strings.Join(labelValues, "\xff")
+
+	cost, ok := cc.costs[key]
+	if !ok {
+		cost = &costValue{labelValues: labelValues}
+		cc.costs[key] = cost
+	}
+
+	cost.monthToDate += monthToDate
+	cost.yesterday += yesterday
+}
+
+func (cc *costCounter) Collect(ch chan<- prometheus.Metric) {
+	for _, cost := range cc.costs {
+		ch <- prometheus.MustNewConstMetric(cc.descs.monthToDate, prometheus.GaugeValue, cost.monthToDate, cost.labelValues...)
+		ch <- prometheus.MustNewConstMetric(cc.descs.yesterday, prometheus.GaugeValue, cost.yesterday, cost.labelValues...)
+	}
+}
+
+var newCostCounter = func(descs *costDescs) costCounterInterface {
+	return &costCounter{
+		descs: descs,
+		costs: make(map[string]*costValue),
+	}
+}
+
+type labelMapping struct {
+	label  string
+	column string
+}
+
+func parseLabelMappings(mappings []string) ([]labelMapping, error) {
+	labels := make([]labelMapping, 0)
+	seen := make(map[string]bool)
+
+	for _, mapping := range mappings {
+		parts := strings.SplitN(mapping, "=", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, fmt.Errorf("invalid label mapping %q; expected label=column", mapping)
+		}
+
+		if !model.LabelName(parts[0]).IsValid() {
+			return nil, fmt.Errorf("invalid label name %q", parts[0])
+		}
+
+		if seen[parts[0]] {
+			return nil, fmt.Errorf("duplicated label name %q", parts[0])
+		}
+		seen[parts[0]] = true
+
+		labels = append(labels, labelMapping{label: parts[0], column: parts[1]})
+	}
+
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].label < labels[j].label
+	})
+
+	return labels, nil
+}
+
+type CostCollector struct {
+	*compute.Common
+
+	Project   string   `long:"billing-project" env:"GCP_EXPORTER_BILLING_PROJECT" description:"Project in which billing export queries are run; defaults to the first of selected projects"`
+	Table     string   `long:"billing-table" env:"GCP_EXPORTER_BILLING_TABLE" description:"BigQuery table with billing export, as project.dataset.table"`
+	QueryFile string   `long:"billing-query-file" env:"GCP_EXPORTER_BILLING_QUERY_FILE" description:"Path to file with custom billing export query"`
+	Labels    []string `long:"billing-label" env:"GCP_EXPORTER_BILLING_LABEL" description:"Export query result column as label, as label=column; may be used multiple times"`
+	Interval  int      `long:"billing-interval" env:"GCP_EXPORTER_BILLING_INTERVAL" description:"Number of seconds between billing export queries"`
+
+	query  string
+	labels []labelMapping
+	descs  *costDescs
+
+	service services.BigQueryServiceInterface
+	costs   costCounterInterface
+
+	lastQuery time.Time
+
+	initialized    bool
+	initalizedLock sync.RWMutex
+}
+
+func (c *CostCollector) GetName() string {
+	return CostCollectorName
+}
+
+func (c *CostCollector) GetData(ctx context.Context) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("billing collector not initialized")
+	}
+
+	if c.service == nil {
+		return fmt.Errorf("billing collector bigquery.Service is not initialized")
+	}
+
+	now := timeNow()
+	if !c.lastQuery.IsZero() && now.Sub(c.lastQuery) < c.interval() {
+		logrus.WithField("last-query", c.lastQuery).Debugln("Skipping billing export query")
+		return nil
+	}
+
+	logrus.WithField("project", c.Project).Debugf("Querying billing export")
+
+	rows, err := c.service.Query(ctx, c.Project, c.query, compute.PerPage)
+	if err != nil {
+		return fmt.Errorf("error while requesting billing data: %v", err)
+	}
+
+	logrus.WithField("count", len(rows)).Debugln("Found billing rows")
+
+	count := newCostCounter(c.descs)
+	for _, row := range rows {
+		labelValues := make([]string, 0, len(c.labels))
+		for _, label := range c.labels {
+			value, ok := row[label.column]
+			if !ok {
+				return fmt.Errorf("column %s missing in billing query results", label.column)
+			}
+
+			labelValues = append(labelValues, value)
+		}
+
+		monthToDate, err := parseCost(row, monthToDateCostColumn)
+		if err != nil {
+			return err
+		}
+
+		yesterday, err := parseCost(row, yesterdayCostColumn)
+		if err != nil {
+			return err
+		}
+
+		count.Add(labelValues, monthToDate, yesterday)
+	}
+
+	c.costs = count
+	c.lastQuery = now
+
+	return nil
+}
+
+func parseCost(row map[string]string, column string) (float64, error) {
+	value, ok := row[column]
+	if !ok {
+		return 0, fmt.Errorf("column %s missing in billing query results", column)
+	}
+
+	if value == "" {
+		return 0, nil
+	}
+
+	cost, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q of column %s: %v", value, column, err)
+	}
+
+	return cost, nil
+}
+
+func (c *CostCollector) interval() time.Duration {
+	if c.Interval <= 0 {
+		return DefaultInterval * time.Second
+	}
+
+	return time.Duration(c.Interval) * time.Second
+}
+
+func (c *CostCollector) isInitialized() bool {
+	c.initalizedLock.RLock()
+	defer c.initalizedLock.RUnlock()
+
+	return c.initialized
+}
+
+func (c *CostCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- lastQueryTimestamp
+
+	if c.descs == nil {
+		return
+	}
+
+	ch <- c.descs.monthToDate
+	ch <- c.descs.yesterday
+}
+
+func (c *CostCollector) Collect(ch chan<- prometheus.Metric) {
+	if !c.lastQuery.IsZero() {
+		ch <- prometheus.MustNewConstMetric(lastQueryTimestamp, prometheus.GaugeValue, float64(c.lastQuery.Unix()))
+	}
+
+	if c.costs != nil {
+		c.costs.Collect(ch)
+	}
+}
+
+func (c *CostCollector) Configuration() map[string]string {
+	labels := make([]string, 0)
+	for _, label := range c.labels {
+		labels = append(labels, label.label+"="+label.column)
+	}
+
+	return map[string]string{
+		"project":    c.Project,
+		"table":      c.Table,
+		"query-file": c.QueryFile,
+		"labels":     strings.Join(labels, ","),
+		"interval":   c.interval().String(),
+	}
+}
+
+func (c *CostCollector) Targets() int {
+	return 1
+}
+
+// GetProjects returns the project in which billing export queries are run,
+// as it's the only one that needs the required permissions
+func (c *CostCollector) GetProjects() []string {
+	if c.Project == "" {
+		return []string{}
+	}
+
+	return []string{c.Project}
+}
+
+func (c *CostCollector) RequiredPermissions() []string {
+	return []string{"bigquery.jobs.create", "bigquery.tables.getData"}
+}
+
+func (c *CostCollector) Init(client *http.Client) error {
+	var err error
+
+	if c.Project == "" && len(c.Common.GetProjects()) > 0 {
+		c.Project = c.Common.GetProjects()[0]
+	}
+
+	if c.Project == "" {
+		return fmt.Errorf("billing collector requires --billing-project or --project to be set")
+	}
+
+	c.query = defaultQuery
+	if c.QueryFile != "" {
+		data, err := ioutil.ReadFile(c.QueryFile)
+		if err != nil {
+			return fmt.Errorf("could not read billing query file: %v", err)
+		}
+
+		c.query = string(data)
+	}
+
+	if strings.Contains(c.query, tablePlaceholder) {
+		if c.Table == "" {
+			return fmt.Errorf("billing collector requires --billing-table to be set")
+		}
+
+		c.query = strings.Replace(c.query, tablePlaceholder, c.Table, -1)
+	}
+
+	mappings := c.Labels
+	if len(mappings) == 0 {
+		mappings = defaultLabels
+	}
+
+	c.labels, err = parseLabelMappings(mappings)
+	if err != nil {
+		return fmt.Errorf("invalid billing labels: %v", err)
+	}
+
+	labelNames := make([]string, 0, len(c.labels))
+	for _, label := range c.labels {
+		labelNames = append(labelNames, label.label)
+	}
+	c.descs = newCostDescs(labelNames)
+
+	c.service, err = services.NewBigQueryService(client)
+	if err != nil {
+		return fmt.Errorf("error while initializing bigQueryService: %v", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"project":  c.Project,
+		"table":    c.Table,
+		"interval": c.interval(),
+	}).Info("Registered collector")
+
+	c.initalizedLock.Lock()
+	defer c.initalizedLock.Unlock()
+
+	c.initialized = true
+
+	return nil
+}
+
+func NewCostCollector(c *compute.Common) *CostCollector {
+	return &CostCollector{
+		Common:      c,
+		initialized: false,
+	}
+}
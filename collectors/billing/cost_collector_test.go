@@ -0,0 +1,292 @@
+package billing
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tests"
+)
+
+func TestParseLabelMappings(t *testing.T) {
+	labels, err := parseLabelMappings([]string{"service=service_description", "project=project_id"})
+	require.NoError(t, err)
+	assert.Equal(t, []labelMapping{
+		{label: "project", column: "project_id"},
+		{label: "service", column: "service_description"},
+	}, labels)
+
+	for _, invalid := range [][]string{{"project"}, {"project="}, {"project-id=project"}, {"project=a", "project=b"}} {
+		_, err := parseLabelMappings(invalid)
+		assert.Error(t, err, "mappings: %v", invalid)
+	}
+}
+
+func TestCostCounter_Add(t *testing.T) {
+	c := newCostCounter(newCostDescs([]string{"project"})).(*costCounter)
+	c.Add([]string{"project-1"}, 10, 1)
+	c.Add([]string{"project-1"}, 5, 0.5)
+	c.Add([]string{"project-2"}, 1, 0)
+
+	require.Len(t, c.costs, 2)
+	assert.Equal(t, &costValue{labelValues: []string{"project-1"}, monthToDate: 15, yesterday: 1.5}, c.costs["project-1"])
+	assert.Equal(t, &costValue{labelValues: []string{"project-2"}, monthToDate: 1, yesterday: 0}, c.costs["project-2"])
+}
+
+func TestCostCounter_Collect(t *testing.T) {
+	ch := make(chan prometheus.Metric, 50)
+	defer close(ch)
+
+	c := newCostCounter(newCostDescs([]string{"project"})).(*costCounter)
+	c.Add([]string{"project-1"}, 10, 1)
+	c.Add([]string{"project-2"}, 1, 0)
+
+	c.Collect(ch)
+
+	assert.Len(t, ch, 4)
+}
+
+func TestCostCollector_GetName(t *testing.T) {
+	collector := NewCostCollector(&compute.Common{})
+	assert.Equal(t, "billing-collector", collector.GetName())
+}
+
+func TestCostCollector_Init(t *testing.T) {
+	collector := NewCostCollector(&compute.Common{Projects: []string{"fake-project-1", "fake-project-2"}})
+	collector.Table = "billing-project.billing.gcp_billing_export_v1"
+
+	err := collector.Init(http.DefaultClient)
+
+	require.NoError(t, err)
+	assert.Equal(t, "fake-project-1", collector.Project)
+	assert.Equal(t, []string{"fake-project-1"}, collector.GetProjects())
+	assert.Contains(t, collector.query, "FROM `billing-project.billing.gcp_billing_export_v1`")
+	assert.Equal(t, "currency=currency,project=project,service=service,sku=sku", collector.Configuration()["labels"])
+	assert.Equal(t, "6h0m0s", collector.Configuration()["interval"])
+}
+
+func TestCostCollector_Init_queryFile(t *testing.T) {
+	tests.RunOnTempDir(t, "billing-query", func(t *testing.T, dir string) {
+		file := filepath.Join(dir, "query.sql")
+		require.NoError(t, ioutil.WriteFile(file, []byte("SELECT team, 1 AS month_to_date_cost, 0 AS yesterday_cost FROM `costs`"), 0600))
+
+		collector := NewCostCollector(&compute.Common{})
+		collector.Project = "billing-project"
+		collector.QueryFile = file
+		collector.Labels = []string{"team=team"}
+
+		err := collector.Init(http.DefaultClient)
+
+		require.NoError(t, err)
+		assert.Equal(t, "SELECT team, 1 AS month_to_date_cost, 0 AS yesterday_cost FROM `costs`", collector.query)
+		assert.Equal(t, []labelMapping{{label: "team", column: "team"}}, collector.labels)
+	})
+}
+
+func TestCostCollector_Init_invalid(t *testing.T) {
+	examples := map[string]struct {
+		collector     *CostCollector
+		expectedError string
+	}{
+		"missing project": {
+			collector:     &CostCollector{Common: &compute.Common{}, Table: "table"},
+			expectedError: "billing collector requires --billing-project or --project to be set",
+		},
+		"missing table": {
+			collector:     &CostCollector{Common: &compute.Common{}, Project: "project"},
+			expectedError: "billing collector requires --billing-table to be set",
+		},
+		"missing query file": {
+			collector:     &CostCollector{Common: &compute.Common{}, Project: "project", QueryFile: "/non/existing/query.sql"},
+			expectedError: "could not read billing query file",
+		},
+		"invalid labels": {
+			collector:     &CostCollector{Common: &compute.Common{}, Project: "project", Table: "table", Labels: []string{"project"}},
+			expectedError: "invalid billing labels",
+		},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			err := example.collector.Init(http.DefaultClient)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), example.expectedError)
+		})
+	}
+}
+
+func TestCostCollector_GetData_withoutInitialize(t *testing.T) {
+	collector := NewCostCollector(&compute.Common{})
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "billing collector not initialized")
+}
+
+func newTestCostCollector(service services.BigQueryServiceInterface) *CostCollector {
+	collector := NewCostCollector(&compute.Common{})
+	collector.Project = "billing-project"
+	collector.query = "fake-query"
+	collector.labels = []labelMapping{{label: "project", column: "project"}, {label: "service", column: "service"}}
+	collector.descs = newCostDescs([]string{"project", "service"})
+	collector.service = service
+	collector.initialized = true
+
+	return collector
+}
+
+func TestCostCollector_GetData(t *testing.T) {
+	oldTimeNow := timeNow
+	defer func() { timeNow = oldTimeNow }()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time {
+		return now
+	}
+
+	rows := []map[string]string{
+		{"project": "project-1", "service": "Compute Engine", "sku": "N2", "month_to_date_cost": "10.5", "yesterday_cost": "1.25"},
+		{"project": "project-1", "service": "Cloud Storage", "sku": "Standard", "month_to_date_cost": "2", "yesterday_cost": ""},
+	}
+
+	service := &services.MockBigQueryServiceInterface{}
+	service.On("Query", mock.Anything, "billing-project", "fake-query", int64(compute.PerPage)).Return(rows, nil).Once()
+
+	ct := &mockCostCounterInterface{}
+	ct.On("Add", []string{"project-1", "Compute Engine"}, 10.5, 1.25).Once()
+	ct.On("Add", []string{"project-1", "Cloud Storage"}, 2.0, 0.0).Once()
+
+	newCostCounter = func(descs *costDescs) costCounterInterface {
+		return ct
+	}
+
+	collector := newTestCostCollector(service)
+
+	require.NoError(t, collector.GetData(context.Background()))
+	assert.Equal(t, now, collector.lastQuery)
+
+	now = now.Add(time.Hour)
+	require.NoError(t, collector.GetData(context.Background()))
+
+	service.AssertExpectations(t)
+	ct.AssertExpectations(t)
+}
+
+func TestCostCollector_GetData_afterInterval(t *testing.T) {
+	oldTimeNow := timeNow
+	defer func() { timeNow = oldTimeNow }()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time {
+		return now
+	}
+
+	service := &services.MockBigQueryServiceInterface{}
+	service.On("Query", mock.Anything, "billing-project", "fake-query", mock.Anything).Return([]map[string]string{}, nil).Twice()
+
+	newCostCounter = func(descs *costDescs) costCounterInterface {
+		return &mockCostCounterInterface{}
+	}
+
+	collector := newTestCostCollector(service)
+	collector.Interval = 60
+
+	require.NoError(t, collector.GetData(context.Background()))
+
+	now = now.Add(time.Minute)
+	require.NoError(t, collector.GetData(context.Background()))
+
+	service.AssertExpectations(t)
+}
+
+func TestCostCollector_GetData_invalidRows(t *testing.T) {
+	examples := map[string]struct {
+		row           map[string]string
+		expectedError string
+	}{
+		"missing label column": {
+			row:           map[string]string{"project": "project-1", "month_to_date_cost": "1", "yesterday_cost": "1"},
+			expectedError: "column service missing in billing query results",
+		},
+		"missing cost column": {
+			row:           map[string]string{"project": "project-1", "service": "service", "month_to_date_cost": "1"},
+			expectedError: "column yesterday_cost missing in billing query results",
+		},
+		"invalid cost": {
+			row:           map[string]string{"project": "project-1", "service": "service", "month_to_date_cost": "abc", "yesterday_cost": "1"},
+			expectedError: `invalid value "abc" of column month_to_date_cost`,
+		},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			service := &services.MockBigQueryServiceInterface{}
+			service.On("Query", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]map[string]string{example.row}, nil).Once()
+
+			ct := &mockCostCounterInterface{}
+			ct.On("Add", mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+			newCostCounter = func(descs *costDescs) costCounterInterface {
+				return ct
+			}
+
+			collector := newTestCostCollector(service)
+
+			err := collector.GetData(context.Background())
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), example.expectedError)
+			assert.True(t, collector.lastQuery.IsZero())
+		})
+	}
+}
+
+func TestCostCollector_GetData_QueryError(t *testing.T) {
+	service := &services.MockBigQueryServiceInterface{}
+	service.On("Query", mock.Anything, "billing-project", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("fake-query-error")).Once()
+
+	collector := newTestCostCollector(service)
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error while requesting billing data: fake-query-error")
+	service.AssertExpectations(t)
+}
+
+func TestCostCollector_Describe(t *testing.T) {
+	ch := make(chan<- *prometheus.Desc, 50)
+	defer close(ch)
+
+	collector := newTestCostCollector(nil)
+	collector.Describe(ch)
+
+	assert.Len(t, ch, 3)
+}
+
+func TestCostCollector_Collect(t *testing.T) {
+	ch := make(chan<- prometheus.Metric, 50)
+	defer close(ch)
+
+	ct := &mockCostCounterInterface{}
+	ct.On("Collect", ch).Once()
+
+	collector := newTestCostCollector(nil)
+	collector.costs = ct
+	collector.lastQuery = time.Now()
+	collector.Collect(ch)
+
+	assert.Len(t, ch, 1)
+	ct.AssertExpectations(t)
+}
@@ -0,0 +1,25 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package billing
+
+import (
+	prometheus "github.com/prometheus/client_golang/prometheus"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// mockCostCounterInterface is an autogenerated mock type for the costCounterInterface type
+type mockCostCounterInterface struct {
+	mock.Mock
+}
+
+// Add provides a mock function with given fields: _a0, _a1, _a2
+func (_m *mockCostCounterInterface) Add(_a0 []string, _a1 float64, _a2 float64) {
+	_m.Called(_a0, _a1, _a2)
+}
+
+// Collect provides a mock function with given fields: _a0
+func (_m *mockCostCounterInterface) Collect(_a0 chan<- prometheus.Metric) {
+	_m.Called(_a0)
+}
@@ -14,3 +14,22 @@ type Interface interface {
 	GetName() string
 	GetData(ctx context.Context) error
 }
+
+type StatusReporterInterface interface {
+	Configuration() map[string]string
+	Targets() int
+}
+
+type PermissionsReporterInterface interface {
+	GetProjects() []string
+	RequiredPermissions() []string
+}
+
+// ScopedPermissionsReporterInterface is implemented by collectors that
+// require permissions on projects/<id>, folders/<number> or
+// organizations/<number> resources instead of on the configured projects
+type ScopedPermissionsReporterInterface interface {
+	PermissionsReporterInterface
+
+	GetScopes() []string
+}
@@ -0,0 +1,44 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package collector
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockPermissionsReporterInterface is an autogenerated mock type for the PermissionsReporterInterface type
+type MockPermissionsReporterInterface struct {
+	mock.Mock
+}
+
+// GetProjects provides a mock function with given fields:
+func (_m *MockPermissionsReporterInterface) GetProjects() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// RequiredPermissions provides a mock function with given fields:
+func (_m *MockPermissionsReporterInterface) RequiredPermissions() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
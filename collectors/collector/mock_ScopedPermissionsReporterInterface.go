@@ -0,0 +1,60 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package collector
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockScopedPermissionsReporterInterface is an autogenerated mock type for the ScopedPermissionsReporterInterface type
+type MockScopedPermissionsReporterInterface struct {
+	mock.Mock
+}
+
+// GetProjects provides a mock function with given fields:
+func (_m *MockScopedPermissionsReporterInterface) GetProjects() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// GetScopes provides a mock function with given fields:
+func (_m *MockScopedPermissionsReporterInterface) GetScopes() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
+
+// RequiredPermissions provides a mock function with given fields:
+func (_m *MockScopedPermissionsReporterInterface) RequiredPermissions() []string {
+	ret := _m.Called()
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func() []string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	return r0
+}
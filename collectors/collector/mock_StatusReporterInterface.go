@@ -0,0 +1,42 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package collector
+
+import mock "github.com/stretchr/testify/mock"
+
+// MockStatusReporterInterface is an autogenerated mock type for the StatusReporterInterface type
+type MockStatusReporterInterface struct {
+	mock.Mock
+}
+
+// Configuration provides a mock function with given fields:
+func (_m *MockStatusReporterInterface) Configuration() map[string]string {
+	ret := _m.Called()
+
+	var r0 map[string]string
+	if rf, ok := ret.Get(0).(func() map[string]string); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	return r0
+}
+
+// Targets provides a mock function with given fields:
+func (_m *MockStatusReporterInterface) Targets() int {
+	ret := _m.Called()
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func() int); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	return r0
+}
@@ -0,0 +1,277 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/compute/v1"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+)
+
+const (
+	AutoscalersCollectorName = "autoscalers-collector"
+)
+
+var (
+	autoscalerMinReplicas = prometheus.NewDesc(
+		"gcp_exporter_autoscaler_min_replicas",
+		"Minimum number of replicas configured in the autoscaler",
+		[]string{"project", "location", "autoscaler"},
+		nil,
+	)
+
+	autoscalerMaxReplicas = prometheus.NewDesc(
+		"gcp_exporter_autoscaler_max_replicas",
+		"Maximum number of replicas configured in the autoscaler",
+		[]string{"project", "location", "autoscaler"},
+		nil,
+	)
+
+	autoscalerRecommendedSize = prometheus.NewDesc(
+		"gcp_exporter_autoscaler_recommended_size",
+		"Number of instances recommended by the autoscaler",
+		[]string{"project", "location", "autoscaler"},
+		nil,
+	)
+
+	autoscalerMode = prometheus.NewDesc(
+		"gcp_exporter_autoscaler_mode",
+		"Autoscaling policy mode of the autoscaler; always 1",
+		[]string{"project", "location", "autoscaler", "mode"},
+		nil,
+	)
+
+	autoscalerStatus = prometheus.NewDesc(
+		"gcp_exporter_autoscaler_status",
+		"Status of the autoscaler; always 1",
+		[]string{"project", "location", "autoscaler", "status"},
+		nil,
+	)
+
+	autoscalerStatusDetails = prometheus.NewDesc(
+		"gcp_exporter_autoscaler_status_details",
+		"Number of status details of the type reported by the autoscaler",
+		[]string{"project", "location", "autoscaler", "type"},
+		nil,
+	)
+)
+
+type autoscalersPermutation struct {
+	Project    string
+	Location   string
+	Autoscaler string
+}
+
+type autoscalerState struct {
+	MinReplicas     float64
+	MaxReplicas     float64
+	RecommendedSize float64
+	Mode            string
+	Status          string
+	StatusDetails   map[string]float64
+}
+
+type autoscalersCounterInterface interface {
+	Add(string, string, []*compute.Autoscaler)
+	Collect(chan<- prometheus.Metric)
+}
+
+type autoscalersCounter struct {
+	count map[autoscalersPermutation]autoscalerState
+	lock  sync.RWMutex
+}
+
+func (ac *autoscalersCounter) Add(project string, location string, autoscalers []*compute.Autoscaler) {
+	ac.lock.Lock()
+	defer ac.lock.Unlock()
+
+	for _, autoscaler := range autoscalers {
+		permutation := autoscalersPermutation{
+			Project:    project,
+			Location:   location,
+			Autoscaler: autoscaler.Name,
+		}
+
+		state := autoscalerState{
+			RecommendedSize: float64(autoscaler.RecommendedSize),
+			Status:          autoscaler.Status,
+			StatusDetails:   make(map[string]float64),
+		}
+
+		if autoscaler.AutoscalingPolicy != nil {
+			state.MinReplicas = float64(autoscaler.AutoscalingPolicy.MinNumReplicas)
+			state.MaxReplicas = float64(autoscaler.AutoscalingPolicy.MaxNumReplicas)
+			state.Mode = autoscaler.AutoscalingPolicy.Mode
+		}
+
+		for _, details := range autoscaler.StatusDetails {
+			state.StatusDetails[details.Type]++
+		}
+
+		ac.count[permutation] = state
+	}
+}
+
+func (ac *autoscalersCounter) Collect(ch chan<- prometheus.Metric) {
+	for permutation, state := range ac.count {
+		labels := []string{permutation.Project, permutation.Location, permutation.Autoscaler}
+
+		ch <- prometheus.MustNewConstMetric(autoscalerMinReplicas, prometheus.GaugeValue, state.MinReplicas, labels...)
+		ch <- prometheus.MustNewConstMetric(autoscalerMaxReplicas, prometheus.GaugeValue, state.MaxReplicas, labels...)
+		ch <- prometheus.MustNewConstMetric(autoscalerRecommendedSize, prometheus.GaugeValue, state.RecommendedSize, labels...)
+
+		if state.Mode != "" {
+			ch <- prometheus.MustNewConstMetric(autoscalerMode, prometheus.GaugeValue, 1, append(labels, state.Mode)...)
+		}
+
+		if state.Status != "" {
+			ch <- prometheus.MustNewConstMetric(autoscalerStatus, prometheus.GaugeValue, 1, append(labels, state.Status)...)
+		}
+
+		for detailsType, count := range state.StatusDetails {
+			ch <- prometheus.MustNewConstMetric(autoscalerStatusDetails, prometheus.GaugeValue, count, append(labels, detailsType)...)
+		}
+	}
+}
+
+var newAutoscalersCounter = func() autoscalersCounterInterface {
+	return &autoscalersCounter{
+		count: make(map[autoscalersPermutation]autoscalerState),
+	}
+}
+
+type AutoscalersCollector struct {
+	*Common
+
+	service     services.ComputeServiceInterface
+	autoscalers autoscalersCounterInterface
+
+	initialized    bool
+	initalizedLock sync.RWMutex
+}
+
+func (c *AutoscalersCollector) GetName() string {
+	return AutoscalersCollectorName
+}
+
+func (c *AutoscalersCollector) GetData(ctx context.Context) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("autoscalers collector not initialized")
+	}
+
+	if c.service == nil {
+		return fmt.Errorf("autoscalers collector compute.Service is not initialized")
+	}
+
+	count := newAutoscalersCounter()
+	for _, project := range c.GetProjects() {
+		for _, zone := range c.GetZones() {
+			logrus.WithFields(logrus.Fields{
+				"project": project,
+				"zone":    zone,
+			}).Debugf("Requesting autoscalers")
+
+			autoscalers, err := c.service.ListAutoscalers(ctx, project, zone, PerPage)
+			if err != nil {
+				return fmt.Errorf("error while requesting autoscalers data: %v", err)
+			}
+
+			logrus.WithField("count", len(autoscalers)).Debugln("Found autoscalers")
+
+			count.Add(project, zone, autoscalers)
+		}
+
+		for _, region := range c.GetRegions() {
+			logrus.WithFields(logrus.Fields{
+				"project": project,
+				"region":  region,
+			}).Debugf("Requesting region autoscalers")
+
+			autoscalers, err := c.service.ListRegionAutoscalers(ctx, project, region, PerPage)
+			if err != nil {
+				return fmt.Errorf("error while requesting region autoscalers data: %v", err)
+			}
+
+			logrus.WithField("count", len(autoscalers)).Debugln("Found region autoscalers")
+
+			count.Add(project, region, autoscalers)
+		}
+	}
+
+	c.autoscalers = count
+
+	return nil
+}
+
+func (c *AutoscalersCollector) isInitialized() bool {
+	c.initalizedLock.RLock()
+	defer c.initalizedLock.RUnlock()
+
+	return c.initialized
+}
+
+func (c *AutoscalersCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- autoscalerMinReplicas
+	ch <- autoscalerMaxReplicas
+	ch <- autoscalerRecommendedSize
+	ch <- autoscalerMode
+	ch <- autoscalerStatus
+	ch <- autoscalerStatusDetails
+}
+
+func (c *AutoscalersCollector) Collect(ch chan<- prometheus.Metric) {
+	c.autoscalers.Collect(ch)
+}
+
+func (c *AutoscalersCollector) Configuration() map[string]string {
+	return map[string]string{
+		"projects": strings.Join(c.GetProjects(), ","),
+		"zones":    strings.Join(c.GetZones(), ","),
+		"regions":  strings.Join(c.GetRegions(), ","),
+	}
+}
+
+func (c *AutoscalersCollector) Targets() int {
+	return len(c.GetProjects()) * (len(c.GetZones()) + len(c.GetRegions()))
+}
+
+func (c *AutoscalersCollector) RequiredPermissions() []string {
+	return []string{"compute.autoscalers.list"}
+}
+
+func (c *AutoscalersCollector) Init(client *http.Client) error {
+	var err error
+
+	c.service, err = services.NewComputeService(client)
+	if err != nil {
+		return fmt.Errorf("error while initializing computeService: %v", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"projects": strings.Join(c.GetProjects(), ","),
+		"zones":    strings.Join(c.GetZones(), ","),
+		"regions":  strings.Join(c.GetRegions(), ","),
+	}).Info("Registered collector")
+
+	c.initalizedLock.Lock()
+	defer c.initalizedLock.Unlock()
+
+	c.initialized = true
+
+	return nil
+}
+
+func NewAutoscalersCollector(c *Common) *AutoscalersCollector {
+	return &AutoscalersCollector{
+		Common:      c,
+		autoscalers: newAutoscalersCounter(),
+		initialized: false,
+	}
+}
@@ -0,0 +1,197 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+)
+
+func TestAutoscalersCounter_Add(t *testing.T) {
+	autoscaler := &compute.Autoscaler{
+		Name:            "autoscaler-1",
+		RecommendedSize: 7,
+		Status:          "ERROR",
+		StatusDetails: []*compute.AutoscalerStatusDetails{
+			{Type: "NOT_ENOUGH_QUOTA_AVAILABLE", Message: "quota exceeded"},
+			{Type: "NOT_ENOUGH_QUOTA_AVAILABLE", Message: "quota exceeded again"},
+			{Type: "SCALING_TARGET_DOES_NOT_EXIST"},
+		},
+		AutoscalingPolicy: &compute.AutoscalingPolicy{
+			MinNumReplicas: 2,
+			MaxNumReplicas: 10,
+			Mode:           "ONLY_SCALE_OUT",
+		},
+	}
+
+	c := newAutoscalersCounter().(*autoscalersCounter)
+	c.Add("project", "zone", []*compute.Autoscaler{autoscaler})
+
+	require.Len(t, c.count, 1)
+
+	p := autoscalersPermutation{Project: "project", Location: "zone", Autoscaler: "autoscaler-1"}
+	assert.Equal(t, autoscalerState{
+		MinReplicas:     2,
+		MaxReplicas:     10,
+		RecommendedSize: 7,
+		Mode:            "ONLY_SCALE_OUT",
+		Status:          "ERROR",
+		StatusDetails: map[string]float64{
+			"NOT_ENOUGH_QUOTA_AVAILABLE":    2,
+			"SCALING_TARGET_DOES_NOT_EXIST": 1,
+		},
+	}, c.count[p])
+}
+
+func TestAutoscalersCounter_Collect(t *testing.T) {
+	ch := make(chan prometheus.Metric, 50)
+	defer close(ch)
+
+	c := newAutoscalersCounter().(*autoscalersCounter)
+	p := autoscalersPermutation{Project: "project", Location: "zone", Autoscaler: "autoscaler-1"}
+	c.count[p] = autoscalerState{
+		MinReplicas:     1,
+		MaxReplicas:     5,
+		RecommendedSize: 3,
+		Mode:            "ON",
+		Status:          "ACTIVE",
+		StatusDetails:   map[string]float64{"NOT_ENOUGH_QUOTA_AVAILABLE": 1},
+	}
+
+	c.Collect(ch)
+
+	assert.Len(t, ch, 6)
+}
+
+func TestAutoscalersCollector_GetName(t *testing.T) {
+	collector := NewAutoscalersCollector(&Common{})
+	assert.Equal(t, "autoscalers-collector", collector.GetName())
+}
+
+func TestAutoscalersCollector_Init(t *testing.T) {
+	collector := NewAutoscalersCollector(&Common{})
+	err := collector.Init(http.DefaultClient)
+
+	assert.NoError(t, err)
+}
+
+func TestAutoscalersCollector_GetData_withoutInitialize(t *testing.T) {
+	collector := NewAutoscalersCollector(&Common{})
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "autoscalers collector not initialized")
+}
+
+func TestAutoscalersCollector_GetData(t *testing.T) {
+	p1 := "fake-project-1"
+	z1 := "fake-region-1-a"
+	z2 := "fake-region-1-b"
+	r1 := "fake-region-1"
+
+	collector := NewAutoscalersCollector(&Common{Projects: []string{p1}, Zones: []string{z1, z2}})
+
+	list1 := []*compute.Autoscaler{{Name: "autoscaler-1"}}
+	list2 := make([]*compute.Autoscaler, 0)
+	list3 := []*compute.Autoscaler{{Name: "region-autoscaler-1"}}
+
+	service := &services.MockComputeServiceInterface{}
+	service.On("ListAutoscalers", mock.Anything, p1, z1, int64(PerPage)).Return(list1, nil).Once()
+	service.On("ListAutoscalers", mock.Anything, p1, z2, int64(PerPage)).Return(list2, nil).Once()
+	service.On("ListRegionAutoscalers", mock.Anything, p1, r1, int64(PerPage)).Return(list3, nil).Once()
+	collector.service = service
+
+	ct := &mockAutoscalersCounterInterface{}
+	ct.On("Add", p1, z1, list1).Once()
+	ct.On("Add", p1, z2, list2).Once()
+	ct.On("Add", p1, r1, list3).Once()
+
+	newAutoscalersCounter = func() autoscalersCounterInterface {
+		return ct
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.NoError(t, err)
+	service.AssertExpectations(t)
+	ct.AssertExpectations(t)
+}
+
+func TestAutoscalersCollector_GetData_ListAutoscalersError(t *testing.T) {
+	collector := NewAutoscalersCollector(&Common{Projects: []string{"fake-project-1"}, Zones: []string{"fake-zone-1"}})
+
+	service := &services.MockComputeServiceInterface{}
+	service.On("ListAutoscalers", mock.Anything, "fake-project-1", "fake-zone-1", mock.Anything).Return(nil, fmt.Errorf("fake-list-error")).Once()
+	collector.service = service
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error while requesting autoscalers data: fake-list-error")
+	service.AssertExpectations(t)
+}
+
+func TestAutoscalersCollector_GetData_ListRegionAutoscalersError(t *testing.T) {
+	collector := NewAutoscalersCollector(&Common{Projects: []string{"fake-project-1"}, Zones: []string{"fake-region-1-a"}})
+
+	service := &services.MockComputeServiceInterface{}
+	service.On("ListAutoscalers", mock.Anything, "fake-project-1", "fake-region-1-a", mock.Anything).Return(make([]*compute.Autoscaler, 0), nil).Once()
+	service.On("ListRegionAutoscalers", mock.Anything, "fake-project-1", "fake-region-1", mock.Anything).Return(nil, fmt.Errorf("fake-list-error")).Once()
+	collector.service = service
+
+	ct := &mockAutoscalersCounterInterface{}
+	ct.On("Add", "fake-project-1", "fake-region-1-a", mock.Anything).Once()
+
+	newAutoscalersCounter = func() autoscalersCounterInterface {
+		return ct
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error while requesting region autoscalers data: fake-list-error")
+	service.AssertExpectations(t)
+}
+
+func TestAutoscalersCollector_Describe(t *testing.T) {
+	ch := make(chan<- *prometheus.Desc, 50)
+	defer close(ch)
+
+	collector := NewAutoscalersCollector(&Common{})
+	collector.Describe(ch)
+
+	assert.Len(t, ch, 6)
+}
+
+func TestAutoscalersCollector_Collect(t *testing.T) {
+	ch := make(chan<- prometheus.Metric, 50)
+	defer close(ch)
+
+	ct := &mockAutoscalersCounterInterface{}
+	ct.On("Collect", ch).Once()
+
+	newAutoscalersCounter = func() autoscalersCounterInterface {
+		return ct
+	}
+
+	collector := NewAutoscalersCollector(&Common{})
+	collector.Collect(ch)
+
+	ct.AssertExpectations(t)
+}
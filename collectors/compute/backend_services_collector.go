@@ -0,0 +1,274 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/compute/v1"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+)
+
+const (
+	BackendServicesCollectorName = "backend-services-collector"
+
+	HealthStateHealthy   = "HEALTHY"
+	HealthStateUnhealthy = "UNHEALTHY"
+)
+
+var (
+	numberOfBackendEndpoints = prometheus.NewDesc(
+		"gcp_exporter_backend_service_endpoints",
+		"Current number of load balancer backend endpoints by health state",
+		[]string{"project", "region", "backend_service", "group", "location", "health_state"},
+		nil,
+	)
+)
+
+type backendEndpointsPermutation struct {
+	Project        string
+	Region         string
+	BackendService string
+	Group          string
+	Location       string
+	HealthState    string
+}
+
+type backendServicesCounterInterface interface {
+	Add(string, string, string, string, *compute.BackendServiceGroupHealth)
+	Collect(chan<- prometheus.Metric)
+}
+
+type backendServicesCounter struct {
+	endpoints map[backendEndpointsPermutation]int
+	lock      sync.RWMutex
+}
+
+func (bc *backendServicesCounter) Add(project string, region string, backendService string, group string, health *compute.BackendServiceGroupHealth) {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	permutation := backendEndpointsPermutation{
+		Project:        project,
+		Region:         region,
+		BackendService: backendService,
+		Group:          path.Base(group),
+		Location:       groupLocation(group),
+	}
+
+	for _, state := range []string{HealthStateHealthy, HealthStateUnhealthy} {
+		permutation.HealthState = state
+		if _, ok := bc.endpoints[permutation]; !ok {
+			bc.endpoints[permutation] = 0
+		}
+	}
+
+	if health == nil {
+		return
+	}
+
+	for _, status := range health.HealthStatus {
+		permutation.HealthState = status.HealthState
+		bc.endpoints[permutation]++
+	}
+}
+
+// groupLocation returns the zone or region from the URL of an instance
+// group or network endpoint group.
+func groupLocation(group string) string {
+	parts := strings.Split(group, "/")
+	for i := 0; i < len(parts)-1; i++ {
+		if parts[i] == "zones" || parts[i] == "regions" {
+			return parts[i+1]
+		}
+	}
+
+	return GlobalRegion
+}
+
+func (bc *backendServicesCounter) Collect(ch chan<- prometheus.Metric) {
+	for permutation, count := range bc.endpoints {
+		ch <- prometheus.MustNewConstMetric(
+			numberOfBackendEndpoints,
+			prometheus.GaugeValue,
+			float64(count),
+			permutation.Project,
+			permutation.Region,
+			permutation.BackendService,
+			permutation.Group,
+			permutation.Location,
+			permutation.HealthState,
+		)
+	}
+}
+
+var newBackendServicesCounter = func() backendServicesCounterInterface {
+	return &backendServicesCounter{
+		endpoints: make(map[backendEndpointsPermutation]int),
+	}
+}
+
+type BackendServicesCollector struct {
+	*Common
+
+	service         services.ComputeServiceInterface
+	backendServices backendServicesCounterInterface
+
+	initialized    bool
+	initalizedLock sync.RWMutex
+}
+
+func (c *BackendServicesCollector) GetName() string {
+	return BackendServicesCollectorName
+}
+
+func (c *BackendServicesCollector) GetData(ctx context.Context) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("backend services collector not initialized")
+	}
+
+	if c.service == nil {
+		return fmt.Errorf("backend services collector compute.Service is not initialized")
+	}
+
+	count := newBackendServicesCounter()
+	for _, project := range c.GetProjects() {
+		for _, region := range c.GetRegions() {
+			logrus.WithFields(logrus.Fields{
+				"project": project,
+				"region":  region,
+			}).Debugf("Requesting backend services")
+
+			backendServices, err := c.service.ListRegionBackendServices(ctx, project, region, PerPage)
+			if err != nil {
+				return fmt.Errorf("error while requesting backend services data: %v", err)
+			}
+
+			err = c.getHealth(count, project, region, backendServices, func(backendService string, group string) (*compute.BackendServiceGroupHealth, error) {
+				return c.service.GetRegionBackendServiceHealth(ctx, project, region, backendService, group)
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		logrus.WithField("project", project).Debugf("Requesting global backend services")
+
+		backendServices, err := c.service.ListBackendServices(ctx, project, PerPage)
+		if err != nil {
+			return fmt.Errorf("error while requesting global backend services data: %v", err)
+		}
+
+		err = c.getHealth(count, project, GlobalRegion, backendServices, func(backendService string, group string) (*compute.BackendServiceGroupHealth, error) {
+			return c.service.GetBackendServiceHealth(ctx, project, backendService, group)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	c.backendServices = count
+
+	return nil
+}
+
+type backendServiceHealthFn func(backendService string, group string) (*compute.BackendServiceGroupHealth, error)
+
+func (c *BackendServicesCollector) getHealth(count backendServicesCounterInterface, project string, region string, backendServices []*compute.BackendService, getHealth backendServiceHealthFn) error {
+	for _, backendService := range backendServices {
+		// Backends without health checks (e.g. serverless or internet
+		// network endpoint groups) don't report health.
+		if len(backendService.HealthChecks) < 1 {
+			continue
+		}
+
+		for _, backend := range backendService.Backends {
+			logrus.WithFields(logrus.Fields{
+				"project":         project,
+				"region":          region,
+				"backend-service": backendService.Name,
+				"group":           backend.Group,
+			}).Debugf("Requesting backend health")
+
+			health, err := getHealth(backendService.Name, backend.Group)
+			if err != nil {
+				return fmt.Errorf("error while requesting backend health data: %v", err)
+			}
+
+			count.Add(project, region, backendService.Name, backend.Group, health)
+		}
+	}
+
+	return nil
+}
+
+func (c *BackendServicesCollector) isInitialized() bool {
+	c.initalizedLock.RLock()
+	defer c.initalizedLock.RUnlock()
+
+	return c.initialized
+}
+
+func (c *BackendServicesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- numberOfBackendEndpoints
+}
+
+func (c *BackendServicesCollector) Collect(ch chan<- prometheus.Metric) {
+	c.backendServices.Collect(ch)
+}
+
+func (c *BackendServicesCollector) Configuration() map[string]string {
+	return map[string]string{
+		"projects": strings.Join(c.GetProjects(), ","),
+		"regions":  strings.Join(c.GetRegions(), ","),
+	}
+}
+
+func (c *BackendServicesCollector) Targets() int {
+	return len(c.GetProjects()) * (len(c.GetRegions()) + 1)
+}
+
+func (c *BackendServicesCollector) RequiredPermissions() []string {
+	return []string{
+		"compute.backendServices.list",
+		"compute.backendServices.get",
+		"compute.regionBackendServices.list",
+		"compute.regionBackendServices.get",
+	}
+}
+
+func (c *BackendServicesCollector) Init(client *http.Client) error {
+	var err error
+
+	c.service, err = services.NewComputeService(client)
+	if err != nil {
+		return fmt.Errorf("error while initializing computeService: %v", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"projects": strings.Join(c.GetProjects(), ","),
+		"regions":  strings.Join(c.GetRegions(), ","),
+	}).Info("Registered collector")
+
+	c.initalizedLock.Lock()
+	defer c.initalizedLock.Unlock()
+
+	c.initialized = true
+
+	return nil
+}
+
+func NewBackendServicesCollector(c *Common) *BackendServicesCollector {
+	return &BackendServicesCollector{
+		Common:          c,
+		backendServices: newBackendServicesCounter(),
+		initialized:     false,
+	}
+}
@@ -0,0 +1,208 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+)
+
+func TestBackendServicesCounter_Add(t *testing.T) {
+	group := "https://www.googleapis.com/compute/v1/projects/project/zones/us-east1-c/instanceGroups/runners"
+
+	c := newBackendServicesCounter().(*backendServicesCounter)
+	c.Add("project", "global", "web", group, &compute.BackendServiceGroupHealth{
+		HealthStatus: []*compute.HealthStatus{
+			{HealthState: "HEALTHY"},
+			{HealthState: "HEALTHY"},
+			{HealthState: "DRAINING"},
+		},
+	})
+
+	permutation := backendEndpointsPermutation{
+		Project:        "project",
+		Region:         "global",
+		BackendService: "web",
+		Group:          "runners",
+		Location:       "us-east1-c",
+	}
+
+	assert.Len(t, c.endpoints, 3)
+
+	permutation.HealthState = HealthStateHealthy
+	assert.Equal(t, 2, c.endpoints[permutation])
+
+	permutation.HealthState = HealthStateUnhealthy
+	assert.Equal(t, 0, c.endpoints[permutation])
+
+	permutation.HealthState = "DRAINING"
+	assert.Equal(t, 1, c.endpoints[permutation])
+}
+
+func TestBackendServicesCounter_Add_emptyGroup(t *testing.T) {
+	c := newBackendServicesCounter().(*backendServicesCounter)
+	c.Add("project", "region", "web", "https://www.googleapis.com/compute/v1/projects/project/regions/region/networkEndpointGroups/neg", &compute.BackendServiceGroupHealth{})
+
+	assert.Len(t, c.endpoints, 2)
+	for permutation, count := range c.endpoints {
+		assert.Equal(t, "neg", permutation.Group)
+		assert.Equal(t, "region", permutation.Location)
+		assert.Equal(t, 0, count)
+	}
+}
+
+func TestGroupLocation(t *testing.T) {
+	tests := map[string]string{
+		"https://www.googleapis.com/compute/v1/projects/project/zones/us-east1-c/instanceGroups/runners": "us-east1-c",
+		"https://www.googleapis.com/compute/v1/projects/project/regions/us-east1/instanceGroups/runners": "us-east1",
+		"https://www.googleapis.com/compute/v1/projects/project/global/networkEndpointGroups/internet":   GlobalRegion,
+	}
+
+	for group, expected := range tests {
+		t.Run(group, func(t *testing.T) {
+			assert.Equal(t, expected, groupLocation(group))
+		})
+	}
+}
+
+func TestBackendServicesCounter_Collect(t *testing.T) {
+	ch := make(chan prometheus.Metric, 50)
+	defer close(ch)
+
+	c := newBackendServicesCounter().(*backendServicesCounter)
+	c.endpoints[backendEndpointsPermutation{Project: "project", Region: "region", BackendService: "web", Group: "runners", Location: "region-a", HealthState: HealthStateHealthy}] = 1
+	c.endpoints[backendEndpointsPermutation{Project: "project", Region: "region", BackendService: "web", Group: "runners", Location: "region-a", HealthState: HealthStateUnhealthy}] = 0
+
+	c.Collect(ch)
+
+	assert.Len(t, ch, 2)
+}
+
+func TestBackendServicesCollector_GetName(t *testing.T) {
+	collector := NewBackendServicesCollector(&Common{})
+	assert.Equal(t, "backend-services-collector", collector.GetName())
+}
+
+func TestBackendServicesCollector_Init(t *testing.T) {
+	collector := NewBackendServicesCollector(&Common{})
+	err := collector.Init(http.DefaultClient)
+
+	assert.NoError(t, err)
+}
+
+func TestBackendServicesCollector_GetData_withoutInitialize(t *testing.T) {
+	collector := NewBackendServicesCollector(&Common{})
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "backend services collector not initialized")
+}
+
+func TestBackendServicesCollector_GetData(t *testing.T) {
+	p1 := "fake-project-1"
+	r1 := "fake-region-1"
+	g1 := "https://www.googleapis.com/compute/v1/projects/fake-project-1/zones/fake-region-1-a/instanceGroups/group-1"
+	g2 := "https://www.googleapis.com/compute/v1/projects/fake-project-1/zones/fake-region-1-b/instanceGroups/group-2"
+	g3 := "https://www.googleapis.com/compute/v1/projects/fake-project-1/regions/fake-region-1/networkEndpointGroups/serverless"
+
+	collector := NewBackendServicesCollector(&Common{Projects: []string{p1}, Zones: []string{"fake-region-1-a"}})
+
+	regionBackendServices := []*compute.BackendService{
+		{Name: "internal", HealthChecks: []string{"hc"}, Backends: []*compute.Backend{{Group: g1}}},
+	}
+	globalBackendServices := []*compute.BackendService{
+		{Name: "web", HealthChecks: []string{"hc"}, Backends: []*compute.Backend{{Group: g1}, {Group: g2}}},
+		{Name: "serverless", Backends: []*compute.Backend{{Group: g3}}},
+	}
+
+	h1 := &compute.BackendServiceGroupHealth{HealthStatus: []*compute.HealthStatus{{HealthState: "HEALTHY"}}}
+	h2 := &compute.BackendServiceGroupHealth{HealthStatus: []*compute.HealthStatus{{HealthState: "UNHEALTHY"}}}
+	h3 := &compute.BackendServiceGroupHealth{}
+
+	service := &services.MockComputeServiceInterface{}
+	service.On("ListRegionBackendServices", mock.Anything, p1, r1, int64(PerPage)).Return(regionBackendServices, nil).Once()
+	service.On("GetRegionBackendServiceHealth", mock.Anything, p1, r1, "internal", g1).Return(h1, nil).Once()
+	service.On("ListBackendServices", mock.Anything, p1, int64(PerPage)).Return(globalBackendServices, nil).Once()
+	service.On("GetBackendServiceHealth", mock.Anything, p1, "web", g1).Return(h2, nil).Once()
+	service.On("GetBackendServiceHealth", mock.Anything, p1, "web", g2).Return(h3, nil).Once()
+	collector.service = service
+
+	ct := &mockBackendServicesCounterInterface{}
+	ct.On("Add", p1, r1, "internal", g1, h1).Once()
+	ct.On("Add", p1, GlobalRegion, "web", g1, h2).Once()
+	ct.On("Add", p1, GlobalRegion, "web", g2, h3).Once()
+
+	newBackendServicesCounter = func() backendServicesCounterInterface {
+		return ct
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.NoError(t, err)
+	service.AssertExpectations(t)
+	ct.AssertExpectations(t)
+}
+
+func TestBackendServicesCollector_GetData_GetHealthError(t *testing.T) {
+	collector := NewBackendServicesCollector(&Common{Projects: []string{"fake-project-1"}})
+
+	backendServices := []*compute.BackendService{
+		{Name: "web", HealthChecks: []string{"hc"}, Backends: []*compute.Backend{{Group: "group-1"}}},
+	}
+
+	service := &services.MockComputeServiceInterface{}
+	service.On("ListBackendServices", mock.Anything, "fake-project-1", mock.Anything).Return(backendServices, nil).Once()
+	service.On("GetBackendServiceHealth", mock.Anything, "fake-project-1", "web", "group-1").Return(nil, fmt.Errorf("fake-get-error")).Once()
+	collector.service = service
+
+	newBackendServicesCounter = func() backendServicesCounterInterface {
+		return &mockBackendServicesCounterInterface{}
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error while requesting backend health data: fake-get-error")
+	service.AssertExpectations(t)
+}
+
+func TestBackendServicesCollector_Describe(t *testing.T) {
+	ch := make(chan<- *prometheus.Desc, 50)
+	defer close(ch)
+
+	collector := NewBackendServicesCollector(&Common{})
+	collector.Describe(ch)
+
+	assert.Len(t, ch, 1)
+}
+
+func TestBackendServicesCollector_Collect(t *testing.T) {
+	ch := make(chan<- prometheus.Metric, 50)
+	defer close(ch)
+
+	ct := &mockBackendServicesCounterInterface{}
+	ct.On("Collect", ch).Once()
+
+	newBackendServicesCounter = func() backendServicesCounterInterface {
+		return ct
+	}
+
+	collector := NewBackendServicesCollector(&Common{})
+	collector.Collect(ch)
+
+	ct.AssertExpectations(t)
+}
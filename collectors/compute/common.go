@@ -20,10 +20,7 @@ func (c *Common) GetZones() []string {
 func (c *Common) GetRegions() []string {
 	regionsMap := make(map[string]bool, 0)
 	for _, zone := range c.Zones {
-		zoneParts := strings.Split(zone, "-")
-		region := strings.Join(zoneParts[0:len(zoneParts)-1], "-")
-
-		regionsMap[region] = true
+		regionsMap[zoneRegion(zone)] = true
 	}
 
 	regions := make([]string, 0)
@@ -33,3 +30,9 @@ func (c *Common) GetRegions() []string {
 
 	return regions
 }
+
+func zoneRegion(zone string) string {
+	zoneParts := strings.Split(zone, "-")
+
+	return strings.Join(zoneParts[0:len(zoneParts)-1], "-")
+}
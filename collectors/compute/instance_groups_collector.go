@@ -0,0 +1,419 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/compute/v1"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/helpers"
+)
+
+const (
+	InstanceGroupsCollectorName = "instance-groups-collector"
+)
+
+var (
+	instanceGroupSize = prometheus.NewDesc(
+		"gcp_exporter_instance_group_size",
+		"Current number of instances in instance group",
+		[]string{"project", "location", "instance_group"},
+		nil,
+	)
+
+	migTargetSize = prometheus.NewDesc(
+		"gcp_exporter_mig_target_size",
+		"Target number of instances in managed instance group",
+		[]string{"project", "location", "instance_group"},
+		nil,
+	)
+
+	migCurrentSize = prometheus.NewDesc(
+		"gcp_exporter_mig_current_size",
+		"Current number of instances in managed instance group",
+		[]string{"project", "location", "instance_group"},
+		nil,
+	)
+
+	migStable = prometheus.NewDesc(
+		"gcp_exporter_mig_stable",
+		"Whether the managed instance group is in a stable state (1) or not (0)",
+		[]string{"project", "location", "instance_group"},
+		nil,
+	)
+
+	migAutoscalerMinReplicas = prometheus.NewDesc(
+		"gcp_exporter_mig_autoscaler_min_replicas",
+		"Minimum number of replicas configured in the autoscaler of managed instance group",
+		[]string{"project", "location", "instance_group"},
+		nil,
+	)
+
+	migAutoscalerMaxReplicas = prometheus.NewDesc(
+		"gcp_exporter_mig_autoscaler_max_replicas",
+		"Maximum number of replicas configured in the autoscaler of managed instance group",
+		[]string{"project", "location", "instance_group"},
+		nil,
+	)
+
+	migAutoscalerRecommendedSize = prometheus.NewDesc(
+		"gcp_exporter_mig_autoscaler_recommended_size",
+		"Number of instances recommended by the autoscaler of managed instance group",
+		[]string{"project", "location", "instance_group"},
+		nil,
+	)
+
+	migCurrentActions = prometheus.NewDesc(
+		"gcp_exporter_mig_current_actions",
+		"Number of instances in managed instance group that are scheduled for the action",
+		[]string{"project", "location", "instance_group", "action"},
+		nil,
+	)
+)
+
+type migMetricType int
+
+const (
+	MIGMetricTypeTargetSize migMetricType = iota
+	MIGMetricTypeCurrentSize
+	MIGMetricTypeStable
+	MIGMetricTypeAutoscalerMinReplicas
+	MIGMetricTypeAutoscalerMaxReplicas
+	MIGMetricTypeAutoscalerRecommendedSize
+)
+
+var migMetricDescs = map[migMetricType]*prometheus.Desc{
+	MIGMetricTypeTargetSize:                migTargetSize,
+	MIGMetricTypeCurrentSize:               migCurrentSize,
+	MIGMetricTypeStable:                    migStable,
+	MIGMetricTypeAutoscalerMinReplicas:     migAutoscalerMinReplicas,
+	MIGMetricTypeAutoscalerMaxReplicas:     migAutoscalerMaxReplicas,
+	MIGMetricTypeAutoscalerRecommendedSize: migAutoscalerRecommendedSize,
+}
+
+type instanceGroupsPermutation struct {
+	Project  string
+	Location string
+	Name     string
+}
+
+type migActionsPermutation struct {
+	instanceGroupsPermutation
+
+	Action string
+}
+
+type instanceGroupsCounterInterface interface {
+	Add(string, string, []*compute.InstanceGroup, []*compute.InstanceGroupManager, []*compute.Autoscaler)
+	Collect(chan<- prometheus.Metric)
+}
+
+type instanceGroupsCounter struct {
+	sizes   map[instanceGroupsPermutation]float64
+	migs    map[instanceGroupsPermutation]map[migMetricType]float64
+	actions map[migActionsPermutation]float64
+	lock    sync.RWMutex
+}
+
+func (igc *instanceGroupsCounter) Add(project string, location string, groups []*compute.InstanceGroup, managers []*compute.InstanceGroupManager, autoscalers []*compute.Autoscaler) {
+	igc.lock.Lock()
+	defer igc.lock.Unlock()
+
+	groupsBySelfLink := make(map[string]*compute.InstanceGroup, len(groups))
+	for _, group := range groups {
+		groupsBySelfLink[group.SelfLink] = group
+
+		permutation := instanceGroupsPermutation{
+			Project:  project,
+			Location: location,
+			Name:     group.Name,
+		}
+		igc.sizes[permutation] = float64(group.Size)
+	}
+
+	autoscalersByTarget := make(map[string]*compute.Autoscaler, len(autoscalers))
+	for _, autoscaler := range autoscalers {
+		autoscalersByTarget[autoscaler.Target] = autoscaler
+	}
+
+	for _, manager := range managers {
+		permutation := instanceGroupsPermutation{
+			Project:  project,
+			Location: location,
+			Name:     manager.Name,
+		}
+
+		metrics := make(map[migMetricType]float64)
+		metrics[MIGMetricTypeTargetSize] = float64(manager.TargetSize)
+
+		group, ok := groupsBySelfLink[manager.InstanceGroup]
+		if ok {
+			metrics[MIGMetricTypeCurrentSize] = float64(group.Size)
+		}
+
+		if manager.Status != nil {
+			metrics[MIGMetricTypeStable] = helpers.BoolToFloat64(manager.Status.IsStable)
+		}
+
+		autoscaler, ok := autoscalersByTarget[manager.SelfLink]
+		if ok {
+			if autoscaler.AutoscalingPolicy != nil {
+				metrics[MIGMetricTypeAutoscalerMinReplicas] = float64(autoscaler.AutoscalingPolicy.MinNumReplicas)
+				metrics[MIGMetricTypeAutoscalerMaxReplicas] = float64(autoscaler.AutoscalingPolicy.MaxNumReplicas)
+			}
+			metrics[MIGMetricTypeAutoscalerRecommendedSize] = float64(autoscaler.RecommendedSize)
+		}
+
+		igc.migs[permutation] = metrics
+
+		if manager.CurrentActions == nil {
+			continue
+		}
+
+		actions := map[string]int64{
+			"creating":   manager.CurrentActions.Creating,
+			"deleting":   manager.CurrentActions.Deleting,
+			"recreating": manager.CurrentActions.Recreating,
+			"restarting": manager.CurrentActions.Restarting,
+		}
+
+		for action, count := range actions {
+			igc.actions[migActionsPermutation{instanceGroupsPermutation: permutation, Action: action}] = float64(count)
+		}
+	}
+}
+
+func (igc *instanceGroupsCounter) Collect(ch chan<- prometheus.Metric) {
+	for permutation, size := range igc.sizes {
+		ch <- prometheus.MustNewConstMetric(
+			instanceGroupSize,
+			prometheus.GaugeValue,
+			size,
+			permutation.Project,
+			permutation.Location,
+			permutation.Name,
+		)
+	}
+
+	for permutation, metrics := range igc.migs {
+		for metricType, value := range metrics {
+			ch <- prometheus.MustNewConstMetric(
+				migMetricDescs[metricType],
+				prometheus.GaugeValue,
+				value,
+				permutation.Project,
+				permutation.Location,
+				permutation.Name,
+			)
+		}
+	}
+
+	for permutation, count := range igc.actions {
+		ch <- prometheus.MustNewConstMetric(
+			migCurrentActions,
+			prometheus.GaugeValue,
+			count,
+			permutation.Project,
+			permutation.Location,
+			permutation.Name,
+			permutation.Action,
+		)
+	}
+}
+
+var newInstanceGroupsCounter = func() instanceGroupsCounterInterface {
+	return &instanceGroupsCounter{
+		sizes:   make(map[instanceGroupsPermutation]float64),
+		migs:    make(map[instanceGroupsPermutation]map[migMetricType]float64),
+		actions: make(map[migActionsPermutation]float64),
+	}
+}
+
+type InstanceGroupsCollector struct {
+	*Common
+
+	service services.ComputeServiceInterface
+
+	instanceGroups instanceGroupsCounterInterface
+
+	initialized    bool
+	initalizedLock sync.RWMutex
+}
+
+func (c *InstanceGroupsCollector) GetName() string {
+	return InstanceGroupsCollectorName
+}
+
+func (c *InstanceGroupsCollector) GetData(ctx context.Context) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("instance groups collector not initialized")
+	}
+
+	if c.service == nil {
+		return fmt.Errorf("instance groups collector compute.Service is not initialized")
+	}
+
+	count := newInstanceGroupsCounter()
+	for _, project := range c.GetProjects() {
+		for _, zone := range c.GetZones() {
+			logrus.WithFields(logrus.Fields{
+				"project": project,
+				"zone":    zone,
+			}).Debugf("Requesting instance groups")
+
+			err := c.getZoneData(ctx, count, project, zone)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, region := range c.GetRegions() {
+			logrus.WithFields(logrus.Fields{
+				"project": project,
+				"region":  region,
+			}).Debugf("Requesting region instance groups")
+
+			err := c.getRegionData(ctx, count, project, region)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	c.instanceGroups = count
+
+	return nil
+}
+
+func (c *InstanceGroupsCollector) getZoneData(ctx context.Context, count instanceGroupsCounterInterface, project string, zone string) error {
+	groups, err := c.service.ListInstanceGroups(ctx, project, zone, PerPage)
+	if err != nil {
+		return fmt.Errorf("error while requesting instance groups data: %v", err)
+	}
+
+	managers, err := c.service.ListInstanceGroupManagers(ctx, project, zone, PerPage)
+	if err != nil {
+		return fmt.Errorf("error while requesting instance group managers data: %v", err)
+	}
+
+	autoscalers, err := c.service.ListAutoscalers(ctx, project, zone, PerPage)
+	if err != nil {
+		return fmt.Errorf("error while requesting autoscalers data: %v", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"groups":      len(groups),
+		"managers":    len(managers),
+		"autoscalers": len(autoscalers),
+	}).Debugln("Found instance groups")
+
+	count.Add(project, zone, groups, managers, autoscalers)
+
+	return nil
+}
+
+func (c *InstanceGroupsCollector) getRegionData(ctx context.Context, count instanceGroupsCounterInterface, project string, region string) error {
+	groups, err := c.service.ListRegionInstanceGroups(ctx, project, region, PerPage)
+	if err != nil {
+		return fmt.Errorf("error while requesting region instance groups data: %v", err)
+	}
+
+	managers, err := c.service.ListRegionInstanceGroupManagers(ctx, project, region, PerPage)
+	if err != nil {
+		return fmt.Errorf("error while requesting region instance group managers data: %v", err)
+	}
+
+	autoscalers, err := c.service.ListRegionAutoscalers(ctx, project, region, PerPage)
+	if err != nil {
+		return fmt.Errorf("error while requesting region autoscalers data: %v", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"groups":      len(groups),
+		"managers":    len(managers),
+		"autoscalers": len(autoscalers),
+	}).Debugln("Found region instance groups")
+
+	count.Add(project, region, groups, managers, autoscalers)
+
+	return nil
+}
+
+func (c *InstanceGroupsCollector) isInitialized() bool {
+	c.initalizedLock.RLock()
+	defer c.initalizedLock.RUnlock()
+
+	return c.initialized
+}
+
+func (c *InstanceGroupsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- instanceGroupSize
+	ch <- migTargetSize
+	ch <- migCurrentSize
+	ch <- migStable
+	ch <- migAutoscalerMinReplicas
+	ch <- migAutoscalerMaxReplicas
+	ch <- migAutoscalerRecommendedSize
+	ch <- migCurrentActions
+}
+
+func (c *InstanceGroupsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.instanceGroups.Collect(ch)
+}
+
+func (c *InstanceGroupsCollector) Configuration() map[string]string {
+	return map[string]string{
+		"projects": strings.Join(c.GetProjects(), ","),
+		"zones":    strings.Join(c.GetZones(), ","),
+		"regions":  strings.Join(c.GetRegions(), ","),
+	}
+}
+
+func (c *InstanceGroupsCollector) Targets() int {
+	return len(c.GetProjects()) * (len(c.GetZones()) + len(c.GetRegions()))
+}
+
+func (c *InstanceGroupsCollector) RequiredPermissions() []string {
+	return []string{
+		"compute.instanceGroups.list",
+		"compute.instanceGroupManagers.list",
+		"compute.autoscalers.list",
+	}
+}
+
+func (c *InstanceGroupsCollector) Init(client *http.Client) error {
+	var err error
+
+	c.service, err = services.NewComputeService(client)
+	if err != nil {
+		return fmt.Errorf("error while initializing computeService: %v", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"projects": strings.Join(c.GetProjects(), ","),
+		"zones":    strings.Join(c.GetZones(), ","),
+		"regions":  strings.Join(c.GetRegions(), ","),
+	}).Info("Registered collector")
+
+	c.initalizedLock.Lock()
+	defer c.initalizedLock.Unlock()
+
+	c.initialized = true
+
+	return nil
+}
+
+func NewInstanceGroupsCollector(c *Common) *InstanceGroupsCollector {
+	return &InstanceGroupsCollector{
+		Common:         c,
+		instanceGroups: newInstanceGroupsCounter(),
+		initialized:    false,
+	}
+}
@@ -0,0 +1,237 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+)
+
+func TestInstanceGroupsCounter_Add(t *testing.T) {
+	groups := []*compute.InstanceGroup{
+		{Name: "mig-1", SelfLink: "groups/mig-1", Size: 3},
+		{Name: "unmanaged-1", SelfLink: "groups/unmanaged-1", Size: 2},
+	}
+	managers := []*compute.InstanceGroupManager{
+		{
+			Name:          "mig-1",
+			SelfLink:      "managers/mig-1",
+			InstanceGroup: "groups/mig-1",
+			TargetSize:    5,
+			Status:        &compute.InstanceGroupManagerStatus{IsStable: false},
+			CurrentActions: &compute.InstanceGroupManagerActionsSummary{
+				Creating:   2,
+				Deleting:   1,
+				Recreating: 0,
+				Restarting: 3,
+			},
+		},
+		{
+			Name:          "mig-2",
+			SelfLink:      "managers/mig-2",
+			InstanceGroup: "groups/mig-2",
+			TargetSize:    1,
+			Status:        &compute.InstanceGroupManagerStatus{IsStable: true},
+		},
+	}
+	autoscalers := []*compute.Autoscaler{
+		{
+			Target:            "managers/mig-1",
+			RecommendedSize:   4,
+			AutoscalingPolicy: &compute.AutoscalingPolicy{MinNumReplicas: 1, MaxNumReplicas: 10},
+		},
+	}
+
+	c := newInstanceGroupsCounter().(*instanceGroupsCounter)
+	c.Add("project", "zone", groups, managers, autoscalers)
+
+	assert.Len(t, c.sizes, 2)
+	assert.Equal(t, float64(3), c.sizes[instanceGroupsPermutation{Project: "project", Location: "zone", Name: "mig-1"}])
+	assert.Equal(t, float64(2), c.sizes[instanceGroupsPermutation{Project: "project", Location: "zone", Name: "unmanaged-1"}])
+
+	p1 := instanceGroupsPermutation{Project: "project", Location: "zone", Name: "mig-1"}
+	assert.Equal(t, map[migMetricType]float64{
+		MIGMetricTypeTargetSize:                5,
+		MIGMetricTypeCurrentSize:               3,
+		MIGMetricTypeStable:                    0,
+		MIGMetricTypeAutoscalerMinReplicas:     1,
+		MIGMetricTypeAutoscalerMaxReplicas:     10,
+		MIGMetricTypeAutoscalerRecommendedSize: 4,
+	}, c.migs[p1])
+
+	p2 := instanceGroupsPermutation{Project: "project", Location: "zone", Name: "mig-2"}
+	assert.Equal(t, map[migMetricType]float64{
+		MIGMetricTypeTargetSize: 1,
+		MIGMetricTypeStable:     1,
+	}, c.migs[p2])
+
+	assert.Len(t, c.actions, 4)
+	assert.Equal(t, float64(2), c.actions[migActionsPermutation{instanceGroupsPermutation: p1, Action: "creating"}])
+	assert.Equal(t, float64(1), c.actions[migActionsPermutation{instanceGroupsPermutation: p1, Action: "deleting"}])
+	assert.Equal(t, float64(0), c.actions[migActionsPermutation{instanceGroupsPermutation: p1, Action: "recreating"}])
+	assert.Equal(t, float64(3), c.actions[migActionsPermutation{instanceGroupsPermutation: p1, Action: "restarting"}])
+}
+
+func TestInstanceGroupsCounter_Collect(t *testing.T) {
+	ch := make(chan prometheus.Metric, 50)
+	defer close(ch)
+
+	c := newInstanceGroupsCounter().(*instanceGroupsCounter)
+	p := instanceGroupsPermutation{Project: "project", Location: "zone", Name: "mig-1"}
+	c.sizes[p] = 3
+	c.migs[p] = map[migMetricType]float64{
+		MIGMetricTypeTargetSize:  5,
+		MIGMetricTypeCurrentSize: 3,
+	}
+	c.actions[migActionsPermutation{instanceGroupsPermutation: p, Action: "creating"}] = 2
+
+	c.Collect(ch)
+
+	assert.Len(t, ch, 4)
+}
+
+func TestInstanceGroupsCollector_GetName(t *testing.T) {
+	collector := NewInstanceGroupsCollector(&Common{})
+	assert.Equal(t, "instance-groups-collector", collector.GetName())
+}
+
+func TestInstanceGroupsCollector_Init(t *testing.T) {
+	collector := NewInstanceGroupsCollector(&Common{})
+	err := collector.Init(http.DefaultClient)
+
+	assert.NoError(t, err)
+}
+
+func TestInstanceGroupsCollector_Init_noClient(t *testing.T) {
+	collector := NewInstanceGroupsCollector(&Common{})
+	err := collector.Init(nil)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error while initializing computeService:")
+}
+
+func TestInstanceGroupsCollector_GetData_withoutInitialize(t *testing.T) {
+	collector := NewInstanceGroupsCollector(&Common{})
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "instance groups collector not initialized")
+}
+
+func TestInstanceGroupsCollector_GetData_withoutComputeService(t *testing.T) {
+	collector := NewInstanceGroupsCollector(&Common{})
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "instance groups collector compute.Service is not initialized")
+}
+
+func TestInstanceGroupsCollector_GetData(t *testing.T) {
+	p1 := "fake-project-1"
+	z1 := "fake-region-1-a"
+	r1 := "fake-region-1"
+
+	collector := NewInstanceGroupsCollector(&Common{Projects: []string{p1}, Zones: []string{z1}})
+
+	zoneGroups := []*compute.InstanceGroup{{Name: "zone-group"}}
+	zoneManagers := []*compute.InstanceGroupManager{{Name: "zone-manager"}}
+	zoneAutoscalers := []*compute.Autoscaler{{Name: "zone-autoscaler"}}
+	regionGroups := []*compute.InstanceGroup{{Name: "region-group"}}
+	regionManagers := []*compute.InstanceGroupManager{{Name: "region-manager"}}
+	regionAutoscalers := make([]*compute.Autoscaler, 0)
+
+	service := &services.MockComputeServiceInterface{}
+	service.On("ListInstanceGroups", mock.Anything, p1, z1, int64(PerPage)).Return(zoneGroups, nil).Once()
+	service.On("ListInstanceGroupManagers", mock.Anything, p1, z1, int64(PerPage)).Return(zoneManagers, nil).Once()
+	service.On("ListAutoscalers", mock.Anything, p1, z1, int64(PerPage)).Return(zoneAutoscalers, nil).Once()
+	service.On("ListRegionInstanceGroups", mock.Anything, p1, r1, int64(PerPage)).Return(regionGroups, nil).Once()
+	service.On("ListRegionInstanceGroupManagers", mock.Anything, p1, r1, int64(PerPage)).Return(regionManagers, nil).Once()
+	service.On("ListRegionAutoscalers", mock.Anything, p1, r1, int64(PerPage)).Return(regionAutoscalers, nil).Once()
+	collector.service = service
+
+	ct := &mockInstanceGroupsCounterInterface{}
+	ct.On("Add", p1, z1, zoneGroups, zoneManagers, zoneAutoscalers).Once()
+	ct.On("Add", p1, r1, regionGroups, regionManagers, regionAutoscalers).Once()
+
+	newInstanceGroupsCounter = func() instanceGroupsCounterInterface {
+		return ct
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.NoError(t, err)
+	service.AssertExpectations(t)
+	ct.AssertExpectations(t)
+}
+
+func TestInstanceGroupsCollector_GetData_ListError(t *testing.T) {
+	collector := NewInstanceGroupsCollector(&Common{Projects: []string{"fake-project-1"}, Zones: []string{"fake-region-1-a"}})
+
+	service := &services.MockComputeServiceInterface{}
+	service.On("ListInstanceGroups", mock.Anything, "fake-project-1", "fake-region-1-a", mock.Anything).Return(make([]*compute.InstanceGroup, 0), nil).Once()
+	service.On("ListInstanceGroupManagers", mock.Anything, "fake-project-1", "fake-region-1-a", mock.Anything).Return(nil, fmt.Errorf("fake-list-error")).Once()
+	collector.service = service
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error while requesting instance group managers data: fake-list-error")
+	service.AssertExpectations(t)
+}
+
+func TestInstanceGroupsCollector_Describe(t *testing.T) {
+	ch := make(chan<- *prometheus.Desc, 50)
+	defer close(ch)
+
+	collector := NewInstanceGroupsCollector(&Common{})
+	collector.Describe(ch)
+
+	assert.Len(t, ch, 8)
+}
+
+func TestInstanceGroupsCollector_Collect(t *testing.T) {
+	ch := make(chan<- prometheus.Metric, 50)
+	defer close(ch)
+
+	ct := &mockInstanceGroupsCounterInterface{}
+	ct.On("Collect", ch).Once()
+
+	newInstanceGroupsCounter = func() instanceGroupsCounterInterface {
+		return ct
+	}
+
+	collector := NewInstanceGroupsCollector(&Common{})
+	collector.Collect(ch)
+
+	ct.AssertExpectations(t)
+}
+
+func TestInstanceGroupsCollector_Configuration(t *testing.T) {
+	collector := NewInstanceGroupsCollector(&Common{
+		Projects: []string{"fake-project-1", "fake-project-2"},
+		Zones:    []string{"fake-region-1-a", "fake-region-1-b"},
+	})
+
+	configuration := collector.Configuration()
+	assert.Equal(t, "fake-project-1,fake-project-2", configuration["projects"])
+	assert.Equal(t, "fake-region-1-a,fake-region-1-b", configuration["zones"])
+	assert.Equal(t, "fake-region-1", configuration["regions"])
+
+	assert.Equal(t, 6, collector.Targets())
+}
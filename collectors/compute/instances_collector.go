@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"path"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -22,6 +25,13 @@ var (
 		[]string{"project", "zone", "tags", "machine_type"},
 		nil,
 	)
+
+	instancesEstimatedHourlyCost = prometheus.NewDesc(
+		"gcp_exporter_instances_estimated_hourly_cost",
+		"Estimated hourly cost of instances and their disks, based on the pricing file",
+		[]string{"project", "zone", "tags", "machine_type"},
+		nil,
+	)
 )
 
 const (
@@ -36,13 +46,29 @@ type instancesPermutation struct {
 	MachineType string
 }
 
+func newInstancesPermutation(project string, zone string, instance *compute.Instance) instancesPermutation {
+	permutation := instancesPermutation{
+		Project:     project,
+		Zone:        zone,
+		MachineType: instance.MachineType,
+	}
+
+	if instance.Tags != nil {
+		permutation.Tags = strings.Join(instance.Tags.Items, ",")
+	}
+
+	return permutation
+}
+
 type instancesCounterInterface interface {
 	Add(string, string, []*compute.Instance)
+	AddCost(string, string, *compute.Instance, float64)
 	Collect(chan<- prometheus.Metric)
 }
 
 type instancesCounter struct {
 	count map[instancesPermutation]int
+	cost  map[instancesPermutation]float64
 	lock  sync.RWMutex
 }
 
@@ -51,15 +77,7 @@ func (ic *instancesCounter) Add(project string, zone string, instances []*comput
 	defer ic.lock.Unlock()
 
 	for _, instance := range instances {
-		permutation := instancesPermutation{
-			Project:     project,
-			Zone:        zone,
-			MachineType: instance.MachineType,
-		}
-
-		if instance.Tags != nil {
-			permutation.Tags = strings.Join(instance.Tags.Items, ",")
-		}
+		permutation := newInstancesPermutation(project, zone, instance)
 
 		_, ok := ic.count[permutation]
 		if ok {
@@ -70,6 +88,13 @@ func (ic *instancesCounter) Add(project string, zone string, instances []*comput
 	}
 }
 
+func (ic *instancesCounter) AddCost(project string, zone string, instance *compute.Instance, cost float64) {
+	ic.lock.Lock()
+	defer ic.lock.Unlock()
+
+	ic.cost[newInstancesPermutation(project, zone, instance)] += cost
+}
+
 func (ic *instancesCounter) Collect(ch chan<- prometheus.Metric) {
 	for permutation, count := range ic.count {
 		ch <- prometheus.MustNewConstMetric(
@@ -82,11 +107,24 @@ func (ic *instancesCounter) Collect(ch chan<- prometheus.Metric) {
 			permutation.MachineType,
 		)
 	}
+
+	for permutation, cost := range ic.cost {
+		ch <- prometheus.MustNewConstMetric(
+			instancesEstimatedHourlyCost,
+			prometheus.GaugeValue,
+			cost,
+			permutation.Project,
+			permutation.Zone,
+			permutation.Tags,
+			permutation.MachineType,
+		)
+	}
 }
 
 var newInstancesCounter = func() instancesCounterInterface {
 	return &instancesCounter{
 		count: make(map[instancesPermutation]int),
+		cost:  make(map[instancesPermutation]float64),
 	}
 }
 
@@ -96,6 +134,10 @@ type InstancesCollector struct {
 	MatchTags []string `long:"match-tag" description:"Count instances that are matching selected tag"`
 	PerPage   int64    `long:"per-page" description:"Items to request per API page, for listing requests"`
 
+	PricingFile string `long:"pricing-file" description:"Path to YAML or JSON file with prices used to estimate hourly cost of instances"`
+
+	pricing Pricing
+
 	service   services.ComputeServiceInterface
 	instances instancesCounterInterface
 
@@ -133,6 +175,15 @@ func (c *InstancesCollector) GetData(ctx context.Context) error {
 
 			selectedInstances := c.filterInstances(instances)
 			count.Add(project, zone, selectedInstances)
+
+			if c.pricing == nil {
+				continue
+			}
+
+			err = c.addInstancesCost(ctx, count, project, zone, selectedInstances)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -141,6 +192,94 @@ func (c *InstancesCollector) GetData(ctx context.Context) error {
 	return nil
 }
 
+func (c *InstancesCollector) addInstancesCost(ctx context.Context, count instancesCounterInterface, project string, zone string, instances []*compute.Instance) error {
+	disks, err := c.service.ListDisks(ctx, project, zone, c.PerPage)
+	if err != nil {
+		return fmt.Errorf("error while requesting disks data: %v", err)
+	}
+
+	disksMap := make(map[string]*compute.Disk, len(disks))
+	for _, disk := range disks {
+		disksMap[disk.SelfLink] = disk
+	}
+
+	region := zoneRegion(zone)
+	unpriced := make(map[string]bool)
+
+	for _, instance := range instances {
+		cost, missing := c.instanceHourlyCost(region, instance, disksMap)
+		for _, item := range missing {
+			unpriced[item] = true
+		}
+
+		count.AddCost(project, zone, instance, cost)
+	}
+
+	if len(unpriced) > 0 {
+		items := make([]string, 0, len(unpriced))
+		for item := range unpriced {
+			items = append(items, item)
+		}
+		sort.Strings(items)
+
+		logrus.WithFields(logrus.Fields{
+			"project":  project,
+			"zone":     zone,
+			"unpriced": strings.Join(items, ", "),
+		}).Warningln("Missing prices; estimated cost doesn't include them")
+	}
+
+	return nil
+}
+
+// instanceHourlyCost estimates the hourly cost of instance: machine type
+// is charged only while the instance is running, attached disks always.
+func (c *InstancesCollector) instanceHourlyCost(region string, instance *compute.Instance, disks map[string]*compute.Disk) (float64, []string) {
+	cost := float64(0)
+	missing := make([]string, 0)
+
+	if instance.Status == "RUNNING" {
+		machineType := path.Base(instance.MachineType)
+		preemptible := instance.Scheduling != nil && (instance.Scheduling.Preemptible || instance.Scheduling.ProvisioningModel == "SPOT")
+
+		price, ok := c.pricing.machineTypeHourlyCost(region, machineType, preemptible)
+		if ok {
+			cost += price
+		} else {
+			missing = append(missing, fmt.Sprintf("machine type %s (preemptible: %t) in %s", machineType, preemptible, region))
+		}
+	}
+
+	for _, attachedDisk := range instance.Disks {
+		diskType := LocalSSDDiskType
+		sizeGb := attachedDisk.DiskSizeGb
+
+		if attachedDisk.Type == "SCRATCH" {
+			if sizeGb == 0 {
+				sizeGb = LocalSSDDiskSizeGb
+			}
+		} else {
+			disk, ok := disks[attachedDisk.Source]
+			if !ok {
+				missing = append(missing, fmt.Sprintf("disk %s", attachedDisk.Source))
+				continue
+			}
+
+			diskType = path.Base(disk.Type)
+			sizeGb = disk.SizeGb
+		}
+
+		price, ok := c.pricing.diskHourlyCost(region, diskType, sizeGb)
+		if ok {
+			cost += price
+		} else {
+			missing = append(missing, fmt.Sprintf("disk type %s in %s", diskType, region))
+		}
+	}
+
+	return cost, missing
+}
+
 func (c *InstancesCollector) isInitialized() bool {
 	c.initalizedLock.RLock()
 	defer c.initalizedLock.RUnlock()
@@ -180,24 +319,55 @@ func (c *InstancesCollector) filterInstances(instances []*compute.Instance) []*c
 
 func (c *InstancesCollector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- numberOfInstances
+	ch <- instancesEstimatedHourlyCost
 }
 
 func (c *InstancesCollector) Collect(ch chan<- prometheus.Metric) {
 	c.instances.Collect(ch)
 }
 
+func (c *InstancesCollector) Configuration() map[string]string {
+	return map[string]string{
+		"projects":    strings.Join(c.GetProjects(), ","),
+		"zones":       strings.Join(c.GetZones(), ","),
+		"matchTags":   strings.Join(c.MatchTags, ","),
+		"perPage":     strconv.FormatInt(c.PerPage, 10),
+		"pricingFile": c.PricingFile,
+	}
+}
+
+func (c *InstancesCollector) Targets() int {
+	return len(c.GetProjects()) * len(c.GetZones())
+}
+
+func (c *InstancesCollector) RequiredPermissions() []string {
+	if c.PricingFile != "" {
+		return []string{"compute.instances.list", "compute.disks.list"}
+	}
+
+	return []string{"compute.instances.list"}
+}
+
 func (c *InstancesCollector) Init(client *http.Client) error {
 	var err error
 
+	if c.PricingFile != "" {
+		c.pricing, err = LoadPricing(c.PricingFile)
+		if err != nil {
+			return err
+		}
+	}
+
 	c.service, err = services.NewComputeService(client)
 	if err != nil {
 		return fmt.Errorf("error while initializing computeService: %v", err)
 	}
 
 	logrus.WithFields(logrus.Fields{
-		"projects":  strings.Join(c.GetProjects(), ","),
-		"zones":     strings.Join(c.GetZones(), ","),
-		"matchTags": strings.Join(c.MatchTags, ","),
+		"projects":    strings.Join(c.GetProjects(), ","),
+		"zones":       strings.Join(c.GetZones(), ","),
+		"matchTags":   strings.Join(c.MatchTags, ","),
+		"pricingFile": c.PricingFile,
 	}).Info("Registered collector")
 
 	c.initalizedLock.Lock()
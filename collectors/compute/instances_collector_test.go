@@ -46,10 +46,30 @@ func TestInstancesCounter_Collect(t *testing.T) {
 		Tags:    "",
 	}
 	c.count[p] = 1
+	c.cost[p] = 0.5
 
 	c.Collect(ch)
 
-	assert.Len(t, ch, 1)
+	assert.Len(t, ch, 2)
+}
+
+func TestInstancesCounter_AddCost(t *testing.T) {
+	instance1 := &compute.Instance{Tags: &compute.Tags{Items: []string{"fake-tag"}}, MachineType: "n1-standard-1"}
+	instance2 := &compute.Instance{Tags: &compute.Tags{Items: []string{"fake-tag"}}, MachineType: "n1-standard-1"}
+
+	c := newInstancesCounter().(*instancesCounter)
+	c.AddCost("project", "zone", instance1, 0.25)
+	c.AddCost("project", "zone", instance2, 0.5)
+
+	assert.Len(t, c.cost, 1)
+
+	p := instancesPermutation{
+		Project:     "project",
+		Zone:        "zone",
+		Tags:        "fake-tag",
+		MachineType: "n1-standard-1",
+	}
+	assert.Equal(t, 0.75, c.cost[p])
 }
 
 func TestInstancesCollector_GetName(t *testing.T) {
@@ -210,6 +230,159 @@ func TestInstancesCollector_GetData_TagFiltered(t *testing.T) {
 	}
 }
 
+func newTestPricing() Pricing {
+	hourly := 0.2
+	preemptibleHourly := 0.05
+
+	return Pricing{
+		"us-east1": {
+			MachineTypes: map[string]*MachineTypePrice{
+				"n2-standard-2": {Hourly: &hourly, PreemptibleHourly: &preemptibleHourly},
+			},
+			DiskTypes: map[string]*DiskTypePrice{
+				"pd-ssd":         {MonthlyPerGb: 0.146},
+				LocalSSDDiskType: {MonthlyPerGb: 0.073},
+			},
+		},
+	}
+}
+
+func TestInstancesCollector_instanceHourlyCost(t *testing.T) {
+	disks := map[string]*compute.Disk{
+		"disk-1": {SelfLink: "disk-1", Type: "https://www.googleapis.com/compute/v1/projects/p/zones/us-east1-c/diskTypes/pd-ssd", SizeGb: 100},
+		"disk-2": {SelfLink: "disk-2", Type: "https://www.googleapis.com/compute/v1/projects/p/zones/us-east1-c/diskTypes/pd-balanced", SizeGb: 10},
+	}
+
+	machineType := "https://www.googleapis.com/compute/v1/projects/p/zones/us-east1-c/machineTypes/n2-standard-2"
+	diskCost := 0.146 * 100 / hoursPerMonth
+
+	examples := map[string]struct {
+		instance        *compute.Instance
+		expectedCost    float64
+		expectedMissing []string
+	}{
+		"running": {
+			instance:        &compute.Instance{Status: "RUNNING", MachineType: machineType, Disks: []*compute.AttachedDisk{{Source: "disk-1"}}},
+			expectedCost:    0.2 + diskCost,
+			expectedMissing: []string{},
+		},
+		"preemptible": {
+			instance:        &compute.Instance{Status: "RUNNING", MachineType: machineType, Scheduling: &compute.Scheduling{Preemptible: true}},
+			expectedCost:    0.05,
+			expectedMissing: []string{},
+		},
+		"spot": {
+			instance:        &compute.Instance{Status: "RUNNING", MachineType: machineType, Scheduling: &compute.Scheduling{ProvisioningModel: "SPOT"}},
+			expectedCost:    0.05,
+			expectedMissing: []string{},
+		},
+		"terminated": {
+			instance:        &compute.Instance{Status: "TERMINATED", MachineType: machineType, Disks: []*compute.AttachedDisk{{Source: "disk-1"}}},
+			expectedCost:    diskCost,
+			expectedMissing: []string{},
+		},
+		"local ssd": {
+			instance:        &compute.Instance{Status: "TERMINATED", Disks: []*compute.AttachedDisk{{Type: "SCRATCH"}}},
+			expectedCost:    0.073 * LocalSSDDiskSizeGb / hoursPerMonth,
+			expectedMissing: []string{},
+		},
+		"unpriced": {
+			instance: &compute.Instance{
+				Status:      "RUNNING",
+				MachineType: "https://www.googleapis.com/compute/v1/projects/p/zones/us-east1-c/machineTypes/e2-micro",
+				Disks:       []*compute.AttachedDisk{{Source: "disk-1"}, {Source: "disk-2"}, {Source: "disk-3"}},
+			},
+			expectedCost: diskCost,
+			expectedMissing: []string{
+				"machine type e2-micro (preemptible: false) in us-east1",
+				"disk type pd-balanced in us-east1",
+				"disk disk-3",
+			},
+		},
+	}
+
+	collector := NewInstancesCollector(&Common{})
+	collector.pricing = newTestPricing()
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			cost, missing := collector.instanceHourlyCost("us-east1", example.instance, disks)
+			assert.InDelta(t, example.expectedCost, cost, 0.000001)
+			assert.Equal(t, example.expectedMissing, missing)
+		})
+	}
+}
+
+func TestInstancesCollector_GetData_withPricing(t *testing.T) {
+	p1 := "fake-project-1"
+	z1 := "us-east1-c"
+
+	collector := NewInstancesCollector(&Common{Projects: []string{p1}, Zones: []string{z1}})
+	collector.pricing = newTestPricing()
+
+	instance := &compute.Instance{
+		Status:      "RUNNING",
+		MachineType: "https://www.googleapis.com/compute/v1/projects/p/zones/us-east1-c/machineTypes/n2-standard-2",
+	}
+	list := []*compute.Instance{instance}
+
+	service := &services.MockComputeServiceInterface{}
+	service.On("ListInstances", mock.Anything, p1, z1, mock.Anything).Return(list, nil).Once()
+	service.On("ListDisks", mock.Anything, p1, z1, mock.Anything).Return([]*compute.Disk{}, nil).Once()
+	collector.service = service
+
+	ct := &mockInstancesCounterInterface{}
+	ct.On("Add", p1, z1, list).Once()
+	ct.On("AddCost", p1, z1, instance, 0.2).Once()
+
+	newInstancesCounter = func() instancesCounterInterface {
+		return ct
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.NoError(t, err)
+	service.AssertExpectations(t)
+	ct.AssertExpectations(t)
+}
+
+func TestInstancesCollector_GetData_ListDisksError(t *testing.T) {
+	collector := NewInstancesCollector(&Common{Projects: []string{"fake-project-1"}, Zones: []string{"us-east1-c"}})
+	collector.pricing = newTestPricing()
+
+	service := &services.MockComputeServiceInterface{}
+	service.On("ListInstances", mock.Anything, "fake-project-1", "us-east1-c", mock.Anything).Return([]*compute.Instance{}, nil).Once()
+	service.On("ListDisks", mock.Anything, "fake-project-1", "us-east1-c", mock.Anything).Return(nil, fmt.Errorf("fake-list-disks-error")).Once()
+	collector.service = service
+
+	ct := &mockInstancesCounterInterface{}
+	ct.On("Add", mock.Anything, mock.Anything, mock.Anything).Once()
+
+	newInstancesCounter = func() instancesCounterInterface {
+		return ct
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error while requesting disks data: fake-list-disks-error")
+	service.AssertExpectations(t)
+}
+
+func TestInstancesCollector_Init_invalidPricingFile(t *testing.T) {
+	collector := NewInstancesCollector(&Common{})
+	collector.PricingFile = "/non/existing/pricing.yml"
+
+	err := collector.Init(http.DefaultClient)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not read pricing file")
+}
+
 func TestInstancesCollector_GetData_ListInstancesError(t *testing.T) {
 	collector := NewInstancesCollector(&Common{})
 	collector.Projects = append(collector.Projects, "fake-project-1")
@@ -235,7 +408,7 @@ func TestInstancesCollector_Describe(t *testing.T) {
 	collector := NewInstancesCollector(&Common{})
 	collector.Describe(ch)
 
-	assert.Len(t, ch, 1)
+	assert.Len(t, ch, 2)
 }
 
 func TestInstancesCollector_Collect(t *testing.T) {
@@ -254,3 +427,19 @@ func TestInstancesCollector_Collect(t *testing.T) {
 
 	ct.AssertExpectations(t)
 }
+
+func TestInstancesCollector_Configuration(t *testing.T) {
+	collector := NewInstancesCollector(&Common{
+		Projects: []string{"fake-project-1", "fake-project-2"},
+		Zones:    []string{"fake-zone-1", "fake-zone-2", "fake-zone-3"},
+	})
+	collector.MatchTags = []string{"tag-1"}
+
+	configuration := collector.Configuration()
+	assert.Equal(t, "fake-project-1,fake-project-2", configuration["projects"])
+	assert.Equal(t, "fake-zone-1,fake-zone-2,fake-zone-3", configuration["zones"])
+	assert.Equal(t, "tag-1", configuration["matchTags"])
+	assert.Equal(t, "500", configuration["perPage"])
+
+	assert.Equal(t, 6, collector.Targets())
+}
@@ -0,0 +1,26 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package compute
+
+import (
+	prometheus "github.com/prometheus/client_golang/prometheus"
+	mock "github.com/stretchr/testify/mock"
+	compute "google.golang.org/api/compute/v1"
+)
+
+// mockInstanceGroupsCounterInterface is an autogenerated mock type for the instanceGroupsCounterInterface type
+type mockInstanceGroupsCounterInterface struct {
+	mock.Mock
+}
+
+// Add provides a mock function with given fields: _a0, _a1, _a2, _a3, _a4
+func (_m *mockInstanceGroupsCounterInterface) Add(_a0 string, _a1 string, _a2 []*compute.InstanceGroup, _a3 []*compute.InstanceGroupManager, _a4 []*compute.Autoscaler) {
+	_m.Called(_a0, _a1, _a2, _a3, _a4)
+}
+
+// Collect provides a mock function with given fields: _a0
+func (_m *mockInstanceGroupsCounterInterface) Collect(_a0 chan<- prometheus.Metric) {
+	_m.Called(_a0)
+}
@@ -20,6 +20,11 @@ func (_m *mockInstancesCounterInterface) Add(_a0 string, _a1 string, _a2 []*comp
 	_m.Called(_a0, _a1, _a2)
 }
 
+// AddCost provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *mockInstancesCounterInterface) AddCost(_a0 string, _a1 string, _a2 *compute.Instance, _a3 float64) {
+	_m.Called(_a0, _a1, _a2, _a3)
+}
+
 // Collect provides a mock function with given fields: _a0
 func (_m *mockInstancesCounterInterface) Collect(_a0 chan<- prometheus.Metric) {
 	_m.Called(_a0)
@@ -0,0 +1,36 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package compute
+
+import (
+	prometheus "github.com/prometheus/client_golang/prometheus"
+	mock "github.com/stretchr/testify/mock"
+	compute "google.golang.org/api/compute/v1"
+)
+
+// mockNetworkingCounterInterface is an autogenerated mock type for the networkingCounterInterface type
+type mockNetworkingCounterInterface struct {
+	mock.Mock
+}
+
+// AddAddresses provides a mock function with given fields: _a0, _a1, _a2
+func (_m *mockNetworkingCounterInterface) AddAddresses(_a0 string, _a1 string, _a2 []*compute.Address) {
+	_m.Called(_a0, _a1, _a2)
+}
+
+// AddFirewalls provides a mock function with given fields: _a0, _a1
+func (_m *mockNetworkingCounterInterface) AddFirewalls(_a0 string, _a1 []*compute.Firewall) {
+	_m.Called(_a0, _a1)
+}
+
+// AddForwardingRules provides a mock function with given fields: _a0, _a1, _a2
+func (_m *mockNetworkingCounterInterface) AddForwardingRules(_a0 string, _a1 string, _a2 []*compute.ForwardingRule) {
+	_m.Called(_a0, _a1, _a2)
+}
+
+// Collect provides a mock function with given fields: _a0
+func (_m *mockNetworkingCounterInterface) Collect(_a0 chan<- prometheus.Metric) {
+	_m.Called(_a0)
+}
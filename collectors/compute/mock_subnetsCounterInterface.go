@@ -0,0 +1,36 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package compute
+
+import (
+	prometheus "github.com/prometheus/client_golang/prometheus"
+	mock "github.com/stretchr/testify/mock"
+	compute "google.golang.org/api/compute/v1"
+)
+
+// mockSubnetsCounterInterface is an autogenerated mock type for the subnetsCounterInterface type
+type mockSubnetsCounterInterface struct {
+	mock.Mock
+}
+
+// Add provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *mockSubnetsCounterInterface) Add(_a0 string, _a1 string, _a2 []*compute.Subnetwork, _a3 []*compute.Instance) {
+	_m.Called(_a0, _a1, _a2, _a3)
+}
+
+// AddAddresses provides a mock function with given fields: _a0, _a1, _a2
+func (_m *mockSubnetsCounterInterface) AddAddresses(_a0 string, _a1 string, _a2 []*compute.Address) {
+	_m.Called(_a0, _a1, _a2)
+}
+
+// AddForwardingRules provides a mock function with given fields: _a0, _a1, _a2
+func (_m *mockSubnetsCounterInterface) AddForwardingRules(_a0 string, _a1 string, _a2 []*compute.ForwardingRule) {
+	_m.Called(_a0, _a1, _a2)
+}
+
+// Collect provides a mock function with given fields: _a0
+func (_m *mockSubnetsCounterInterface) Collect(_a0 chan<- prometheus.Metric) {
+	_m.Called(_a0)
+}
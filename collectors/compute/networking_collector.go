@@ -0,0 +1,311 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/compute/v1"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+)
+
+const (
+	NetworkingCollectorName = "networking-collector"
+	GlobalRegion            = "global"
+)
+
+var (
+	numberOfAddresses = prometheus.NewDesc(
+		"gcp_exporter_addresses_count",
+		"Current number of reserved static addresses",
+		[]string{"project", "region", "status", "address_type"},
+		nil,
+	)
+
+	numberOfForwardingRules = prometheus.NewDesc(
+		"gcp_exporter_forwarding_rules_count",
+		"Current number of forwarding rules",
+		[]string{"project", "region", "load_balancing_scheme"},
+		nil,
+	)
+
+	numberOfFirewallRules = prometheus.NewDesc(
+		"gcp_exporter_firewall_rules_count",
+		"Current number of firewall rules",
+		[]string{"project", "network"},
+		nil,
+	)
+)
+
+type addressesPermutation struct {
+	Project     string
+	Region      string
+	Status      string
+	AddressType string
+}
+
+type forwardingRulesPermutation struct {
+	Project             string
+	Region              string
+	LoadBalancingScheme string
+}
+
+type firewallRulesPermutation struct {
+	Project string
+	Network string
+}
+
+type networkingCounterInterface interface {
+	AddAddresses(string, string, []*compute.Address)
+	AddForwardingRules(string, string, []*compute.ForwardingRule)
+	AddFirewalls(string, []*compute.Firewall)
+	Collect(chan<- prometheus.Metric)
+}
+
+type networkingCounter struct {
+	addresses       map[addressesPermutation]int
+	forwardingRules map[forwardingRulesPermutation]int
+	firewallRules   map[firewallRulesPermutation]int
+	lock            sync.RWMutex
+}
+
+func (nc *networkingCounter) AddAddresses(project string, region string, addresses []*compute.Address) {
+	nc.lock.Lock()
+	defer nc.lock.Unlock()
+
+	for _, address := range addresses {
+		permutation := addressesPermutation{
+			Project:     project,
+			Region:      region,
+			Status:      address.Status,
+			AddressType: address.AddressType,
+		}
+
+		nc.addresses[permutation]++
+	}
+}
+
+func (nc *networkingCounter) AddForwardingRules(project string, region string, rules []*compute.ForwardingRule) {
+	nc.lock.Lock()
+	defer nc.lock.Unlock()
+
+	for _, rule := range rules {
+		permutation := forwardingRulesPermutation{
+			Project:             project,
+			Region:              region,
+			LoadBalancingScheme: rule.LoadBalancingScheme,
+		}
+
+		nc.forwardingRules[permutation]++
+	}
+}
+
+func (nc *networkingCounter) AddFirewalls(project string, firewalls []*compute.Firewall) {
+	nc.lock.Lock()
+	defer nc.lock.Unlock()
+
+	for _, firewall := range firewalls {
+		permutation := firewallRulesPermutation{
+			Project: project,
+			Network: path.Base(firewall.Network),
+		}
+
+		nc.firewallRules[permutation]++
+	}
+}
+
+func (nc *networkingCounter) Collect(ch chan<- prometheus.Metric) {
+	for permutation, count := range nc.addresses {
+		ch <- prometheus.MustNewConstMetric(
+			numberOfAddresses,
+			prometheus.GaugeValue,
+			float64(count),
+			permutation.Project,
+			permutation.Region,
+			permutation.Status,
+			permutation.AddressType,
+		)
+	}
+
+	for permutation, count := range nc.forwardingRules {
+		ch <- prometheus.MustNewConstMetric(
+			numberOfForwardingRules,
+			prometheus.GaugeValue,
+			float64(count),
+			permutation.Project,
+			permutation.Region,
+			permutation.LoadBalancingScheme,
+		)
+	}
+
+	for permutation, count := range nc.firewallRules {
+		ch <- prometheus.MustNewConstMetric(
+			numberOfFirewallRules,
+			prometheus.GaugeValue,
+			float64(count),
+			permutation.Project,
+			permutation.Network,
+		)
+	}
+}
+
+var newNetworkingCounter = func() networkingCounterInterface {
+	return &networkingCounter{
+		addresses:       make(map[addressesPermutation]int),
+		forwardingRules: make(map[forwardingRulesPermutation]int),
+		firewallRules:   make(map[firewallRulesPermutation]int),
+	}
+}
+
+type NetworkingCollector struct {
+	*Common
+
+	service    services.ComputeServiceInterface
+	networking networkingCounterInterface
+
+	initialized    bool
+	initalizedLock sync.RWMutex
+}
+
+func (c *NetworkingCollector) GetName() string {
+	return NetworkingCollectorName
+}
+
+func (c *NetworkingCollector) GetData(ctx context.Context) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("networking collector not initialized")
+	}
+
+	if c.service == nil {
+		return fmt.Errorf("networking collector compute.Service is not initialized")
+	}
+
+	count := newNetworkingCounter()
+	for _, project := range c.GetProjects() {
+		for _, region := range c.GetRegions() {
+			logrus.WithFields(logrus.Fields{
+				"project": project,
+				"region":  region,
+			}).Debugf("Requesting addresses and forwarding rules")
+
+			addresses, err := c.service.ListAddresses(ctx, project, region, PerPage)
+			if err != nil {
+				return fmt.Errorf("error while requesting addresses data: %v", err)
+			}
+			count.AddAddresses(project, region, addresses)
+
+			rules, err := c.service.ListForwardingRules(ctx, project, region, PerPage)
+			if err != nil {
+				return fmt.Errorf("error while requesting forwarding rules data: %v", err)
+			}
+			count.AddForwardingRules(project, region, rules)
+		}
+
+		err := c.getGlobalData(ctx, count, project)
+		if err != nil {
+			return err
+		}
+	}
+
+	c.networking = count
+
+	return nil
+}
+
+func (c *NetworkingCollector) getGlobalData(ctx context.Context, count networkingCounterInterface, project string) error {
+	logrus.WithField("project", project).Debugf("Requesting global addresses, forwarding rules and firewall rules")
+
+	addresses, err := c.service.ListGlobalAddresses(ctx, project, PerPage)
+	if err != nil {
+		return fmt.Errorf("error while requesting global addresses data: %v", err)
+	}
+	count.AddAddresses(project, GlobalRegion, addresses)
+
+	rules, err := c.service.ListGlobalForwardingRules(ctx, project, PerPage)
+	if err != nil {
+		return fmt.Errorf("error while requesting global forwarding rules data: %v", err)
+	}
+	count.AddForwardingRules(project, GlobalRegion, rules)
+
+	firewalls, err := c.service.ListFirewalls(ctx, project, PerPage)
+	if err != nil {
+		return fmt.Errorf("error while requesting firewall rules data: %v", err)
+	}
+	count.AddFirewalls(project, firewalls)
+
+	return nil
+}
+
+func (c *NetworkingCollector) isInitialized() bool {
+	c.initalizedLock.RLock()
+	defer c.initalizedLock.RUnlock()
+
+	return c.initialized
+}
+
+func (c *NetworkingCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- numberOfAddresses
+	ch <- numberOfForwardingRules
+	ch <- numberOfFirewallRules
+}
+
+func (c *NetworkingCollector) Collect(ch chan<- prometheus.Metric) {
+	c.networking.Collect(ch)
+}
+
+func (c *NetworkingCollector) Configuration() map[string]string {
+	return map[string]string{
+		"projects": strings.Join(c.GetProjects(), ","),
+		"regions":  strings.Join(c.GetRegions(), ","),
+	}
+}
+
+func (c *NetworkingCollector) Targets() int {
+	return len(c.GetProjects()) * (len(c.GetRegions()) + 1)
+}
+
+func (c *NetworkingCollector) RequiredPermissions() []string {
+	return []string{
+		"compute.addresses.list",
+		"compute.globalAddresses.list",
+		"compute.forwardingRules.list",
+		"compute.globalForwardingRules.list",
+		"compute.firewalls.list",
+	}
+}
+
+func (c *NetworkingCollector) Init(client *http.Client) error {
+	var err error
+
+	c.service, err = services.NewComputeService(client)
+	if err != nil {
+		return fmt.Errorf("error while initializing computeService: %v", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"projects": strings.Join(c.GetProjects(), ","),
+		"regions":  strings.Join(c.GetRegions(), ","),
+	}).Info("Registered collector")
+
+	c.initalizedLock.Lock()
+	defer c.initalizedLock.Unlock()
+
+	c.initialized = true
+
+	return nil
+}
+
+func NewNetworkingCollector(c *Common) *NetworkingCollector {
+	return &NetworkingCollector{
+		Common:      c,
+		networking:  newNetworkingCounter(),
+		initialized: false,
+	}
+}
@@ -0,0 +1,184 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+)
+
+func TestNetworkingCounter_AddAddresses(t *testing.T) {
+	c := newNetworkingCounter().(*networkingCounter)
+	c.AddAddresses("project", "region", []*compute.Address{
+		{Status: "RESERVED", AddressType: "EXTERNAL"},
+		{Status: "RESERVED", AddressType: "EXTERNAL"},
+		{Status: "IN_USE", AddressType: "INTERNAL"},
+	})
+
+	assert.Len(t, c.addresses, 2)
+	assert.Equal(t, 2, c.addresses[addressesPermutation{Project: "project", Region: "region", Status: "RESERVED", AddressType: "EXTERNAL"}])
+	assert.Equal(t, 1, c.addresses[addressesPermutation{Project: "project", Region: "region", Status: "IN_USE", AddressType: "INTERNAL"}])
+}
+
+func TestNetworkingCounter_AddForwardingRules(t *testing.T) {
+	c := newNetworkingCounter().(*networkingCounter)
+	c.AddForwardingRules("project", "global", []*compute.ForwardingRule{
+		{LoadBalancingScheme: "EXTERNAL"},
+		{LoadBalancingScheme: "INTERNAL_MANAGED"},
+		{LoadBalancingScheme: "EXTERNAL"},
+	})
+
+	assert.Len(t, c.forwardingRules, 2)
+	assert.Equal(t, 2, c.forwardingRules[forwardingRulesPermutation{Project: "project", Region: "global", LoadBalancingScheme: "EXTERNAL"}])
+	assert.Equal(t, 1, c.forwardingRules[forwardingRulesPermutation{Project: "project", Region: "global", LoadBalancingScheme: "INTERNAL_MANAGED"}])
+}
+
+func TestNetworkingCounter_AddFirewalls(t *testing.T) {
+	c := newNetworkingCounter().(*networkingCounter)
+	c.AddFirewalls("project", []*compute.Firewall{
+		{Network: "https://www.googleapis.com/compute/v1/projects/project/global/networks/default"},
+		{Network: "https://www.googleapis.com/compute/v1/projects/project/global/networks/default"},
+		{Network: "https://www.googleapis.com/compute/v1/projects/project/global/networks/runners"},
+	})
+
+	assert.Len(t, c.firewallRules, 2)
+	assert.Equal(t, 2, c.firewallRules[firewallRulesPermutation{Project: "project", Network: "default"}])
+	assert.Equal(t, 1, c.firewallRules[firewallRulesPermutation{Project: "project", Network: "runners"}])
+}
+
+func TestNetworkingCounter_Collect(t *testing.T) {
+	ch := make(chan prometheus.Metric, 50)
+	defer close(ch)
+
+	c := newNetworkingCounter().(*networkingCounter)
+	c.addresses[addressesPermutation{Project: "project", Region: "region", Status: "RESERVED", AddressType: "EXTERNAL"}] = 1
+	c.forwardingRules[forwardingRulesPermutation{Project: "project", Region: "region", LoadBalancingScheme: "EXTERNAL"}] = 1
+	c.firewallRules[firewallRulesPermutation{Project: "project", Network: "default"}] = 1
+
+	c.Collect(ch)
+
+	assert.Len(t, ch, 3)
+}
+
+func TestNetworkingCollector_GetName(t *testing.T) {
+	collector := NewNetworkingCollector(&Common{})
+	assert.Equal(t, "networking-collector", collector.GetName())
+}
+
+func TestNetworkingCollector_Init(t *testing.T) {
+	collector := NewNetworkingCollector(&Common{})
+	err := collector.Init(http.DefaultClient)
+
+	assert.NoError(t, err)
+}
+
+func TestNetworkingCollector_GetData_withoutInitialize(t *testing.T) {
+	collector := NewNetworkingCollector(&Common{})
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "networking collector not initialized")
+}
+
+func TestNetworkingCollector_GetData(t *testing.T) {
+	p1 := "fake-project-1"
+	r1 := "fake-region-1"
+
+	collector := NewNetworkingCollector(&Common{Projects: []string{p1}, Zones: []string{"fake-region-1-a"}})
+
+	addresses := []*compute.Address{{Name: "address-1"}}
+	globalAddresses := []*compute.Address{{Name: "global-address-1"}}
+	rules := []*compute.ForwardingRule{{Name: "rule-1"}}
+	globalRules := make([]*compute.ForwardingRule, 0)
+	firewalls := []*compute.Firewall{{Name: "firewall-1"}}
+
+	service := &services.MockComputeServiceInterface{}
+	service.On("ListAddresses", mock.Anything, p1, r1, int64(PerPage)).Return(addresses, nil).Once()
+	service.On("ListForwardingRules", mock.Anything, p1, r1, int64(PerPage)).Return(rules, nil).Once()
+	service.On("ListGlobalAddresses", mock.Anything, p1, int64(PerPage)).Return(globalAddresses, nil).Once()
+	service.On("ListGlobalForwardingRules", mock.Anything, p1, int64(PerPage)).Return(globalRules, nil).Once()
+	service.On("ListFirewalls", mock.Anything, p1, int64(PerPage)).Return(firewalls, nil).Once()
+	collector.service = service
+
+	ct := &mockNetworkingCounterInterface{}
+	ct.On("AddAddresses", p1, r1, addresses).Once()
+	ct.On("AddAddresses", p1, GlobalRegion, globalAddresses).Once()
+	ct.On("AddForwardingRules", p1, r1, rules).Once()
+	ct.On("AddForwardingRules", p1, GlobalRegion, globalRules).Once()
+	ct.On("AddFirewalls", p1, firewalls).Once()
+
+	newNetworkingCounter = func() networkingCounterInterface {
+		return ct
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.NoError(t, err)
+	service.AssertExpectations(t)
+	ct.AssertExpectations(t)
+}
+
+func TestNetworkingCollector_GetData_ListFirewallsError(t *testing.T) {
+	collector := NewNetworkingCollector(&Common{Projects: []string{"fake-project-1"}})
+
+	service := &services.MockComputeServiceInterface{}
+	service.On("ListGlobalAddresses", mock.Anything, "fake-project-1", mock.Anything).Return(make([]*compute.Address, 0), nil).Once()
+	service.On("ListGlobalForwardingRules", mock.Anything, "fake-project-1", mock.Anything).Return(make([]*compute.ForwardingRule, 0), nil).Once()
+	service.On("ListFirewalls", mock.Anything, "fake-project-1", mock.Anything).Return(nil, fmt.Errorf("fake-list-error")).Once()
+	collector.service = service
+
+	ct := &mockNetworkingCounterInterface{}
+	ct.On("AddAddresses", "fake-project-1", GlobalRegion, mock.Anything).Once()
+	ct.On("AddForwardingRules", "fake-project-1", GlobalRegion, mock.Anything).Once()
+
+	newNetworkingCounter = func() networkingCounterInterface {
+		return ct
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error while requesting firewall rules data: fake-list-error")
+	service.AssertExpectations(t)
+}
+
+func TestNetworkingCollector_Describe(t *testing.T) {
+	ch := make(chan<- *prometheus.Desc, 50)
+	defer close(ch)
+
+	collector := NewNetworkingCollector(&Common{})
+	collector.Describe(ch)
+
+	assert.Len(t, ch, 3)
+}
+
+func TestNetworkingCollector_Collect(t *testing.T) {
+	ch := make(chan<- prometheus.Metric, 50)
+	defer close(ch)
+
+	ct := &mockNetworkingCounterInterface{}
+	ct.On("Collect", ch).Once()
+
+	newNetworkingCounter = func() networkingCounterInterface {
+		return ct
+	}
+
+	collector := NewNetworkingCollector(&Common{})
+	collector.Collect(ch)
+
+	ct.AssertExpectations(t)
+}
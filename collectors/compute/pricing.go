@@ -0,0 +1,114 @@
+package compute
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	hoursPerMonth = 730
+
+	LocalSSDDiskType   = "local-ssd"
+	LocalSSDDiskSizeGb = 375
+)
+
+type MachineTypePrice struct {
+	Hourly            *float64 `yaml:"hourly"`
+	PreemptibleHourly *float64 `yaml:"preemptible_hourly"`
+}
+
+type DiskTypePrice struct {
+	MonthlyPerGb float64 `yaml:"monthly_per_gb"`
+}
+
+type RegionPricing struct {
+	MachineTypes map[string]*MachineTypePrice `yaml:"machine_types"`
+	DiskTypes    map[string]*DiskTypePrice    `yaml:"disk_types"`
+}
+
+type Pricing map[string]*RegionPricing
+
+func (p Pricing) validate() error {
+	for region, pricing := range p {
+		if pricing == nil {
+			return fmt.Errorf("no prices defined for region %s", region)
+		}
+
+		for machineType, price := range pricing.MachineTypes {
+			if price == nil || (price.Hourly == nil && price.PreemptibleHourly == nil) {
+				return fmt.Errorf("no prices defined for machine type %s in region %s", machineType, region)
+			}
+
+			if (price.Hourly != nil && *price.Hourly < 0) || (price.PreemptibleHourly != nil && *price.PreemptibleHourly < 0) {
+				return fmt.Errorf("negative price of machine type %s in region %s", machineType, region)
+			}
+		}
+
+		for diskType, price := range pricing.DiskTypes {
+			if price == nil || price.MonthlyPerGb < 0 {
+				return fmt.Errorf("invalid price of disk type %s in region %s", diskType, region)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (p Pricing) machineTypeHourlyCost(region string, machineType string, preemptible bool) (float64, bool) {
+	pricing, ok := p[region]
+	if !ok {
+		return 0, false
+	}
+
+	price, ok := pricing.MachineTypes[machineType]
+	if !ok {
+		return 0, false
+	}
+
+	hourly := price.Hourly
+	if preemptible {
+		hourly = price.PreemptibleHourly
+	}
+
+	if hourly == nil {
+		return 0, false
+	}
+
+	return *hourly, true
+}
+
+func (p Pricing) diskHourlyCost(region string, diskType string, sizeGb int64) (float64, bool) {
+	pricing, ok := p[region]
+	if !ok {
+		return 0, false
+	}
+
+	price, ok := pricing.DiskTypes[diskType]
+	if !ok {
+		return 0, false
+	}
+
+	return price.MonthlyPerGb * float64(sizeGb) / hoursPerMonth, true
+}
+
+func LoadPricing(path string) (Pricing, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read pricing file: %v", err)
+	}
+
+	pricing := make(Pricing)
+	err = yaml.UnmarshalStrict(data, &pricing)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse pricing file: %v", err)
+	}
+
+	err = pricing.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid pricing file: %v", err)
+	}
+
+	return pricing, nil
+}
@@ -0,0 +1,121 @@
+package compute
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tests"
+)
+
+func writePricing(t *testing.T, dir string, name string, content string) string {
+	file := filepath.Join(dir, name)
+	require.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+
+	return file
+}
+
+func TestLoadPricing(t *testing.T) {
+	examples := map[string]string{
+		"pricing.yml": `
+us-east1:
+  machine_types:
+    n2-standard-2:
+      hourly: 0.2
+      preemptible_hourly: 0.05
+    n2-standard-4:
+      hourly: 0.4
+  disk_types:
+    pd-ssd:
+      monthly_per_gb: 0.146
+`,
+		"pricing.json": `{
+  "us-east1": {
+    "machine_types": {
+      "n2-standard-2": {"hourly": 0.2, "preemptible_hourly": 0.05},
+      "n2-standard-4": {"hourly": 0.4}
+    },
+    "disk_types": {
+      "pd-ssd": {"monthly_per_gb": 0.146}
+    }
+  }
+}`,
+	}
+
+	for name, content := range examples {
+		t.Run(name, func(t *testing.T) {
+			tests.RunOnTempDir(t, "pricing", func(t *testing.T, dir string) {
+				pricing, err := LoadPricing(writePricing(t, dir, name, content))
+				require.NoError(t, err)
+
+				cost, ok := pricing.machineTypeHourlyCost("us-east1", "n2-standard-2", false)
+				assert.True(t, ok)
+				assert.Equal(t, 0.2, cost)
+
+				cost, ok = pricing.machineTypeHourlyCost("us-east1", "n2-standard-2", true)
+				assert.True(t, ok)
+				assert.Equal(t, 0.05, cost)
+
+				_, ok = pricing.machineTypeHourlyCost("us-east1", "n2-standard-4", true)
+				assert.False(t, ok)
+
+				_, ok = pricing.machineTypeHourlyCost("us-west1", "n2-standard-2", false)
+				assert.False(t, ok)
+
+				cost, ok = pricing.diskHourlyCost("us-east1", "pd-ssd", 730)
+				assert.True(t, ok)
+				assert.InDelta(t, 0.146, cost, 0.000001)
+
+				_, ok = pricing.diskHourlyCost("us-east1", "pd-standard", 10)
+				assert.False(t, ok)
+			})
+		})
+	}
+}
+
+func TestLoadPricing_invalid(t *testing.T) {
+	examples := map[string]struct {
+		content       string
+		expectedError string
+	}{
+		"unknown field": {
+			content:       "us-east1:\n  machine_types:\n    n2-standard-2:\n      price: 0.2\n",
+			expectedError: "could not parse pricing file",
+		},
+		"empty region": {
+			content:       "us-east1:\n",
+			expectedError: "no prices defined for region us-east1",
+		},
+		"no machine type prices": {
+			content:       "us-east1:\n  machine_types:\n    n2-standard-2: {}\n",
+			expectedError: "no prices defined for machine type n2-standard-2 in region us-east1",
+		},
+		"negative machine type price": {
+			content:       "us-east1:\n  machine_types:\n    n2-standard-2:\n      hourly: -1\n",
+			expectedError: "negative price of machine type n2-standard-2 in region us-east1",
+		},
+		"negative disk price": {
+			content:       "us-east1:\n  disk_types:\n    pd-ssd:\n      monthly_per_gb: -1\n",
+			expectedError: "invalid price of disk type pd-ssd in region us-east1",
+		},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			tests.RunOnTempDir(t, "pricing", func(t *testing.T, dir string) {
+				_, err := LoadPricing(writePricing(t, dir, "pricing.yml", example.content))
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), example.expectedError)
+			})
+		})
+	}
+}
+
+func TestLoadPricing_missingFile(t *testing.T) {
+	_, err := LoadPricing("/non/existing/pricing.yml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not read pricing file")
+}
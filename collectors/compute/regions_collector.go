@@ -168,6 +168,21 @@ func (c *RegionsCollector) Collect(ch chan<- prometheus.Metric) {
 	c.regionQuotas.Collect(ch)
 }
 
+func (c *RegionsCollector) Configuration() map[string]string {
+	return map[string]string{
+		"projects": strings.Join(c.GetProjects(), ","),
+		"regions":  strings.Join(c.GetRegions(), ","),
+	}
+}
+
+func (c *RegionsCollector) Targets() int {
+	return len(c.GetProjects()) * len(c.GetRegions())
+}
+
+func (c *RegionsCollector) RequiredPermissions() []string {
+	return []string{"compute.regions.get"}
+}
+
 func (c *RegionsCollector) Init(client *http.Client) error {
 	var err error
 
@@ -177,3 +177,16 @@ func TestRegionsCollector_Collect(t *testing.T) {
 
 	ct.AssertExpectations(t)
 }
+
+func TestRegionsCollector_Configuration(t *testing.T) {
+	collector := NewRegionsCollector(&Common{
+		Projects: []string{"fake-project-1", "fake-project-2"},
+		Zones:    []string{"fake-zone-1", "fake-zone-2"},
+	})
+
+	configuration := collector.Configuration()
+	assert.Equal(t, "fake-project-1,fake-project-2", configuration["projects"])
+	assert.Equal(t, "fake-zone", configuration["regions"])
+
+	assert.Equal(t, 2, collector.Targets())
+}
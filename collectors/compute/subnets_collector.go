@@ -0,0 +1,402 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/compute/v1"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+)
+
+const (
+	SubnetsCollectorName = "subnets-collector"
+
+	PrimaryRangeName = "primary"
+
+	// GCP reserves the network, gateway, second-to-last and broadcast addresses
+	// of the primary range of each subnet
+	primaryRangeReservedAddresses = 4
+)
+
+var (
+	subnetIPAddressesTotal = prometheus.NewDesc(
+		"gcp_exporter_subnet_ip_addresses_total",
+		"Number of usable IP addresses in the subnet range",
+		[]string{"project", "region", "network", "subnet", "range"},
+		nil,
+	)
+
+	subnetIPAddressesAllocated = prometheus.NewDesc(
+		"gcp_exporter_subnet_ip_addresses_allocated",
+		"Number of IP addresses of the subnet range allocated to instance network interfaces, alias IP ranges, internal addresses and internal forwarding rules",
+		[]string{"project", "region", "network", "subnet", "range"},
+		nil,
+	)
+
+	subnetIPUtilization = prometheus.NewDesc(
+		"gcp_exporter_subnet_ip_utilization_ratio",
+		"Ratio of allocated to usable IP addresses in the subnet range",
+		[]string{"project", "region", "network", "subnet", "range"},
+		nil,
+	)
+)
+
+type subnetsPermutation struct {
+	Project string
+	Region  string
+	Network string
+	Subnet  string
+	Range   string
+}
+
+type subnetRangeUsage struct {
+	Total     float64
+	Allocated float64
+}
+
+type subnetsCounterInterface interface {
+	Add(string, string, []*compute.Subnetwork, []*compute.Instance)
+	AddAddresses(string, string, []*compute.Address)
+	AddForwardingRules(string, string, []*compute.ForwardingRule)
+	Collect(chan<- prometheus.Metric)
+}
+
+type subnetsCounter struct {
+	count   map[subnetsPermutation]*subnetRangeUsage
+	subnets map[string]*compute.Subnetwork
+	// Internal forwarding rules usually use a reserved internal address and
+	// a reserved address may be assigned to an instance, so addresses of
+	// primary ranges are counted only once.
+	primaryIPs map[subnetsPermutation]map[string]bool
+	lock       sync.RWMutex
+}
+
+func (sc *subnetsCounter) Add(project string, region string, subnets []*compute.Subnetwork, instances []*compute.Instance) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	for _, subnet := range subnets {
+		sc.subnets[subnet.SelfLink] = subnet
+
+		sc.addRange(project, region, subnet, PrimaryRangeName, subnet.IpCidrRange, primaryRangeReservedAddresses)
+		for _, secondaryRange := range subnet.SecondaryIpRanges {
+			sc.addRange(project, region, subnet, secondaryRange.RangeName, secondaryRange.IpCidrRange, 0)
+		}
+	}
+
+	for _, instance := range instances {
+		for _, networkInterface := range instance.NetworkInterfaces {
+			subnet, ok := sc.subnets[networkInterface.Subnetwork]
+			if !ok {
+				continue
+			}
+
+			sc.allocatePrimaryIP(project, region, subnet, networkInterface.NetworkIP)
+
+			for _, aliasRange := range networkInterface.AliasIpRanges {
+				size, err := cidrSize(aliasRange.IpCidrRange)
+				if err != nil {
+					logrus.WithError(err).WithField("instance", instance.Name).Warningln("Invalid alias IP range")
+					continue
+				}
+
+				rangeName := aliasRange.SubnetworkRangeName
+				if rangeName == "" {
+					rangeName = PrimaryRangeName
+				}
+
+				sc.allocate(project, region, subnet, rangeName, size)
+			}
+		}
+	}
+}
+
+func (sc *subnetsCounter) AddAddresses(project string, region string, addresses []*compute.Address) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	for _, address := range addresses {
+		if address.AddressType != "INTERNAL" {
+			continue
+		}
+
+		subnet, ok := sc.subnets[address.Subnetwork]
+		if !ok {
+			continue
+		}
+
+		sc.allocatePrimaryIP(project, region, subnet, address.Address)
+	}
+}
+
+func (sc *subnetsCounter) AddForwardingRules(project string, region string, rules []*compute.ForwardingRule) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	for _, rule := range rules {
+		if !strings.HasPrefix(rule.LoadBalancingScheme, "INTERNAL") {
+			continue
+		}
+
+		subnet, ok := sc.subnets[rule.Subnetwork]
+		if !ok {
+			continue
+		}
+
+		sc.allocatePrimaryIP(project, region, subnet, rule.IPAddress)
+	}
+}
+
+func (sc *subnetsCounter) addRange(project string, region string, subnet *compute.Subnetwork, rangeName string, cidr string, reserved float64) {
+	size, err := cidrSize(cidr)
+	if err != nil {
+		logrus.WithError(err).WithField("subnet", subnet.Name).Warningln("Invalid subnet IP range")
+		return
+	}
+
+	sc.count[newSubnetsPermutation(project, region, subnet, rangeName)] = &subnetRangeUsage{
+		Total: math.Max(size-reserved, 0),
+	}
+}
+
+func (sc *subnetsCounter) allocatePrimaryIP(project string, region string, subnet *compute.Subnetwork, ip string) {
+	if ip == "" {
+		return
+	}
+
+	permutation := newSubnetsPermutation(project, region, subnet, PrimaryRangeName)
+
+	ips, ok := sc.primaryIPs[permutation]
+	if !ok {
+		ips = make(map[string]bool)
+		sc.primaryIPs[permutation] = ips
+	}
+
+	if ips[ip] {
+		return
+	}
+	ips[ip] = true
+
+	sc.allocate(project, region, subnet, PrimaryRangeName, 1)
+}
+
+func (sc *subnetsCounter) allocate(project string, region string, subnet *compute.Subnetwork, rangeName string, size float64) {
+	usage, ok := sc.count[newSubnetsPermutation(project, region, subnet, rangeName)]
+	if !ok {
+		return
+	}
+
+	usage.Allocated += size
+}
+
+func newSubnetsPermutation(project string, region string, subnet *compute.Subnetwork, rangeName string) subnetsPermutation {
+	return subnetsPermutation{
+		Project: project,
+		Region:  region,
+		Network: path.Base(subnet.Network),
+		Subnet:  subnet.Name,
+		Range:   rangeName,
+	}
+}
+
+func cidrSize(cidr string) (float64, error) {
+	var ones, bits int
+
+	if strings.HasPrefix(cidr, "/") {
+		prefix, err := strconv.Atoi(strings.TrimPrefix(cidr, "/"))
+		if err != nil || prefix < 0 || prefix > 32 {
+			return 0, fmt.Errorf("invalid CIDR %q", cidr)
+		}
+
+		ones, bits = prefix, 32
+	} else {
+		_, network, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return 0, err
+		}
+
+		ones, bits = network.Mask.Size()
+	}
+
+	return math.Pow(2, float64(bits-ones)), nil
+}
+
+func (sc *subnetsCounter) Collect(ch chan<- prometheus.Metric) {
+	for permutation, usage := range sc.count {
+		labels := []string{
+			permutation.Project,
+			permutation.Region,
+			permutation.Network,
+			permutation.Subnet,
+			permutation.Range,
+		}
+
+		ch <- prometheus.MustNewConstMetric(subnetIPAddressesTotal, prometheus.GaugeValue, usage.Total, labels...)
+		ch <- prometheus.MustNewConstMetric(subnetIPAddressesAllocated, prometheus.GaugeValue, usage.Allocated, labels...)
+
+		if usage.Total > 0 {
+			ch <- prometheus.MustNewConstMetric(subnetIPUtilization, prometheus.GaugeValue, usage.Allocated/usage.Total, labels...)
+		}
+	}
+}
+
+var newSubnetsCounter = func() subnetsCounterInterface {
+	return &subnetsCounter{
+		count:      make(map[subnetsPermutation]*subnetRangeUsage),
+		subnets:    make(map[string]*compute.Subnetwork),
+		primaryIPs: make(map[subnetsPermutation]map[string]bool),
+	}
+}
+
+type SubnetsCollector struct {
+	*Common
+
+	service services.ComputeServiceInterface
+	subnets subnetsCounterInterface
+
+	initialized    bool
+	initalizedLock sync.RWMutex
+}
+
+func (c *SubnetsCollector) GetName() string {
+	return SubnetsCollectorName
+}
+
+func (c *SubnetsCollector) GetData(ctx context.Context) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("subnets collector not initialized")
+	}
+
+	if c.service == nil {
+		return fmt.Errorf("subnets collector compute.Service is not initialized")
+	}
+
+	count := newSubnetsCounter()
+	for _, project := range c.GetProjects() {
+		instances := make([]*compute.Instance, 0)
+		for _, zone := range c.GetZones() {
+			logrus.WithFields(logrus.Fields{
+				"project": project,
+				"zone":    zone,
+			}).Debugf("Requesting instances")
+
+			zoneInstances, err := c.service.ListInstances(ctx, project, zone, PerPage)
+			if err != nil {
+				return fmt.Errorf("error while requesting instances data: %v", err)
+			}
+
+			instances = append(instances, zoneInstances...)
+		}
+
+		for _, region := range c.GetRegions() {
+			logrus.WithFields(logrus.Fields{
+				"project": project,
+				"region":  region,
+			}).Debugf("Requesting subnets, addresses and forwarding rules")
+
+			subnets, err := c.service.ListSubnetworks(ctx, project, region, PerPage)
+			if err != nil {
+				return fmt.Errorf("error while requesting subnets data: %v", err)
+			}
+
+			logrus.WithField("count", len(subnets)).Debugln("Found subnets")
+
+			count.Add(project, region, subnets, instances)
+
+			addresses, err := c.service.ListAddresses(ctx, project, region, PerPage)
+			if err != nil {
+				return fmt.Errorf("error while requesting addresses data: %v", err)
+			}
+			count.AddAddresses(project, region, addresses)
+
+			rules, err := c.service.ListForwardingRules(ctx, project, region, PerPage)
+			if err != nil {
+				return fmt.Errorf("error while requesting forwarding rules data: %v", err)
+			}
+			count.AddForwardingRules(project, region, rules)
+		}
+	}
+
+	c.subnets = count
+
+	return nil
+}
+
+func (c *SubnetsCollector) isInitialized() bool {
+	c.initalizedLock.RLock()
+	defer c.initalizedLock.RUnlock()
+
+	return c.initialized
+}
+
+func (c *SubnetsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- subnetIPAddressesTotal
+	ch <- subnetIPAddressesAllocated
+	ch <- subnetIPUtilization
+}
+
+func (c *SubnetsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.subnets.Collect(ch)
+}
+
+func (c *SubnetsCollector) Configuration() map[string]string {
+	return map[string]string{
+		"projects": strings.Join(c.GetProjects(), ","),
+		"zones":    strings.Join(c.GetZones(), ","),
+		"regions":  strings.Join(c.GetRegions(), ","),
+	}
+}
+
+func (c *SubnetsCollector) Targets() int {
+	return len(c.GetProjects()) * len(c.GetRegions())
+}
+
+func (c *SubnetsCollector) RequiredPermissions() []string {
+	return []string{
+		"compute.subnetworks.list",
+		"compute.instances.list",
+		"compute.addresses.list",
+		"compute.forwardingRules.list",
+	}
+}
+
+func (c *SubnetsCollector) Init(client *http.Client) error {
+	var err error
+
+	c.service, err = services.NewComputeService(client)
+	if err != nil {
+		return fmt.Errorf("error while initializing computeService: %v", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"projects": strings.Join(c.GetProjects(), ","),
+		"zones":    strings.Join(c.GetZones(), ","),
+		"regions":  strings.Join(c.GetRegions(), ","),
+	}).Info("Registered collector")
+
+	c.initalizedLock.Lock()
+	defer c.initalizedLock.Unlock()
+
+	c.initialized = true
+
+	return nil
+}
+
+func NewSubnetsCollector(c *Common) *SubnetsCollector {
+	return &SubnetsCollector{
+		Common:      c,
+		subnets:     newSubnetsCounter(),
+		initialized: false,
+	}
+}
@@ -0,0 +1,243 @@
+package compute
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/compute/v1"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+)
+
+func TestCidrSize(t *testing.T) {
+	examples := map[string]struct {
+		cidr          string
+		expectedSize  float64
+		expectedError bool
+	}{
+		"full CIDR":      {cidr: "10.0.0.0/24", expectedSize: 256},
+		"single address": {cidr: "10.0.0.5/32", expectedSize: 1},
+		"prefix only":    {cidr: "/28", expectedSize: 16},
+		"invalid CIDR":   {cidr: "10.0.0.0", expectedError: true},
+		"invalid prefix": {cidr: "/33", expectedError: true},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			size, err := cidrSize(example.cidr)
+			if example.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, example.expectedSize, size)
+		})
+	}
+}
+
+func TestSubnetsCounter_Add(t *testing.T) {
+	subnet := &compute.Subnetwork{
+		Name:        "runners",
+		SelfLink:    "subnetworks/runners",
+		Network:     "https://www.googleapis.com/compute/v1/projects/project/global/networks/ci",
+		IpCidrRange: "10.0.0.0/24",
+		SecondaryIpRanges: []*compute.SubnetworkSecondaryRange{
+			{RangeName: "pods", IpCidrRange: "10.1.0.0/16"},
+		},
+	}
+
+	instances := []*compute.Instance{
+		{
+			Name: "instance-1",
+			NetworkInterfaces: []*compute.NetworkInterface{
+				{Subnetwork: "subnetworks/runners", NetworkIP: "10.0.0.2"},
+			},
+		},
+		{
+			Name: "instance-2",
+			NetworkInterfaces: []*compute.NetworkInterface{
+				{
+					Subnetwork: "subnetworks/runners",
+					NetworkIP:  "10.0.0.3",
+					AliasIpRanges: []*compute.AliasIpRange{
+						{IpCidrRange: "10.1.0.0/24", SubnetworkRangeName: "pods"},
+						{IpCidrRange: "10.0.0.16/28"},
+					},
+				},
+				{Subnetwork: "subnetworks/other", NetworkIP: "192.168.0.2"},
+			},
+		},
+	}
+
+	c := newSubnetsCounter().(*subnetsCounter)
+	c.Add("project", "region", []*compute.Subnetwork{subnet}, instances)
+
+	require.Len(t, c.count, 2)
+
+	primary := subnetsPermutation{Project: "project", Region: "region", Network: "ci", Subnet: "runners", Range: "primary"}
+	assert.Equal(t, &subnetRangeUsage{Total: 252, Allocated: 18}, c.count[primary])
+
+	pods := subnetsPermutation{Project: "project", Region: "region", Network: "ci", Subnet: "runners", Range: "pods"}
+	assert.Equal(t, &subnetRangeUsage{Total: 65536, Allocated: 256}, c.count[pods])
+}
+
+func TestSubnetsCounter_AddAddressesAndForwardingRules(t *testing.T) {
+	subnet := &compute.Subnetwork{
+		Name:        "runners",
+		SelfLink:    "subnetworks/runners",
+		Network:     "https://www.googleapis.com/compute/v1/projects/project/global/networks/ci",
+		IpCidrRange: "10.0.0.0/24",
+	}
+
+	instances := []*compute.Instance{
+		{
+			Name: "instance-1",
+			NetworkInterfaces: []*compute.NetworkInterface{
+				{Subnetwork: "subnetworks/runners", NetworkIP: "10.0.0.2"},
+			},
+		},
+	}
+
+	c := newSubnetsCounter().(*subnetsCounter)
+	c.Add("project", "region", []*compute.Subnetwork{subnet}, instances)
+	c.AddAddresses("project", "region", []*compute.Address{
+		{AddressType: "INTERNAL", Subnetwork: "subnetworks/runners", Address: "10.0.0.2"},
+		{AddressType: "INTERNAL", Subnetwork: "subnetworks/runners", Address: "10.0.0.10"},
+		{AddressType: "INTERNAL", Subnetwork: "subnetworks/other", Address: "192.168.0.10"},
+		{AddressType: "EXTERNAL", Address: "35.0.0.1"},
+	})
+	c.AddForwardingRules("project", "region", []*compute.ForwardingRule{
+		{LoadBalancingScheme: "INTERNAL", Subnetwork: "subnetworks/runners", IPAddress: "10.0.0.10"},
+		{LoadBalancingScheme: "INTERNAL_MANAGED", Subnetwork: "subnetworks/runners", IPAddress: "10.0.0.11"},
+		{LoadBalancingScheme: "EXTERNAL", IPAddress: "35.0.0.2"},
+	})
+
+	primary := subnetsPermutation{Project: "project", Region: "region", Network: "ci", Subnet: "runners", Range: "primary"}
+	assert.Equal(t, &subnetRangeUsage{Total: 252, Allocated: 3}, c.count[primary])
+}
+
+func TestSubnetsCounter_Collect(t *testing.T) {
+	ch := make(chan prometheus.Metric, 50)
+	defer close(ch)
+
+	c := newSubnetsCounter().(*subnetsCounter)
+	c.count[subnetsPermutation{Project: "project", Region: "region", Network: "ci", Subnet: "runners", Range: "primary"}] = &subnetRangeUsage{Total: 252, Allocated: 10}
+	c.count[subnetsPermutation{Project: "project", Region: "region", Network: "ci", Subnet: "tiny", Range: "primary"}] = &subnetRangeUsage{Total: 0}
+
+	c.Collect(ch)
+
+	assert.Len(t, ch, 5)
+}
+
+func TestSubnetsCollector_GetName(t *testing.T) {
+	collector := NewSubnetsCollector(&Common{})
+	assert.Equal(t, "subnets-collector", collector.GetName())
+}
+
+func TestSubnetsCollector_Init(t *testing.T) {
+	collector := NewSubnetsCollector(&Common{})
+	err := collector.Init(http.DefaultClient)
+
+	assert.NoError(t, err)
+}
+
+func TestSubnetsCollector_GetData_withoutInitialize(t *testing.T) {
+	collector := NewSubnetsCollector(&Common{})
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "subnets collector not initialized")
+}
+
+func TestSubnetsCollector_GetData(t *testing.T) {
+	p1 := "fake-project-1"
+	z1 := "fake-region-1-a"
+	z2 := "fake-region-1-b"
+	r1 := "fake-region-1"
+
+	collector := NewSubnetsCollector(&Common{Projects: []string{p1}, Zones: []string{z1, z2}})
+
+	instance1 := &compute.Instance{Name: "instance-1"}
+	instance2 := &compute.Instance{Name: "instance-2"}
+	subnets := []*compute.Subnetwork{{Name: "subnet-1"}}
+	addresses := []*compute.Address{{Name: "address-1"}}
+	rules := []*compute.ForwardingRule{{Name: "rule-1"}}
+
+	service := &services.MockComputeServiceInterface{}
+	service.On("ListInstances", mock.Anything, p1, z1, int64(PerPage)).Return([]*compute.Instance{instance1}, nil).Once()
+	service.On("ListInstances", mock.Anything, p1, z2, int64(PerPage)).Return([]*compute.Instance{instance2}, nil).Once()
+	service.On("ListSubnetworks", mock.Anything, p1, r1, int64(PerPage)).Return(subnets, nil).Once()
+	service.On("ListAddresses", mock.Anything, p1, r1, int64(PerPage)).Return(addresses, nil).Once()
+	service.On("ListForwardingRules", mock.Anything, p1, r1, int64(PerPage)).Return(rules, nil).Once()
+	collector.service = service
+
+	ct := &mockSubnetsCounterInterface{}
+	ct.On("Add", p1, r1, subnets, []*compute.Instance{instance1, instance2}).Once()
+	ct.On("AddAddresses", p1, r1, addresses).Once()
+	ct.On("AddForwardingRules", p1, r1, rules).Once()
+
+	newSubnetsCounter = func() subnetsCounterInterface {
+		return ct
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.NoError(t, err)
+	service.AssertExpectations(t)
+	ct.AssertExpectations(t)
+}
+
+func TestSubnetsCollector_GetData_ListSubnetworksError(t *testing.T) {
+	collector := NewSubnetsCollector(&Common{Projects: []string{"fake-project-1"}, Zones: []string{"fake-region-1-a"}})
+
+	service := &services.MockComputeServiceInterface{}
+	service.On("ListInstances", mock.Anything, "fake-project-1", "fake-region-1-a", mock.Anything).Return(make([]*compute.Instance, 0), nil).Once()
+	service.On("ListSubnetworks", mock.Anything, "fake-project-1", "fake-region-1", mock.Anything).Return(nil, fmt.Errorf("fake-list-error")).Once()
+	collector.service = service
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error while requesting subnets data: fake-list-error")
+	service.AssertExpectations(t)
+}
+
+func TestSubnetsCollector_Describe(t *testing.T) {
+	ch := make(chan<- *prometheus.Desc, 50)
+	defer close(ch)
+
+	collector := NewSubnetsCollector(&Common{})
+	collector.Describe(ch)
+
+	assert.Len(t, ch, 3)
+}
+
+func TestSubnetsCollector_Collect(t *testing.T) {
+	ch := make(chan<- prometheus.Metric, 50)
+	defer close(ch)
+
+	ct := &mockSubnetsCounterInterface{}
+	ct.On("Collect", ch).Once()
+
+	newSubnetsCounter = func() subnetsCounterInterface {
+		return ct
+	}
+
+	collector := NewSubnetsCollector(&Common{})
+	collector.Collect(ch)
+
+	ct.AssertExpectations(t)
+}
@@ -0,0 +1,477 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/container/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+)
+
+const (
+	ClustersCollectorName = "gke-collector"
+
+	UnspecifiedChannel = "UNSPECIFIED"
+)
+
+var (
+	numberOfClusters = prometheus.NewDesc(
+		"gcp_exporter_gke_clusters_count",
+		"Current number of GKE clusters",
+		[]string{"project", "location", "status", "version"},
+		nil,
+	)
+
+	clusterMasterVersionBehind = prometheus.NewDesc(
+		"gcp_exporter_gke_cluster_master_version_behind",
+		"Whether the master version of GKE cluster is older (1) or not (0) than the default version of its release channel",
+		[]string{"project", "location", "cluster", "channel"},
+		nil,
+	)
+
+	nodePoolNodes = prometheus.NewDesc(
+		"gcp_exporter_gke_node_pool_nodes",
+		"Target number of nodes in GKE node pool",
+		[]string{"project", "location", "cluster", "node_pool", "machine_type", "version"},
+		nil,
+	)
+
+	nodePoolAutoscalingMinNodes = prometheus.NewDesc(
+		"gcp_exporter_gke_node_pool_autoscaling_min_nodes",
+		"Minimum number of nodes per zone configured in GKE node pool autoscaling",
+		[]string{"project", "location", "cluster", "node_pool"},
+		nil,
+	)
+
+	nodePoolAutoscalingMaxNodes = prometheus.NewDesc(
+		"gcp_exporter_gke_node_pool_autoscaling_max_nodes",
+		"Maximum number of nodes per zone configured in GKE node pool autoscaling",
+		[]string{"project", "location", "cluster", "node_pool"},
+		nil,
+	)
+
+	nodePoolVersionBehind = prometheus.NewDesc(
+		"gcp_exporter_gke_node_pool_version_behind",
+		"Whether the node version of GKE node pool is older (1) or not (0) than the default version of its release channel",
+		[]string{"project", "location", "cluster", "node_pool", "channel"},
+		nil,
+	)
+)
+
+type clustersPermutation struct {
+	Project  string
+	Location string
+	Status   string
+	Version  string
+}
+
+type clusterPermutation struct {
+	Project  string
+	Location string
+	Cluster  string
+	Channel  string
+}
+
+type nodePoolPermutation struct {
+	clusterPermutation
+
+	NodePool string
+}
+
+type nodePoolState struct {
+	MachineType   string
+	Version       string
+	Nodes         float64
+	Autoscaling   bool
+	MinNodes      float64
+	MaxNodes      float64
+	VersionBehind *float64
+}
+
+type clustersCounterInterface interface {
+	Add(string, *container.Cluster, string, map[string]int64)
+	Collect(chan<- prometheus.Metric)
+}
+
+type clustersCounter struct {
+	clusters     map[clustersPermutation]int
+	masterBehind map[clusterPermutation]float64
+	nodePools    map[nodePoolPermutation]nodePoolState
+	lock         sync.RWMutex
+}
+
+func (cc *clustersCounter) Add(project string, cluster *container.Cluster, defaultVersion string, nodePoolSizes map[string]int64) {
+	cc.lock.Lock()
+	defer cc.lock.Unlock()
+
+	cc.clusters[clustersPermutation{
+		Project:  project,
+		Location: cluster.Location,
+		Status:   cluster.Status,
+		Version:  cluster.CurrentMasterVersion,
+	}]++
+
+	permutation := clusterPermutation{
+		Project:  project,
+		Location: cluster.Location,
+		Cluster:  cluster.Name,
+		Channel:  clusterChannel(cluster),
+	}
+
+	behind, err := versionBehind(cluster.CurrentMasterVersion, defaultVersion)
+	if err == nil {
+		cc.masterBehind[permutation] = behind
+	}
+
+	for _, nodePool := range cluster.NodePools {
+		state := nodePoolState{
+			Version: nodePool.Version,
+			Nodes:   float64(nodePoolSizes[nodePool.Name]),
+		}
+
+		if nodePool.Config != nil {
+			state.MachineType = nodePool.Config.MachineType
+		}
+
+		if nodePool.Autoscaling != nil && nodePool.Autoscaling.Enabled {
+			state.Autoscaling = true
+			state.MinNodes = float64(nodePool.Autoscaling.MinNodeCount)
+			state.MaxNodes = float64(nodePool.Autoscaling.MaxNodeCount)
+		}
+
+		behind, err := versionBehind(nodePool.Version, defaultVersion)
+		if err == nil {
+			state.VersionBehind = &behind
+		}
+
+		cc.nodePools[nodePoolPermutation{clusterPermutation: permutation, NodePool: nodePool.Name}] = state
+	}
+}
+
+func clusterChannel(cluster *container.Cluster) string {
+	if cluster.ReleaseChannel == nil || cluster.ReleaseChannel.Channel == "" {
+		return UnspecifiedChannel
+	}
+
+	return cluster.ReleaseChannel.Channel
+}
+
+func versionBehind(version string, defaultVersion string) (float64, error) {
+	current, err := parseGKEVersion(version)
+	if err != nil {
+		return 0, err
+	}
+
+	target, err := parseGKEVersion(defaultVersion)
+	if err != nil {
+		return 0, err
+	}
+
+	for i := 0; i < len(current) && i < len(target); i++ {
+		if current[i] != target[i] {
+			if current[i] < target[i] {
+				return 1, nil
+			}
+
+			return 0, nil
+		}
+	}
+
+	if len(current) < len(target) {
+		return 1, nil
+	}
+
+	return 0, nil
+}
+
+func parseGKEVersion(version string) ([]int, error) {
+	if version == "" {
+		return nil, fmt.Errorf("empty version")
+	}
+
+	parts := strings.Split(strings.Replace(version, "-gke.", ".", 1), ".")
+
+	numbers := make([]int, 0, len(parts))
+	for _, part := range parts {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version %q", version)
+		}
+
+		numbers = append(numbers, number)
+	}
+
+	return numbers, nil
+}
+
+func (cc *clustersCounter) Collect(ch chan<- prometheus.Metric) {
+	for permutation, count := range cc.clusters {
+		ch <- prometheus.MustNewConstMetric(
+			numberOfClusters,
+			prometheus.GaugeValue,
+			float64(count),
+			permutation.Project,
+			permutation.Location,
+			permutation.Status,
+			permutation.Version,
+		)
+	}
+
+	for permutation, behind := range cc.masterBehind {
+		ch <- prometheus.MustNewConstMetric(
+			clusterMasterVersionBehind,
+			prometheus.GaugeValue,
+			behind,
+			permutation.Project,
+			permutation.Location,
+			permutation.Cluster,
+			permutation.Channel,
+		)
+	}
+
+	for permutation, state := range cc.nodePools {
+		labels := []string{permutation.Project, permutation.Location, permutation.Cluster, permutation.NodePool}
+
+		ch <- prometheus.MustNewConstMetric(nodePoolNodes, prometheus.GaugeValue, state.Nodes, append(labels, state.MachineType, state.Version)...)
+
+		if state.Autoscaling {
+			ch <- prometheus.MustNewConstMetric(nodePoolAutoscalingMinNodes, prometheus.GaugeValue, state.MinNodes, labels...)
+			ch <- prometheus.MustNewConstMetric(nodePoolAutoscalingMaxNodes, prometheus.GaugeValue, state.MaxNodes, labels...)
+		}
+
+		if state.VersionBehind != nil {
+			ch <- prometheus.MustNewConstMetric(nodePoolVersionBehind, prometheus.GaugeValue, *state.VersionBehind, append(labels, permutation.Channel)...)
+		}
+	}
+}
+
+var newClustersCounter = func() clustersCounterInterface {
+	return &clustersCounter{
+		clusters:     make(map[clustersPermutation]int),
+		masterBehind: make(map[clusterPermutation]float64),
+		nodePools:    make(map[nodePoolPermutation]nodePoolState),
+	}
+}
+
+type ClustersCollector struct {
+	*compute.Common
+
+	service        services.ContainerServiceInterface
+	computeService services.ComputeServiceInterface
+	clusters       clustersCounterInterface
+
+	initialized    bool
+	initalizedLock sync.RWMutex
+}
+
+func (c *ClustersCollector) GetName() string {
+	return ClustersCollectorName
+}
+
+func (c *ClustersCollector) GetData(ctx context.Context) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("gke collector not initialized")
+	}
+
+	if c.service == nil || c.computeService == nil {
+		return fmt.Errorf("gke collector container.Service is not initialized")
+	}
+
+	count := newClustersCounter()
+	for _, project := range c.GetProjects() {
+		logrus.WithField("project", project).Debugf("Requesting GKE clusters")
+
+		clusters, err := c.service.ListClusters(ctx, project)
+		if err != nil {
+			return fmt.Errorf("error while requesting GKE clusters data: %v", err)
+		}
+
+		logrus.WithField("count", len(clusters)).Debugln("Found GKE clusters")
+
+		serverConfigs := make(map[string]*container.ServerConfig)
+		for _, cluster := range clusters {
+			defaultVersion, err := c.defaultVersion(ctx, project, cluster, serverConfigs)
+			if err != nil {
+				return err
+			}
+
+			nodePoolSizes, err := c.nodePoolSizes(ctx, cluster)
+			if err != nil {
+				return err
+			}
+
+			count.Add(project, cluster, defaultVersion, nodePoolSizes)
+		}
+	}
+
+	c.clusters = count
+
+	return nil
+}
+
+func (c *ClustersCollector) defaultVersion(ctx context.Context, project string, cluster *container.Cluster, serverConfigs map[string]*container.ServerConfig) (string, error) {
+	config, ok := serverConfigs[cluster.Location]
+	if !ok {
+		var err error
+
+		config, err = c.service.GetServerConfig(ctx, project, cluster.Location)
+		if err != nil {
+			return "", fmt.Errorf("error while requesting GKE server config: %v", err)
+		}
+
+		serverConfigs[cluster.Location] = config
+	}
+
+	channel := clusterChannel(cluster)
+	if channel == UnspecifiedChannel {
+		return config.DefaultClusterVersion, nil
+	}
+
+	for _, channelConfig := range config.Channels {
+		if channelConfig.Channel == channel {
+			return channelConfig.DefaultVersion, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (c *ClustersCollector) nodePoolSizes(ctx context.Context, cluster *container.Cluster) (map[string]int64, error) {
+	sizes := make(map[string]int64, len(cluster.NodePools))
+
+	for _, nodePool := range cluster.NodePools {
+		for _, instanceGroupURL := range nodePool.InstanceGroupUrls {
+			project, zone, name, err := parseInstanceGroupManagerURL(instanceGroupURL)
+			if err != nil {
+				return nil, err
+			}
+
+			manager, err := c.computeService.GetInstanceGroupManager(ctx, project, zone, name)
+			if isNotFound(err) {
+				// Instance groups of clusters and node pools that are being
+				// created or deleted may not exist yet or anymore
+				logrus.WithError(err).WithFields(logrus.Fields{
+					"cluster":   cluster.Name,
+					"node-pool": nodePool.Name,
+					"status":    nodePool.Status,
+				}).Warningln("Skipping missing GKE node pool instance group")
+
+				continue
+			}
+
+			if err != nil {
+				return nil, fmt.Errorf("error while requesting GKE node pool instance group data: %v", err)
+			}
+
+			sizes[nodePool.Name] += manager.TargetSize
+		}
+	}
+
+	return sizes, nil
+}
+
+func isNotFound(err error) bool {
+	e, ok := err.(*googleapi.Error)
+
+	return ok && e.Code == http.StatusNotFound
+}
+
+func parseInstanceGroupManagerURL(instanceGroupURL string) (string, string, string, error) {
+	u, err := url.Parse(instanceGroupURL)
+	if err != nil {
+		return "", "", "", fmt.Errorf("invalid instance group URL %q: %v", instanceGroupURL, err)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 6 {
+		return "", "", "", fmt.Errorf("invalid instance group URL %q", instanceGroupURL)
+	}
+
+	parts = parts[len(parts)-6:]
+	if parts[0] != "projects" || parts[2] != "zones" || parts[4] != "instanceGroupManagers" {
+		return "", "", "", fmt.Errorf("invalid instance group URL %q", instanceGroupURL)
+	}
+
+	return parts[1], parts[3], parts[5], nil
+}
+
+func (c *ClustersCollector) isInitialized() bool {
+	c.initalizedLock.RLock()
+	defer c.initalizedLock.RUnlock()
+
+	return c.initialized
+}
+
+func (c *ClustersCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- numberOfClusters
+	ch <- clusterMasterVersionBehind
+	ch <- nodePoolNodes
+	ch <- nodePoolAutoscalingMinNodes
+	ch <- nodePoolAutoscalingMaxNodes
+	ch <- nodePoolVersionBehind
+}
+
+func (c *ClustersCollector) Collect(ch chan<- prometheus.Metric) {
+	c.clusters.Collect(ch)
+}
+
+func (c *ClustersCollector) Configuration() map[string]string {
+	return map[string]string{
+		"projects": strings.Join(c.GetProjects(), ","),
+	}
+}
+
+func (c *ClustersCollector) Targets() int {
+	return len(c.GetProjects())
+}
+
+func (c *ClustersCollector) RequiredPermissions() []string {
+	return []string{
+		"container.clusters.list",
+		"compute.instanceGroupManagers.get",
+	}
+}
+
+func (c *ClustersCollector) Init(client *http.Client) error {
+	var err error
+
+	c.service, err = services.NewContainerService(client)
+	if err != nil {
+		return fmt.Errorf("error while initializing containerService: %v", err)
+	}
+
+	c.computeService, err = services.NewComputeService(client)
+	if err != nil {
+		return fmt.Errorf("error while initializing computeService: %v", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"projects": strings.Join(c.GetProjects(), ","),
+	}).Info("Registered collector")
+
+	c.initalizedLock.Lock()
+	defer c.initalizedLock.Unlock()
+
+	c.initialized = true
+
+	return nil
+}
+
+func NewClustersCollector(c *compute.Common) *ClustersCollector {
+	return &ClustersCollector{
+		Common:      c,
+		clusters:    newClustersCounter(),
+		initialized: false,
+	}
+}
@@ -0,0 +1,293 @@
+package container
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	computeapi "google.golang.org/api/compute/v1"
+	"google.golang.org/api/container/v1"
+	"google.golang.org/api/googleapi"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+)
+
+func TestVersionBehind(t *testing.T) {
+	examples := map[string]struct {
+		version        string
+		defaultVersion string
+		expected       float64
+		expectedError  bool
+	}{
+		"same version":        {version: "1.27.3-gke.100", defaultVersion: "1.27.3-gke.100", expected: 0},
+		"older gke patch":     {version: "1.27.3-gke.100", defaultVersion: "1.27.3-gke.1200", expected: 1},
+		"older minor":         {version: "1.26.8-gke.200", defaultVersion: "1.27.3-gke.100", expected: 1},
+		"newer than default":  {version: "1.28.1-gke.100", defaultVersion: "1.27.3-gke.100", expected: 0},
+		"shorter version":     {version: "1.27.3", defaultVersion: "1.27.3-gke.100", expected: 1},
+		"empty default":       {version: "1.27.3-gke.100", defaultVersion: "", expectedError: true},
+		"non-numeric version": {version: "latest", defaultVersion: "1.27.3-gke.100", expectedError: true},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			behind, err := versionBehind(example.version, example.defaultVersion)
+			if example.expectedError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, example.expected, behind)
+		})
+	}
+}
+
+func TestParseInstanceGroupManagerURL(t *testing.T) {
+	project, zone, name, err := parseInstanceGroupManagerURL("https://www.googleapis.com/compute/v1/projects/project-1/zones/us-east1-c/instanceGroupManagers/gke-pool-1-grp")
+	require.NoError(t, err)
+	assert.Equal(t, "project-1", project)
+	assert.Equal(t, "us-east1-c", zone)
+	assert.Equal(t, "gke-pool-1-grp", name)
+
+	_, _, _, err = parseInstanceGroupManagerURL("https://www.googleapis.com/compute/v1/projects/project-1/global/networks/default")
+	assert.Error(t, err)
+}
+
+func TestClustersCounter_Add(t *testing.T) {
+	cluster := &container.Cluster{
+		Name:                 "cluster-1",
+		Location:             "us-east1",
+		Status:               "RUNNING",
+		CurrentMasterVersion: "1.27.3-gke.100",
+		ReleaseChannel:       &container.ReleaseChannel{Channel: "REGULAR"},
+		NodePools: []*container.NodePool{
+			{
+				Name:        "pool-1",
+				Version:     "1.26.8-gke.200",
+				Config:      &container.NodeConfig{MachineType: "n2-standard-4"},
+				Autoscaling: &container.NodePoolAutoscaling{Enabled: true, MinNodeCount: 1, MaxNodeCount: 5},
+			},
+			{
+				Name:    "pool-2",
+				Version: "1.27.3-gke.100",
+			},
+		},
+	}
+
+	c := newClustersCounter().(*clustersCounter)
+	c.Add("project", cluster, "1.27.3-gke.100", map[string]int64{"pool-1": 6})
+
+	assert.Equal(t, 1, c.clusters[clustersPermutation{Project: "project", Location: "us-east1", Status: "RUNNING", Version: "1.27.3-gke.100"}])
+
+	p := clusterPermutation{Project: "project", Location: "us-east1", Cluster: "cluster-1", Channel: "REGULAR"}
+	assert.Equal(t, float64(0), c.masterBehind[p])
+
+	require.Len(t, c.nodePools, 2)
+
+	behind := float64(1)
+	assert.Equal(t, nodePoolState{
+		MachineType:   "n2-standard-4",
+		Version:       "1.26.8-gke.200",
+		Nodes:         6,
+		Autoscaling:   true,
+		MinNodes:      1,
+		MaxNodes:      5,
+		VersionBehind: &behind,
+	}, c.nodePools[nodePoolPermutation{clusterPermutation: p, NodePool: "pool-1"}])
+
+	notBehind := float64(0)
+	assert.Equal(t, nodePoolState{
+		Version:       "1.27.3-gke.100",
+		VersionBehind: &notBehind,
+	}, c.nodePools[nodePoolPermutation{clusterPermutation: p, NodePool: "pool-2"}])
+}
+
+func TestClustersCounter_Collect(t *testing.T) {
+	ch := make(chan prometheus.Metric, 50)
+	defer close(ch)
+
+	c := newClustersCounter().(*clustersCounter)
+	p := clusterPermutation{Project: "project", Location: "us-east1", Cluster: "cluster-1", Channel: "REGULAR"}
+	behind := float64(1)
+
+	c.clusters[clustersPermutation{Project: "project", Location: "us-east1", Status: "RUNNING", Version: "1.27.3-gke.100"}] = 1
+	c.masterBehind[p] = 0
+	c.nodePools[nodePoolPermutation{clusterPermutation: p, NodePool: "pool-1"}] = nodePoolState{Nodes: 3, Autoscaling: true, MaxNodes: 5, VersionBehind: &behind}
+	c.nodePools[nodePoolPermutation{clusterPermutation: p, NodePool: "pool-2"}] = nodePoolState{Nodes: 1}
+
+	c.Collect(ch)
+
+	assert.Len(t, ch, 7)
+}
+
+func TestClustersCollector_GetName(t *testing.T) {
+	collector := NewClustersCollector(&compute.Common{})
+	assert.Equal(t, "gke-collector", collector.GetName())
+}
+
+func TestClustersCollector_Init(t *testing.T) {
+	collector := NewClustersCollector(&compute.Common{})
+	err := collector.Init(http.DefaultClient)
+
+	assert.NoError(t, err)
+}
+
+func TestClustersCollector_GetData_withoutInitialize(t *testing.T) {
+	collector := NewClustersCollector(&compute.Common{})
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "gke collector not initialized")
+}
+
+func TestClustersCollector_GetData(t *testing.T) {
+	p1 := "fake-project-1"
+
+	collector := NewClustersCollector(&compute.Common{Projects: []string{p1}})
+
+	cluster1 := &container.Cluster{
+		Name:           "cluster-1",
+		Location:       "us-east1",
+		ReleaseChannel: &container.ReleaseChannel{Channel: "STABLE"},
+		NodePools: []*container.NodePool{
+			{
+				Name: "pool-1",
+				InstanceGroupUrls: []string{
+					"https://www.googleapis.com/compute/v1/projects/fake-project-1/zones/us-east1-b/instanceGroupManagers/grp-b",
+					"https://www.googleapis.com/compute/v1/projects/fake-project-1/zones/us-east1-c/instanceGroupManagers/grp-c",
+				},
+			},
+		},
+	}
+	cluster2 := &container.Cluster{Name: "cluster-2", Location: "us-east1"}
+
+	serverConfig := &container.ServerConfig{
+		DefaultClusterVersion: "1.27.3-gke.100",
+		Channels: []*container.ReleaseChannelConfig{
+			{Channel: "STABLE", DefaultVersion: "1.26.8-gke.200"},
+		},
+	}
+
+	service := &services.MockContainerServiceInterface{}
+	service.On("ListClusters", mock.Anything, p1).Return([]*container.Cluster{cluster1, cluster2}, nil).Once()
+	service.On("GetServerConfig", mock.Anything, p1, "us-east1").Return(serverConfig, nil).Once()
+	collector.service = service
+
+	computeService := &services.MockComputeServiceInterface{}
+	computeService.On("GetInstanceGroupManager", mock.Anything, p1, "us-east1-b", "grp-b").Return(&computeapi.InstanceGroupManager{TargetSize: 2}, nil).Once()
+	computeService.On("GetInstanceGroupManager", mock.Anything, p1, "us-east1-c", "grp-c").Return(&computeapi.InstanceGroupManager{TargetSize: 3}, nil).Once()
+	collector.computeService = computeService
+
+	ct := &mockClustersCounterInterface{}
+	ct.On("Add", p1, cluster1, "1.26.8-gke.200", map[string]int64{"pool-1": 5}).Once()
+	ct.On("Add", p1, cluster2, "1.27.3-gke.100", map[string]int64{}).Once()
+
+	newClustersCounter = func() clustersCounterInterface {
+		return ct
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.NoError(t, err)
+	service.AssertExpectations(t)
+	computeService.AssertExpectations(t)
+	ct.AssertExpectations(t)
+}
+
+func TestClustersCollector_GetData_missingInstanceGroup(t *testing.T) {
+	p1 := "fake-project-1"
+
+	collector := NewClustersCollector(&compute.Common{Projects: []string{p1}})
+
+	cluster := &container.Cluster{
+		Name:     "cluster-1",
+		Location: "us-east1",
+		NodePools: []*container.NodePool{
+			{
+				Name:   "pool-1",
+				Status: "STOPPING",
+				InstanceGroupUrls: []string{
+					"https://www.googleapis.com/compute/v1/projects/fake-project-1/zones/us-east1-b/instanceGroupManagers/grp-b",
+				},
+			},
+		},
+	}
+
+	service := &services.MockContainerServiceInterface{}
+	service.On("ListClusters", mock.Anything, p1).Return([]*container.Cluster{cluster}, nil).Once()
+	service.On("GetServerConfig", mock.Anything, p1, "us-east1").Return(&container.ServerConfig{}, nil).Once()
+	collector.service = service
+
+	computeService := &services.MockComputeServiceInterface{}
+	computeService.On("GetInstanceGroupManager", mock.Anything, p1, "us-east1-b", "grp-b").Return(nil, &googleapi.Error{Code: http.StatusNotFound}).Once()
+	collector.computeService = computeService
+
+	ct := &mockClustersCounterInterface{}
+	ct.On("Add", p1, cluster, "", map[string]int64{}).Once()
+
+	newClustersCounter = func() clustersCounterInterface {
+		return ct
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.NoError(t, err)
+	computeService.AssertExpectations(t)
+	ct.AssertExpectations(t)
+}
+
+func TestClustersCollector_GetData_ListClustersError(t *testing.T) {
+	collector := NewClustersCollector(&compute.Common{Projects: []string{"fake-project-1"}})
+
+	service := &services.MockContainerServiceInterface{}
+	service.On("ListClusters", mock.Anything, "fake-project-1").Return(nil, fmt.Errorf("fake-list-error")).Once()
+	collector.service = service
+	collector.computeService = &services.MockComputeServiceInterface{}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error while requesting GKE clusters data: fake-list-error")
+	service.AssertExpectations(t)
+}
+
+func TestClustersCollector_Describe(t *testing.T) {
+	ch := make(chan<- *prometheus.Desc, 50)
+	defer close(ch)
+
+	collector := NewClustersCollector(&compute.Common{})
+	collector.Describe(ch)
+
+	assert.Len(t, ch, 6)
+}
+
+func TestClustersCollector_Collect(t *testing.T) {
+	ch := make(chan<- prometheus.Metric, 50)
+	defer close(ch)
+
+	ct := &mockClustersCounterInterface{}
+	ct.On("Collect", ch).Once()
+
+	newClustersCounter = func() clustersCounterInterface {
+		return ct
+	}
+
+	collector := NewClustersCollector(&compute.Common{})
+	collector.Collect(ch)
+
+	ct.AssertExpectations(t)
+}
@@ -0,0 +1,26 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package container
+
+import (
+	prometheus "github.com/prometheus/client_golang/prometheus"
+	mock "github.com/stretchr/testify/mock"
+	container "google.golang.org/api/container/v1"
+)
+
+// mockClustersCounterInterface is an autogenerated mock type for the clustersCounterInterface type
+type mockClustersCounterInterface struct {
+	mock.Mock
+}
+
+// Add provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *mockClustersCounterInterface) Add(_a0 string, _a1 *container.Cluster, _a2 string, _a3 map[string]int64) {
+	_m.Called(_a0, _a1, _a2, _a3)
+}
+
+// Collect provides a mock function with given fields: _a0
+func (_m *mockClustersCounterInterface) Collect(_a0 chan<- prometheus.Metric) {
+	_m.Called(_a0)
+}
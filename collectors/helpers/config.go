@@ -0,0 +1,60 @@
+package helpers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// LoadYAMLConfig reads the YAML file into config and validates it; kind
+// names the config file in errors
+func LoadYAMLConfig(kind string, path string, config interface{}, validate func() error) error {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read %s config file: %v", kind, err)
+	}
+
+	err = yaml.UnmarshalStrict(data, config)
+	if err != nil {
+		return fmt.Errorf("could not parse %s config file: %v", kind, err)
+	}
+
+	err = validate()
+	if err != nil {
+		return fmt.Errorf("invalid %s config file: %v", kind, err)
+	}
+
+	return nil
+}
+
+// ValidateQueryNames requires at least one query and unique query names
+func ValidateQueryNames(names []string) error {
+	if len(names) < 1 {
+		return fmt.Errorf("no queries defined")
+	}
+
+	seen := make(map[string]bool)
+	for i, name := range names {
+		if seen[name] {
+			return fmt.Errorf("query %d: duplicated name %q", i, name)
+		}
+
+		seen[name] = true
+	}
+
+	return nil
+}
+
+// SanitizeLabelName replaces characters not allowed in Prometheus label
+// names with underscores
+func SanitizeLabelName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+
+		return '_'
+	}, name)
+}
@@ -0,0 +1,78 @@
+package helpers
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tests"
+)
+
+type testConfig struct {
+	Name string `yaml:"name"`
+}
+
+func TestLoadYAMLConfig(t *testing.T) {
+	examples := map[string]struct {
+		content       string
+		validateError error
+		expectedError string
+	}{
+		"valid": {
+			content: "name: test",
+		},
+		"unknown field": {
+			content:       "unknown: test",
+			expectedError: "could not parse test config file",
+		},
+		"invalid": {
+			content:       "name: test",
+			validateError: fmt.Errorf("invalid name"),
+			expectedError: "invalid test config file: invalid name",
+		},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			tests.RunOnTempDir(t, "yaml-config", func(t *testing.T, dir string) {
+				file := filepath.Join(dir, "config.yml")
+				require.NoError(t, ioutil.WriteFile(file, []byte(example.content), 0600))
+
+				config := &testConfig{}
+				err := LoadYAMLConfig("test", file, config, func() error {
+					return example.validateError
+				})
+
+				if example.expectedError != "" {
+					require.Error(t, err)
+					assert.Contains(t, err.Error(), example.expectedError)
+					return
+				}
+
+				require.NoError(t, err)
+				assert.Equal(t, "test", config.Name)
+			})
+		})
+	}
+}
+
+func TestLoadYAMLConfig_missingFile(t *testing.T) {
+	err := LoadYAMLConfig("test", "/non/existing/file.yml", &testConfig{}, func() error { return nil })
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not read test config file")
+}
+
+func TestValidateQueryNames(t *testing.T) {
+	assert.NoError(t, ValidateQueryNames([]string{"a", "b"}))
+	assert.EqualError(t, ValidateQueryNames([]string{}), "no queries defined")
+	assert.EqualError(t, ValidateQueryNames([]string{"a", "b", "a"}), `query 2: duplicated name "a"`)
+}
+
+func TestSanitizeLabelName(t *testing.T) {
+	assert.Equal(t, "instance_name_1", SanitizeLabelName("instance_name_1"))
+	assert.Equal(t, "k8s_io_app", SanitizeLabelName("k8s.io/app"))
+}
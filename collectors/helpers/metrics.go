@@ -0,0 +1,10 @@
+package helpers
+
+// BoolToFloat64 converts a flag into a metric value of 1 or 0
+func BoolToFloat64(value bool) float64 {
+	if value {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,417 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/cloudresourcemanager/v1"
+	iamapi "google.golang.org/api/iam/v1"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+)
+
+const (
+	IAMCollectorName = "iam-collector"
+)
+
+var (
+	primitiveRoles = map[string]bool{
+		"roles/owner":  true,
+		"roles/editor": true,
+	}
+
+	publicMembers = map[string]bool{
+		"allUsers":              true,
+		"allAuthenticatedUsers": true,
+	}
+)
+
+var timeNow = time.Now
+
+var (
+	numberOfServiceAccounts = prometheus.NewDesc(
+		"gcp_exporter_iam_service_accounts_count",
+		"Current number of service accounts",
+		[]string{"project", "disabled"},
+		nil,
+	)
+
+	serviceAccountUserManagedKeys = prometheus.NewDesc(
+		"gcp_exporter_iam_service_account_user_managed_keys",
+		"Number of enabled user-managed keys of service account",
+		[]string{"project", "service_account"},
+		nil,
+	)
+
+	serviceAccountOldestUserManagedKeyAge = prometheus.NewDesc(
+		"gcp_exporter_iam_service_account_oldest_user_managed_key_age_seconds",
+		"Age of the oldest enabled user-managed key of service account",
+		[]string{"project", "service_account"},
+		nil,
+	)
+
+	primitiveRoleMembers = prometheus.NewDesc(
+		"gcp_exporter_iam_primitive_role_members",
+		"Number of members granted a primitive role in project IAM policy",
+		[]string{"project", "role", "member_type"},
+		nil,
+	)
+
+	publicRoleBindings = prometheus.NewDesc(
+		"gcp_exporter_iam_public_role_bindings",
+		"Number of project IAM policy bindings granting a role to allUsers or allAuthenticatedUsers",
+		[]string{"project", "role", "member"},
+		nil,
+	)
+)
+
+type keysMetricType int
+
+const (
+	KeysMetricTypeUserManagedKeys keysMetricType = iota
+	KeysMetricTypeOldestUserManagedKeyAge
+)
+
+var keysMetricDescs = map[keysMetricType]*prometheus.Desc{
+	KeysMetricTypeUserManagedKeys:         serviceAccountUserManagedKeys,
+	KeysMetricTypeOldestUserManagedKeyAge: serviceAccountOldestUserManagedKeyAge,
+}
+
+type serviceAccountsPermutation struct {
+	Project  string
+	Disabled string
+}
+
+type serviceAccountPermutation struct {
+	Project        string
+	ServiceAccount string
+}
+
+type primitiveRolePermutation struct {
+	Project    string
+	Role       string
+	MemberType string
+}
+
+type publicBindingPermutation struct {
+	Project string
+	Role    string
+	Member  string
+}
+
+type iamCounterInterface interface {
+	AddServiceAccounts(string, []*iamapi.ServiceAccount)
+	AddUserManagedKeys(string, *iamapi.ServiceAccount, []*iamapi.ServiceAccountKey)
+	AddPolicy(string, *cloudresourcemanager.Policy)
+	Collect(chan<- prometheus.Metric)
+}
+
+type iamCounter struct {
+	serviceAccounts map[serviceAccountsPermutation]int
+	keys            map[serviceAccountPermutation]map[keysMetricType]float64
+	primitiveRoles  map[primitiveRolePermutation]int
+	publicBindings  map[publicBindingPermutation]int
+	lock            sync.RWMutex
+}
+
+func (ic *iamCounter) AddServiceAccounts(project string, accounts []*iamapi.ServiceAccount) {
+	ic.lock.Lock()
+	defer ic.lock.Unlock()
+
+	for _, account := range accounts {
+		ic.serviceAccounts[serviceAccountsPermutation{
+			Project:  project,
+			Disabled: strconv.FormatBool(account.Disabled),
+		}]++
+	}
+}
+
+func (ic *iamCounter) AddUserManagedKeys(project string, account *iamapi.ServiceAccount, keys []*iamapi.ServiceAccountKey) {
+	ic.lock.Lock()
+	defer ic.lock.Unlock()
+
+	now := timeNow()
+
+	count := 0
+	var oldest time.Time
+	for _, key := range keys {
+		if key.Disabled {
+			continue
+		}
+
+		count++
+
+		validAfter, err := time.Parse(time.RFC3339, key.ValidAfterTime)
+		if err != nil {
+			continue
+		}
+
+		if oldest.IsZero() || validAfter.Before(oldest) {
+			oldest = validAfter
+		}
+	}
+
+	if count < 1 {
+		return
+	}
+
+	metrics := map[keysMetricType]float64{
+		KeysMetricTypeUserManagedKeys: float64(count),
+	}
+
+	if !oldest.IsZero() {
+		metrics[KeysMetricTypeOldestUserManagedKeyAge] = now.Sub(oldest).Seconds()
+	}
+
+	ic.keys[serviceAccountPermutation{Project: project, ServiceAccount: account.Email}] = metrics
+}
+
+func (ic *iamCounter) AddPolicy(project string, policy *cloudresourcemanager.Policy) {
+	ic.lock.Lock()
+	defer ic.lock.Unlock()
+
+	for _, binding := range policy.Bindings {
+		for _, member := range binding.Members {
+			if primitiveRoles[binding.Role] {
+				ic.primitiveRoles[primitiveRolePermutation{
+					Project:    project,
+					Role:       binding.Role,
+					MemberType: memberType(member),
+				}]++
+			}
+
+			if publicMembers[member] {
+				ic.publicBindings[publicBindingPermutation{
+					Project: project,
+					Role:    binding.Role,
+					Member:  member,
+				}]++
+			}
+		}
+	}
+}
+
+// memberType returns the type prefix of a policy member, e.g. user for
+// user:someone@example.com; allUsers and allAuthenticatedUsers are returned
+// unchanged
+func memberType(member string) string {
+	index := strings.Index(member, ":")
+	if index < 0 {
+		return member
+	}
+
+	return member[:index]
+}
+
+func (ic *iamCounter) Collect(ch chan<- prometheus.Metric) {
+	for permutation, count := range ic.serviceAccounts {
+		ch <- prometheus.MustNewConstMetric(
+			numberOfServiceAccounts,
+			prometheus.GaugeValue,
+			float64(count),
+			permutation.Project,
+			permutation.Disabled,
+		)
+	}
+
+	for permutation, metrics := range ic.keys {
+		for metricType, value := range metrics {
+			ch <- prometheus.MustNewConstMetric(
+				keysMetricDescs[metricType],
+				prometheus.GaugeValue,
+				value,
+				permutation.Project,
+				permutation.ServiceAccount,
+			)
+		}
+	}
+
+	for permutation, count := range ic.primitiveRoles {
+		ch <- prometheus.MustNewConstMetric(
+			primitiveRoleMembers,
+			prometheus.GaugeValue,
+			float64(count),
+			permutation.Project,
+			permutation.Role,
+			permutation.MemberType,
+		)
+	}
+
+	for permutation, count := range ic.publicBindings {
+		ch <- prometheus.MustNewConstMetric(
+			publicRoleBindings,
+			prometheus.GaugeValue,
+			float64(count),
+			permutation.Project,
+			permutation.Role,
+			permutation.Member,
+		)
+	}
+}
+
+var newIAMCounter = func() iamCounterInterface {
+	return &iamCounter{
+		serviceAccounts: make(map[serviceAccountsPermutation]int),
+		keys:            make(map[serviceAccountPermutation]map[keysMetricType]float64),
+		primitiveRoles:  make(map[primitiveRolePermutation]int),
+		publicBindings:  make(map[publicBindingPermutation]int),
+	}
+}
+
+type IAMCollector struct {
+	*compute.Common
+
+	iamService             services.IAMServiceInterface
+	resourceManagerService services.ResourceManagerServiceInterface
+	iam                    iamCounterInterface
+
+	initialized    bool
+	initalizedLock sync.RWMutex
+}
+
+func (c *IAMCollector) GetName() string {
+	return IAMCollectorName
+}
+
+func (c *IAMCollector) GetData(ctx context.Context) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("iam collector not initialized")
+	}
+
+	if c.iamService == nil {
+		return fmt.Errorf("iam collector iam.Service is not initialized")
+	}
+
+	if c.resourceManagerService == nil {
+		return fmt.Errorf("iam collector cloudresourcemanager.Service is not initialized")
+	}
+
+	count := newIAMCounter()
+	for _, project := range c.GetProjects() {
+		err := c.addServiceAccounts(ctx, count, project)
+		if err != nil {
+			return err
+		}
+
+		logrus.WithField("project", project).Debugf("Requesting IAM policy")
+
+		policy, err := c.resourceManagerService.GetIamPolicy(ctx, project)
+		if err != nil {
+			return fmt.Errorf("error while requesting IAM policy data: %v", err)
+		}
+
+		logrus.WithField("count", len(policy.Bindings)).Debugln("Found IAM policy bindings")
+
+		count.AddPolicy(project, policy)
+	}
+
+	c.iam = count
+
+	return nil
+}
+
+func (c *IAMCollector) addServiceAccounts(ctx context.Context, count iamCounterInterface, project string) error {
+	logrus.WithField("project", project).Debugf("Requesting service accounts")
+
+	accounts, err := c.iamService.ListServiceAccounts(ctx, project, compute.PerPage)
+	if err != nil {
+		return fmt.Errorf("error while requesting service accounts data: %v", err)
+	}
+
+	logrus.WithField("count", len(accounts)).Debugln("Found service accounts")
+
+	count.AddServiceAccounts(project, accounts)
+
+	for _, account := range accounts {
+		logrus.WithFields(logrus.Fields{
+			"project":         project,
+			"service-account": account.Email,
+		}).Debugf("Requesting service account keys")
+
+		keys, err := c.iamService.ListUserManagedKeys(ctx, project, account.Name)
+		if err != nil {
+			return fmt.Errorf("error while requesting service account keys data: %v", err)
+		}
+
+		logrus.WithField("count", len(keys)).Debugln("Found service account keys")
+
+		count.AddUserManagedKeys(project, account, keys)
+	}
+
+	return nil
+}
+
+func (c *IAMCollector) isInitialized() bool {
+	c.initalizedLock.RLock()
+	defer c.initalizedLock.RUnlock()
+
+	return c.initialized
+}
+
+func (c *IAMCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- numberOfServiceAccounts
+	ch <- serviceAccountUserManagedKeys
+	ch <- serviceAccountOldestUserManagedKeyAge
+	ch <- primitiveRoleMembers
+	ch <- publicRoleBindings
+}
+
+func (c *IAMCollector) Collect(ch chan<- prometheus.Metric) {
+	c.iam.Collect(ch)
+}
+
+func (c *IAMCollector) Configuration() map[string]string {
+	return map[string]string{
+		"projects": strings.Join(c.GetProjects(), ","),
+	}
+}
+
+func (c *IAMCollector) Targets() int {
+	return len(c.GetProjects())
+}
+
+func (c *IAMCollector) RequiredPermissions() []string {
+	return []string{"iam.serviceAccounts.list", "iam.serviceAccountKeys.list", "resourcemanager.projects.getIamPolicy"}
+}
+
+func (c *IAMCollector) Init(client *http.Client) error {
+	var err error
+
+	c.iamService, err = services.NewIAMService(client)
+	if err != nil {
+		return fmt.Errorf("error while initializing iamService: %v", err)
+	}
+
+	c.resourceManagerService, err = services.NewResourceManagerService(client)
+	if err != nil {
+		return fmt.Errorf("error while initializing resourceManagerService: %v", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"projects": strings.Join(c.GetProjects(), ","),
+	}).Info("Registered collector")
+
+	c.initalizedLock.Lock()
+	defer c.initalizedLock.Unlock()
+
+	c.initialized = true
+
+	return nil
+}
+
+func NewIAMCollector(c *compute.Common) *IAMCollector {
+	return &IAMCollector{
+		Common:      c,
+		iam:         newIAMCounter(),
+		initialized: false,
+	}
+}
@@ -0,0 +1,270 @@
+package iam
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/api/cloudresourcemanager/v1"
+	iamapi "google.golang.org/api/iam/v1"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+)
+
+func TestIAMCounter_AddServiceAccounts(t *testing.T) {
+	accounts := []*iamapi.ServiceAccount{
+		{Email: "sa-1@project.iam.gserviceaccount.com"},
+		{Email: "sa-2@project.iam.gserviceaccount.com"},
+		{Email: "sa-3@project.iam.gserviceaccount.com", Disabled: true},
+	}
+
+	c := newIAMCounter().(*iamCounter)
+	c.AddServiceAccounts("project", accounts)
+
+	assert.Equal(t, map[serviceAccountsPermutation]int{
+		{Project: "project", Disabled: "false"}: 2,
+		{Project: "project", Disabled: "true"}:  1,
+	}, c.serviceAccounts)
+}
+
+func TestIAMCounter_AddUserManagedKeys(t *testing.T) {
+	oldTimeNow := timeNow
+	defer func() { timeNow = oldTimeNow }()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time {
+		return now
+	}
+
+	account1 := &iamapi.ServiceAccount{Email: "sa-1@project.iam.gserviceaccount.com"}
+	account2 := &iamapi.ServiceAccount{Email: "sa-2@project.iam.gserviceaccount.com"}
+	account3 := &iamapi.ServiceAccount{Email: "sa-3@project.iam.gserviceaccount.com"}
+
+	c := newIAMCounter().(*iamCounter)
+	c.AddUserManagedKeys("project", account1, []*iamapi.ServiceAccountKey{
+		{ValidAfterTime: "2026-10-18T12:00:00Z"},
+		{ValidAfterTime: "2026-10-09T12:00:00Z"},
+		{ValidAfterTime: "2025-10-19T12:00:00Z", Disabled: true},
+	})
+	c.AddUserManagedKeys("project", account2, []*iamapi.ServiceAccountKey{
+		{ValidAfterTime: "2026-10-18T12:00:00Z", Disabled: true},
+	})
+	c.AddUserManagedKeys("project", account3, []*iamapi.ServiceAccountKey{})
+
+	assert.Equal(t, map[serviceAccountPermutation]map[keysMetricType]float64{
+		{Project: "project", ServiceAccount: "sa-1@project.iam.gserviceaccount.com"}: {
+			KeysMetricTypeUserManagedKeys:         2,
+			KeysMetricTypeOldestUserManagedKeyAge: 10 * 24 * 60 * 60,
+		},
+	}, c.keys)
+}
+
+func TestIAMCounter_AddPolicy(t *testing.T) {
+	policy := &cloudresourcemanager.Policy{
+		Bindings: []*cloudresourcemanager.Binding{
+			{
+				Role:    "roles/owner",
+				Members: []string{"user:owner@example.com", "group:admins@example.com", "user:other@example.com"},
+			},
+			{
+				Role:    "roles/editor",
+				Members: []string{"serviceAccount:123@cloudservices.gserviceaccount.com", "allAuthenticatedUsers"},
+			},
+			{
+				Role:    "roles/viewer",
+				Members: []string{"allUsers", "user:viewer@example.com"},
+			},
+		},
+	}
+
+	c := newIAMCounter().(*iamCounter)
+	c.AddPolicy("project", policy)
+
+	assert.Equal(t, map[primitiveRolePermutation]int{
+		{Project: "project", Role: "roles/owner", MemberType: "user"}:                   2,
+		{Project: "project", Role: "roles/owner", MemberType: "group"}:                  1,
+		{Project: "project", Role: "roles/editor", MemberType: "serviceAccount"}:        1,
+		{Project: "project", Role: "roles/editor", MemberType: "allAuthenticatedUsers"}: 1,
+	}, c.primitiveRoles)
+
+	assert.Equal(t, map[publicBindingPermutation]int{
+		{Project: "project", Role: "roles/editor", Member: "allAuthenticatedUsers"}: 1,
+		{Project: "project", Role: "roles/viewer", Member: "allUsers"}:              1,
+	}, c.publicBindings)
+}
+
+func TestIAMCounter_Collect(t *testing.T) {
+	ch := make(chan prometheus.Metric, 50)
+	defer close(ch)
+
+	c := newIAMCounter().(*iamCounter)
+	c.serviceAccounts[serviceAccountsPermutation{Project: "project", Disabled: "false"}] = 1
+	c.keys[serviceAccountPermutation{Project: "project", ServiceAccount: "sa"}] = map[keysMetricType]float64{
+		KeysMetricTypeUserManagedKeys:         1,
+		KeysMetricTypeOldestUserManagedKeyAge: 60,
+	}
+	c.primitiveRoles[primitiveRolePermutation{Project: "project", Role: "roles/owner", MemberType: "user"}] = 1
+	c.publicBindings[publicBindingPermutation{Project: "project", Role: "roles/viewer", Member: "allUsers"}] = 1
+
+	c.Collect(ch)
+
+	assert.Len(t, ch, 5)
+}
+
+func TestIAMCollector_GetName(t *testing.T) {
+	collector := NewIAMCollector(&compute.Common{})
+	assert.Equal(t, "iam-collector", collector.GetName())
+}
+
+func TestIAMCollector_Init(t *testing.T) {
+	collector := NewIAMCollector(&compute.Common{})
+	err := collector.Init(http.DefaultClient)
+
+	assert.NoError(t, err)
+}
+
+func TestIAMCollector_GetData_withoutInitialize(t *testing.T) {
+	collector := NewIAMCollector(&compute.Common{})
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "iam collector not initialized")
+}
+
+func TestIAMCollector_GetData(t *testing.T) {
+	p1 := "fake-project-1"
+	p2 := "fake-project-2"
+
+	collector := NewIAMCollector(&compute.Common{Projects: []string{p1, p2}})
+
+	account := &iamapi.ServiceAccount{Name: "projects/fake-project-1/serviceAccounts/sa-1", Email: "sa-1"}
+	accounts1 := []*iamapi.ServiceAccount{account}
+	accounts2 := make([]*iamapi.ServiceAccount, 0)
+	keys := []*iamapi.ServiceAccountKey{{Name: "key-1"}}
+	policy1 := &cloudresourcemanager.Policy{Version: 3}
+	policy2 := &cloudresourcemanager.Policy{Version: 1}
+
+	iamService := &services.MockIAMServiceInterface{}
+	iamService.On("ListServiceAccounts", mock.Anything, p1, int64(compute.PerPage)).Return(accounts1, nil).Once()
+	iamService.On("ListServiceAccounts", mock.Anything, p2, int64(compute.PerPage)).Return(accounts2, nil).Once()
+	iamService.On("ListUserManagedKeys", mock.Anything, p1, account.Name).Return(keys, nil).Once()
+	collector.iamService = iamService
+
+	resourceManagerService := &services.MockResourceManagerServiceInterface{}
+	resourceManagerService.On("GetIamPolicy", mock.Anything, p1).Return(policy1, nil).Once()
+	resourceManagerService.On("GetIamPolicy", mock.Anything, p2).Return(policy2, nil).Once()
+	collector.resourceManagerService = resourceManagerService
+
+	ct := &mockIamCounterInterface{}
+	ct.On("AddServiceAccounts", p1, accounts1).Once()
+	ct.On("AddServiceAccounts", p2, accounts2).Once()
+	ct.On("AddUserManagedKeys", p1, account, keys).Once()
+	ct.On("AddPolicy", p1, policy1).Once()
+	ct.On("AddPolicy", p2, policy2).Once()
+
+	newIAMCounter = func() iamCounterInterface {
+		return ct
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.NoError(t, err)
+	iamService.AssertExpectations(t)
+	resourceManagerService.AssertExpectations(t)
+	ct.AssertExpectations(t)
+}
+
+func TestIAMCollector_GetData_errors(t *testing.T) {
+	examples := map[string]struct {
+		accountsErr   error
+		keysErr       error
+		policyErr     error
+		expectedError string
+	}{
+		"service accounts error": {
+			accountsErr:   fmt.Errorf("fake-list-error"),
+			expectedError: "error while requesting service accounts data: fake-list-error",
+		},
+		"keys error": {
+			keysErr:       fmt.Errorf("fake-list-error"),
+			expectedError: "error while requesting service account keys data: fake-list-error",
+		},
+		"policy error": {
+			policyErr:     fmt.Errorf("fake-get-error"),
+			expectedError: "error while requesting IAM policy data: fake-get-error",
+		},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			collector := NewIAMCollector(&compute.Common{Projects: []string{"fake-project-1"}})
+
+			var accounts []*iamapi.ServiceAccount
+			if example.accountsErr == nil {
+				accounts = []*iamapi.ServiceAccount{{Name: "sa-1"}}
+			}
+
+			iamService := &services.MockIAMServiceInterface{}
+			iamService.On("ListServiceAccounts", mock.Anything, "fake-project-1", mock.Anything).Return(accounts, example.accountsErr).Maybe()
+			iamService.On("ListUserManagedKeys", mock.Anything, "fake-project-1", "sa-1").Return(nil, example.keysErr).Maybe()
+			collector.iamService = iamService
+
+			resourceManagerService := &services.MockResourceManagerServiceInterface{}
+			resourceManagerService.On("GetIamPolicy", mock.Anything, "fake-project-1").Return(nil, example.policyErr).Maybe()
+			collector.resourceManagerService = resourceManagerService
+
+			ct := &mockIamCounterInterface{}
+			ct.On("AddServiceAccounts", mock.Anything, mock.Anything).Maybe()
+			ct.On("AddUserManagedKeys", mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+			newIAMCounter = func() iamCounterInterface {
+				return ct
+			}
+
+			collector.initialized = true
+
+			err := collector.GetData(context.Background())
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), example.expectedError)
+		})
+	}
+}
+
+func TestIAMCollector_Describe(t *testing.T) {
+	ch := make(chan<- *prometheus.Desc, 50)
+	defer close(ch)
+
+	collector := NewIAMCollector(&compute.Common{})
+	collector.Describe(ch)
+
+	assert.Len(t, ch, 5)
+}
+
+func TestIAMCollector_Collect(t *testing.T) {
+	ch := make(chan<- prometheus.Metric, 50)
+	defer close(ch)
+
+	ct := &mockIamCounterInterface{}
+	ct.On("Collect", ch).Once()
+
+	newIAMCounter = func() iamCounterInterface {
+		return ct
+	}
+
+	collector := NewIAMCollector(&compute.Common{})
+	collector.Collect(ch)
+
+	ct.AssertExpectations(t)
+}
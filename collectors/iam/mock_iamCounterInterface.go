@@ -0,0 +1,37 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package iam
+
+import (
+	prometheus "github.com/prometheus/client_golang/prometheus"
+	mock "github.com/stretchr/testify/mock"
+	cloudresourcemanager "google.golang.org/api/cloudresourcemanager/v1"
+	iamapi "google.golang.org/api/iam/v1"
+)
+
+// mockIamCounterInterface is an autogenerated mock type for the iamCounterInterface type
+type mockIamCounterInterface struct {
+	mock.Mock
+}
+
+// AddPolicy provides a mock function with given fields: _a0, _a1
+func (_m *mockIamCounterInterface) AddPolicy(_a0 string, _a1 *cloudresourcemanager.Policy) {
+	_m.Called(_a0, _a1)
+}
+
+// AddServiceAccounts provides a mock function with given fields: _a0, _a1
+func (_m *mockIamCounterInterface) AddServiceAccounts(_a0 string, _a1 []*iamapi.ServiceAccount) {
+	_m.Called(_a0, _a1)
+}
+
+// AddUserManagedKeys provides a mock function with given fields: _a0, _a1, _a2
+func (_m *mockIamCounterInterface) AddUserManagedKeys(_a0 string, _a1 *iamapi.ServiceAccount, _a2 []*iamapi.ServiceAccountKey) {
+	_m.Called(_a0, _a1, _a2)
+}
+
+// Collect provides a mock function with given fields: _a0
+func (_m *mockIamCounterInterface) Collect(_a0 chan<- prometheus.Metric) {
+	_m.Called(_a0)
+}
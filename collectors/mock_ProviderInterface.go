@@ -42,3 +42,33 @@ func (_m *MockProviderInterface) Init(_a0 *cli.Context) error {
 
 	return r0
 }
+
+// Ready provides a mock function with given fields:
+func (_m *MockProviderInterface) Ready() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Status provides a mock function with given fields:
+func (_m *MockProviderInterface) Status() []CollectorStatus {
+	ret := _m.Called()
+
+	var r0 []CollectorStatus
+	if rf, ok := ret.Get(0).(func() []CollectorStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]CollectorStatus)
+		}
+	}
+
+	return r0
+}
@@ -0,0 +1,182 @@
+package monitoring
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/helpers"
+)
+
+const (
+	metricNamePrefix = "gcp_exporter_monitoring_"
+
+	DefaultAlignmentPeriod = time.Minute
+	MinAlignmentPeriod     = time.Minute
+)
+
+var labelSources = []string{
+	"metric.labels.",
+	"resource.labels.",
+	"metadata.user_labels.",
+}
+
+type Query struct {
+	Name            string            `yaml:"name"`
+	Help            string            `yaml:"help"`
+	MetricType      string            `yaml:"metric_type"`
+	Filter          string            `yaml:"filter"`
+	AlignmentPeriod time.Duration     `yaml:"alignment_period"`
+	Delay           time.Duration     `yaml:"delay"`
+	Aligner         string            `yaml:"aligner"`
+	Reducer         string            `yaml:"reducer"`
+	GroupBy         []string          `yaml:"group_by"`
+	Labels          map[string]string `yaml:"labels"`
+
+	labelNames []string
+	desc       *prometheus.Desc
+}
+
+func (q *Query) validate() error {
+	if q.Name == "" {
+		return fmt.Errorf("name must be set")
+	}
+
+	if !model.IsValidMetricName(model.LabelValue(metricNamePrefix + q.Name)) {
+		return fmt.Errorf("invalid name %q", q.Name)
+	}
+
+	if q.MetricType == "" {
+		return fmt.Errorf("metric_type must be set")
+	}
+
+	if q.AlignmentPeriod == 0 {
+		q.AlignmentPeriod = DefaultAlignmentPeriod
+	}
+
+	if q.AlignmentPeriod < MinAlignmentPeriod || q.AlignmentPeriod%time.Second != 0 {
+		return fmt.Errorf("alignment_period must be a whole number of seconds, not shorter than %s", MinAlignmentPeriod)
+	}
+
+	if q.Delay < 0 {
+		return fmt.Errorf("delay can't be negative")
+	}
+
+	if !strings.HasPrefix(q.Aligner, "ALIGN_") || q.Aligner == "ALIGN_NONE" {
+		return fmt.Errorf("aligner must be set to one of ALIGN_* values other than ALIGN_NONE")
+	}
+
+	if q.Reducer != "" && !strings.HasPrefix(q.Reducer, "REDUCE_") {
+		return fmt.Errorf("invalid reducer %q", q.Reducer)
+	}
+
+	if len(q.GroupBy) > 0 && q.Reducer == "" {
+		return fmt.Errorf("group_by requires reducer to be set")
+	}
+
+	if len(q.Labels) == 0 {
+		q.Labels = make(map[string]string)
+		for _, field := range q.GroupBy {
+			name := labelNameFromField(field)
+			if _, ok := q.Labels[name]; ok {
+				return fmt.Errorf("group_by fields map to duplicated label name %q; use labels to name them", name)
+			}
+
+			q.Labels[name] = field
+		}
+	}
+
+	q.labelNames = make([]string, 0)
+	for name, field := range q.Labels {
+		if !model.LabelName(name).IsValid() || name == "project" {
+			return fmt.Errorf("invalid label name %q", name)
+		}
+
+		if !isValidLabelSource(field) {
+			return fmt.Errorf("invalid source %q of label %q", field, name)
+		}
+
+		q.labelNames = append(q.labelNames, name)
+	}
+	sort.Strings(q.labelNames)
+
+	help := q.Help
+	if help == "" {
+		help = fmt.Sprintf("Value of Cloud Monitoring metric %s", q.MetricType)
+	}
+
+	q.desc = prometheus.NewDesc(metricNamePrefix+q.Name, help, append([]string{"project"}, q.labelNames...), nil)
+
+	return nil
+}
+
+func (q *Query) filter() string {
+	filter := fmt.Sprintf("metric.type = %q", q.MetricType)
+	if q.Filter != "" {
+		filter = fmt.Sprintf("%s AND (%s)", filter, q.Filter)
+	}
+
+	return filter
+}
+
+// interval returns the last complete alignment window before now (minus
+// the configured delay). Windows are anchored to multiples of the alignment
+// period, so every refresh within the same period reads the same window
+// instead of a partially filled one.
+func (q *Query) interval(now time.Time) (time.Time, time.Time) {
+	end := now.Add(-q.Delay).Truncate(q.AlignmentPeriod)
+
+	return end.Add(-q.AlignmentPeriod), end
+}
+
+func labelNameFromField(field string) string {
+	parts := strings.Split(field, ".")
+
+	return helpers.SanitizeLabelName(parts[len(parts)-1])
+}
+
+func isValidLabelSource(field string) bool {
+	if field == "metric.type" || field == "resource.type" {
+		return true
+	}
+
+	for _, prefix := range labelSources {
+		if strings.HasPrefix(field, prefix) && len(field) > len(prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type Config struct {
+	Queries []*Query `yaml:"queries"`
+}
+
+func (c *Config) validate() error {
+	names := make([]string, 0, len(c.Queries))
+	for i, query := range c.Queries {
+		err := query.validate()
+		if err != nil {
+			return fmt.Errorf("query %d: %v", i, err)
+		}
+
+		names = append(names, query.Name)
+	}
+
+	return helpers.ValidateQueryNames(names)
+}
+
+func LoadConfig(path string) (*Config, error) {
+	config := &Config{}
+	err := helpers.LoadYAMLConfig("monitoring", path, config, config.validate)
+	if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
@@ -0,0 +1,183 @@
+package monitoring
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tests"
+)
+
+func writeConfig(t *testing.T, dir string, content string) string {
+	file := filepath.Join(dir, "monitoring.yml")
+	require.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+
+	return file
+}
+
+func TestLoadConfig(t *testing.T) {
+	tests.RunOnTempDir(t, "monitoring-config", func(t *testing.T, dir string) {
+		file := writeConfig(t, dir, `
+queries:
+  - name: instance_cpu_utilization
+    metric_type: compute.googleapis.com/instance/cpu/utilization
+    filter: resource.labels.zone = "us-east1-c"
+    alignment_period: 5m
+    delay: 3m
+    aligner: ALIGN_MEAN
+    reducer: REDUCE_MEAN
+    group_by:
+      - resource.labels.zone
+      - metadata.user_labels.role
+  - name: pubsub_backlog
+    help: Number of undelivered messages
+    metric_type: pubsub.googleapis.com/subscription/num_undelivered_messages
+    aligner: ALIGN_MAX
+    labels:
+      subscription: resource.labels.subscription_id
+`)
+
+		config, err := LoadConfig(file)
+		require.NoError(t, err)
+		require.Len(t, config.Queries, 2)
+
+		q1 := config.Queries[0]
+		assert.Equal(t, 5*time.Minute, q1.AlignmentPeriod)
+		assert.Equal(t, 3*time.Minute, q1.Delay)
+		assert.Equal(t, []string{"role", "zone"}, q1.labelNames)
+		assert.Equal(t, map[string]string{"zone": "resource.labels.zone", "role": "metadata.user_labels.role"}, q1.Labels)
+		assert.Equal(t, `metric.type = "compute.googleapis.com/instance/cpu/utilization" AND (resource.labels.zone = "us-east1-c")`, q1.filter())
+		assert.Contains(t, q1.desc.String(), `fqName: "gcp_exporter_monitoring_instance_cpu_utilization"`)
+		assert.Contains(t, q1.desc.String(), `variableLabels: [project role zone]`)
+
+		q2 := config.Queries[1]
+		assert.Equal(t, DefaultAlignmentPeriod, q2.AlignmentPeriod)
+		assert.Equal(t, []string{"subscription"}, q2.labelNames)
+		assert.Equal(t, `metric.type = "pubsub.googleapis.com/subscription/num_undelivered_messages"`, q2.filter())
+		assert.Contains(t, q2.desc.String(), `help: "Number of undelivered messages"`)
+	})
+}
+
+func TestLoadConfig_invalid(t *testing.T) {
+	examples := map[string]struct {
+		content       string
+		expectedError string
+	}{
+		"unknown field": {
+			content:       "queries:\n  - name: test\n    unknown: true\n",
+			expectedError: "could not parse monitoring config file",
+		},
+		"no queries": {
+			content:       "queries: []\n",
+			expectedError: "no queries defined",
+		},
+		"missing name": {
+			content:       "queries:\n  - metric_type: test\n    aligner: ALIGN_MEAN\n",
+			expectedError: "query 0: name must be set",
+		},
+		"invalid name": {
+			content:       "queries:\n  - name: cpu-utilization\n    metric_type: test\n    aligner: ALIGN_MEAN\n",
+			expectedError: `query 0: invalid name "cpu-utilization"`,
+		},
+		"missing metric type": {
+			content:       "queries:\n  - name: test\n    aligner: ALIGN_MEAN\n",
+			expectedError: "query 0: metric_type must be set",
+		},
+		"too short alignment period": {
+			content:       "queries:\n  - name: test\n    metric_type: test\n    aligner: ALIGN_MEAN\n    alignment_period: 30s\n",
+			expectedError: "query 0: alignment_period must be a whole number of seconds",
+		},
+		"negative delay": {
+			content:       "queries:\n  - name: test\n    metric_type: test\n    aligner: ALIGN_MEAN\n    delay: -1m\n",
+			expectedError: "query 0: delay can't be negative",
+		},
+		"missing aligner": {
+			content:       "queries:\n  - name: test\n    metric_type: test\n",
+			expectedError: "query 0: aligner must be set",
+		},
+		"aligner none": {
+			content:       "queries:\n  - name: test\n    metric_type: test\n    aligner: ALIGN_NONE\n",
+			expectedError: "query 0: aligner must be set",
+		},
+		"invalid reducer": {
+			content:       "queries:\n  - name: test\n    metric_type: test\n    aligner: ALIGN_MEAN\n    reducer: MEAN\n",
+			expectedError: `query 0: invalid reducer "MEAN"`,
+		},
+		"group by without reducer": {
+			content:       "queries:\n  - name: test\n    metric_type: test\n    aligner: ALIGN_MEAN\n    group_by: [resource.labels.zone]\n",
+			expectedError: "query 0: group_by requires reducer to be set",
+		},
+		"duplicated group by label": {
+			content:       "queries:\n  - name: test\n    metric_type: test\n    aligner: ALIGN_MEAN\n    reducer: REDUCE_SUM\n    group_by: [resource.labels.zone, metric.labels.zone]\n",
+			expectedError: `query 0: group_by fields map to duplicated label name "zone"`,
+		},
+		"reserved label name": {
+			content:       "queries:\n  - name: test\n    metric_type: test\n    aligner: ALIGN_MEAN\n    labels:\n      project: resource.labels.project_id\n",
+			expectedError: `query 0: invalid label name "project"`,
+		},
+		"invalid label source": {
+			content:       "queries:\n  - name: test\n    metric_type: test\n    aligner: ALIGN_MEAN\n    labels:\n      zone: zone\n",
+			expectedError: `query 0: invalid source "zone" of label "zone"`,
+		},
+		"duplicated name": {
+			content:       "queries:\n  - name: test\n    metric_type: test\n    aligner: ALIGN_MEAN\n  - name: test\n    metric_type: test\n    aligner: ALIGN_MEAN\n",
+			expectedError: `query 1: duplicated name "test"`,
+		},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			tests.RunOnTempDir(t, "monitoring-config", func(t *testing.T, dir string) {
+				_, err := LoadConfig(writeConfig(t, dir, example.content))
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), example.expectedError)
+			})
+		})
+	}
+}
+
+func TestLoadConfig_missingFile(t *testing.T) {
+	_, err := LoadConfig("/non/existing/monitoring.yml")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not read monitoring config file")
+}
+
+func TestQuery_interval(t *testing.T) {
+	examples := map[string]struct {
+		query         Query
+		now           time.Time
+		expectedStart time.Time
+		expectedEnd   time.Time
+	}{
+		"on period boundary": {
+			query:         Query{AlignmentPeriod: 5 * time.Minute},
+			now:           time.Date(2026, 10, 19, 12, 5, 0, 0, time.UTC),
+			expectedStart: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2026, 10, 19, 12, 5, 0, 0, time.UTC),
+		},
+		"inside of period": {
+			query:         Query{AlignmentPeriod: 5 * time.Minute},
+			now:           time.Date(2026, 10, 19, 12, 9, 59, 0, time.UTC),
+			expectedStart: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2026, 10, 19, 12, 5, 0, 0, time.UTC),
+		},
+		"with delay": {
+			query:         Query{AlignmentPeriod: time.Minute, Delay: 3 * time.Minute},
+			now:           time.Date(2026, 10, 19, 12, 5, 30, 0, time.UTC),
+			expectedStart: time.Date(2026, 10, 19, 12, 1, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2026, 10, 19, 12, 2, 0, 0, time.UTC),
+		},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			start, end := example.query.interval(example.now)
+			assert.Equal(t, example.expectedStart, start)
+			assert.Equal(t, example.expectedEnd, end)
+		})
+	}
+}
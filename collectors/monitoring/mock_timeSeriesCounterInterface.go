@@ -0,0 +1,26 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package monitoring
+
+import (
+	prometheus "github.com/prometheus/client_golang/prometheus"
+	mock "github.com/stretchr/testify/mock"
+	monitoring "google.golang.org/api/monitoring/v3"
+)
+
+// mockTimeSeriesCounterInterface is an autogenerated mock type for the timeSeriesCounterInterface type
+type mockTimeSeriesCounterInterface struct {
+	mock.Mock
+}
+
+// Add provides a mock function with given fields: _a0, _a1, _a2
+func (_m *mockTimeSeriesCounterInterface) Add(_a0 string, _a1 *Query, _a2 []*monitoring.TimeSeries) {
+	_m.Called(_a0, _a1, _a2)
+}
+
+// Collect provides a mock function with given fields: _a0
+func (_m *mockTimeSeriesCounterInterface) Collect(_a0 chan<- prometheus.Metric) {
+	_m.Called(_a0)
+}
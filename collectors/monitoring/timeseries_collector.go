@@ -0,0 +1,297 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	monitoringapi "google.golang.org/api/monitoring/v3"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+)
+
+const (
+	TimeSeriesCollectorName = "monitoring-collector"
+)
+
+var timeNow = time.Now
+
+type timeSeriesValue struct {
+	labelValues []string
+	value       float64
+}
+
+type timeSeriesCounterInterface interface {
+	Add(string, *Query, []*monitoringapi.TimeSeries)
+	Collect(chan<- prometheus.Metric)
+}
+
+type timeSeriesCounter struct {
+	values map[*Query]map[string]timeSeriesValue
+	lock   sync.RWMutex
+}
+
+func (tc *timeSeriesCounter) Add(project string, query *Query, timeSeries []*monitoringapi.TimeSeries) {
+	tc.lock.Lock()
+	defer tc.lock.Unlock()
+
+	values, ok := tc.values[query]
+	if !ok {
+		values = make(map[string]timeSeriesValue)
+		tc.values[query] = values
+	}
+
+	for _, series := range timeSeries {
+		value, ok := latestPointValue(series)
+		if !ok {
+			continue
+		}
+
+		labelValues := []string{project}
+		for _, name := range query.labelNames {
+			labelValues = append(labelValues, labelValue(series, query.Labels[name]))
+		}
+
+		key := strings.Join(labelValues, "\xff")
+		if _, exists := values[key]; exists {
+			logrus.WithFields(logrus.Fields{
+				"query":  query.Name,
+				"labels": strings.Join(labelValues, ","),
+			}).Warningln("Multiple time series map to the same labels; only the last one is exported")
+		}
+
+		values[key] = timeSeriesValue{labelValues: labelValues, value: value}
+	}
+}
+
+// latestPointValue returns the value of the newest point of the time series;
+// the API returns points in reverse time order.
+func latestPointValue(series *monitoringapi.TimeSeries) (float64, bool) {
+	if len(series.Points) < 1 || series.Points[0].Value == nil {
+		return 0, false
+	}
+
+	value := series.Points[0].Value
+	switch {
+	case value.DoubleValue != nil:
+		return *value.DoubleValue, true
+	case value.Int64Value != nil:
+		return float64(*value.Int64Value), true
+	case value.BoolValue != nil:
+		if *value.BoolValue {
+			return 1, true
+		}
+
+		return 0, true
+	case value.DistributionValue != nil:
+		return value.DistributionValue.Mean, true
+	}
+
+	return 0, false
+}
+
+func labelValue(series *monitoringapi.TimeSeries, field string) string {
+	switch {
+	case field == "metric.type":
+		if series.Metric != nil {
+			return series.Metric.Type
+		}
+	case field == "resource.type":
+		if series.Resource != nil {
+			return series.Resource.Type
+		}
+	case strings.HasPrefix(field, "metric.labels."):
+		if series.Metric != nil {
+			return series.Metric.Labels[strings.TrimPrefix(field, "metric.labels.")]
+		}
+	case strings.HasPrefix(field, "resource.labels."):
+		if series.Resource != nil {
+			return series.Resource.Labels[strings.TrimPrefix(field, "resource.labels.")]
+		}
+	case strings.HasPrefix(field, "metadata.user_labels."):
+		if series.Metadata != nil {
+			return series.Metadata.UserLabels[strings.TrimPrefix(field, "metadata.user_labels.")]
+		}
+	}
+
+	return ""
+}
+
+func (tc *timeSeriesCounter) Collect(ch chan<- prometheus.Metric) {
+	for query, values := range tc.values {
+		for _, value := range values {
+			ch <- prometheus.MustNewConstMetric(
+				query.desc,
+				prometheus.GaugeValue,
+				value.value,
+				value.labelValues...,
+			)
+		}
+	}
+}
+
+var newTimeSeriesCounter = func() timeSeriesCounterInterface {
+	return &timeSeriesCounter{
+		values: make(map[*Query]map[string]timeSeriesValue),
+	}
+}
+
+type TimeSeriesCollector struct {
+	*compute.Common
+
+	ConfigFile string `long:"monitoring-config-file" env:"GCP_EXPORTER_MONITORING_CONFIG_FILE" description:"Path to file with Cloud Monitoring queries exported by monitoring collector"`
+
+	config     *Config
+	service    services.MonitoringServiceInterface
+	timeSeries timeSeriesCounterInterface
+
+	initialized    bool
+	initalizedLock sync.RWMutex
+}
+
+func (c *TimeSeriesCollector) GetName() string {
+	return TimeSeriesCollectorName
+}
+
+func (c *TimeSeriesCollector) GetData(ctx context.Context) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("monitoring collector not initialized")
+	}
+
+	if c.service == nil {
+		return fmt.Errorf("monitoring collector monitoring.Service is not initialized")
+	}
+
+	now := timeNow()
+
+	count := newTimeSeriesCounter()
+	for _, project := range c.GetProjects() {
+		for _, query := range c.config.Queries {
+			start, end := query.interval(now)
+
+			logrus.WithFields(logrus.Fields{
+				"project": project,
+				"query":   query.Name,
+				"start":   start,
+				"end":     end,
+			}).Debugf("Requesting time series")
+
+			timeSeries, err := c.service.ListTimeSeries(ctx, project, services.TimeSeriesQuery{
+				Filter:          query.filter(),
+				StartTime:       start,
+				EndTime:         end,
+				AlignmentPeriod: query.AlignmentPeriod,
+				Aligner:         query.Aligner,
+				Reducer:         query.Reducer,
+				GroupBy:         query.GroupBy,
+			}, compute.PerPage)
+			if err != nil {
+				return fmt.Errorf("error while requesting time series data for query %s: %v", query.Name, err)
+			}
+
+			logrus.WithField("count", len(timeSeries)).Debugln("Found time series")
+
+			count.Add(project, query, timeSeries)
+		}
+	}
+
+	c.timeSeries = count
+
+	return nil
+}
+
+func (c *TimeSeriesCollector) isInitialized() bool {
+	c.initalizedLock.RLock()
+	defer c.initalizedLock.RUnlock()
+
+	return c.initialized
+}
+
+func (c *TimeSeriesCollector) Describe(ch chan<- *prometheus.Desc) {
+	if c.config == nil {
+		return
+	}
+
+	for _, query := range c.config.Queries {
+		ch <- query.desc
+	}
+}
+
+func (c *TimeSeriesCollector) Collect(ch chan<- prometheus.Metric) {
+	c.timeSeries.Collect(ch)
+}
+
+func (c *TimeSeriesCollector) Configuration() map[string]string {
+	configuration := map[string]string{
+		"projects":    strings.Join(c.GetProjects(), ","),
+		"config-file": c.ConfigFile,
+	}
+
+	if c.config != nil {
+		names := make([]string, 0)
+		for _, query := range c.config.Queries {
+			names = append(names, query.Name)
+		}
+
+		configuration["queries"] = strings.Join(names, ",")
+	}
+
+	return configuration
+}
+
+func (c *TimeSeriesCollector) Targets() int {
+	if c.config == nil {
+		return 0
+	}
+
+	return len(c.GetProjects()) * len(c.config.Queries)
+}
+
+func (c *TimeSeriesCollector) RequiredPermissions() []string {
+	return []string{"monitoring.timeSeries.list"}
+}
+
+func (c *TimeSeriesCollector) Init(client *http.Client) error {
+	var err error
+
+	if c.ConfigFile == "" {
+		return fmt.Errorf("monitoring collector requires --monitoring-config-file to be set")
+	}
+
+	c.config, err = LoadConfig(c.ConfigFile)
+	if err != nil {
+		return err
+	}
+
+	c.service, err = services.NewMonitoringService(client)
+	if err != nil {
+		return fmt.Errorf("error while initializing monitoringService: %v", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"projects": strings.Join(c.GetProjects(), ","),
+		"queries":  len(c.config.Queries),
+	}).Info("Registered collector")
+
+	c.initalizedLock.Lock()
+	defer c.initalizedLock.Unlock()
+
+	c.initialized = true
+
+	return nil
+}
+
+func NewTimeSeriesCollector(c *compute.Common) *TimeSeriesCollector {
+	return &TimeSeriesCollector{
+		Common:      c,
+		timeSeries:  newTimeSeriesCounter(),
+		initialized: false,
+	}
+}
@@ -0,0 +1,284 @@
+package monitoring
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	monitoringapi "google.golang.org/api/monitoring/v3"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tests"
+)
+
+func newTestQuery(t *testing.T, query *Query) *Query {
+	require.NoError(t, query.validate())
+
+	return query
+}
+
+func TestLatestPointValue(t *testing.T) {
+	double := 0.5
+	int64Value := int64(10)
+	boolValue := true
+	stringValue := "value"
+
+	examples := map[string]struct {
+		points        []*monitoringapi.Point
+		expected      float64
+		expectedFound bool
+	}{
+		"no points":    {points: nil},
+		"double":       {points: []*monitoringapi.Point{{Value: &monitoringapi.TypedValue{DoubleValue: &double}}}, expected: 0.5, expectedFound: true},
+		"int64":        {points: []*monitoringapi.Point{{Value: &monitoringapi.TypedValue{Int64Value: &int64Value}}}, expected: 10, expectedFound: true},
+		"bool":         {points: []*monitoringapi.Point{{Value: &monitoringapi.TypedValue{BoolValue: &boolValue}}}, expected: 1, expectedFound: true},
+		"distribution": {points: []*monitoringapi.Point{{Value: &monitoringapi.TypedValue{DistributionValue: &monitoringapi.Distribution{Mean: 2.5}}}}, expected: 2.5, expectedFound: true},
+		"string":       {points: []*monitoringapi.Point{{Value: &monitoringapi.TypedValue{StringValue: &stringValue}}}},
+		"newest first": {
+			points: []*monitoringapi.Point{
+				{Value: &monitoringapi.TypedValue{Int64Value: &int64Value}},
+				{Value: &monitoringapi.TypedValue{DoubleValue: &double}},
+			},
+			expected:      10,
+			expectedFound: true,
+		},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			value, found := latestPointValue(&monitoringapi.TimeSeries{Points: example.points})
+			assert.Equal(t, example.expectedFound, found)
+			assert.Equal(t, example.expected, value)
+		})
+	}
+}
+
+func TestTimeSeriesCounter_Add(t *testing.T) {
+	query := newTestQuery(t, &Query{
+		Name:       "test",
+		MetricType: "compute.googleapis.com/instance/cpu/utilization",
+		Aligner:    "ALIGN_MEAN",
+		Labels: map[string]string{
+			"zone":     "resource.labels.zone",
+			"instance": "metric.labels.instance_name",
+			"role":     "metadata.user_labels.role",
+		},
+	})
+
+	v1 := 0.25
+	v2 := 0.75
+
+	series := []*monitoringapi.TimeSeries{
+		{
+			Metric:   &monitoringapi.Metric{Labels: map[string]string{"instance_name": "instance-1"}},
+			Resource: &monitoringapi.MonitoredResource{Labels: map[string]string{"zone": "us-east1-c"}},
+			Metadata: &monitoringapi.MonitoredResourceMetadata{UserLabels: map[string]string{"role": "runner"}},
+			Points:   []*monitoringapi.Point{{Value: &monitoringapi.TypedValue{DoubleValue: &v1}}},
+		},
+		{
+			Metric:   &monitoringapi.Metric{Labels: map[string]string{"instance_name": "instance-2"}},
+			Resource: &monitoringapi.MonitoredResource{Labels: map[string]string{"zone": "us-east1-d"}},
+			Points:   []*monitoringapi.Point{{Value: &monitoringapi.TypedValue{DoubleValue: &v2}}},
+		},
+		{
+			Metric: &monitoringapi.Metric{Labels: map[string]string{"instance_name": "instance-3"}},
+		},
+	}
+
+	c := newTimeSeriesCounter().(*timeSeriesCounter)
+	c.Add("project", query, series)
+
+	require.Len(t, c.values[query], 2)
+
+	values := make([]timeSeriesValue, 0)
+	for _, value := range c.values[query] {
+		values = append(values, value)
+	}
+
+	assert.ElementsMatch(t, []timeSeriesValue{
+		{labelValues: []string{"project", "instance-1", "runner", "us-east1-c"}, value: 0.25},
+		{labelValues: []string{"project", "instance-2", "", "us-east1-d"}, value: 0.75},
+	}, values)
+}
+
+func TestTimeSeriesCounter_Add_duplicatedLabels(t *testing.T) {
+	query := newTestQuery(t, &Query{Name: "test", MetricType: "test", Aligner: "ALIGN_MEAN"})
+
+	v1 := 1.0
+	v2 := 2.0
+
+	series := []*monitoringapi.TimeSeries{
+		{Points: []*monitoringapi.Point{{Value: &monitoringapi.TypedValue{DoubleValue: &v1}}}},
+		{Points: []*monitoringapi.Point{{Value: &monitoringapi.TypedValue{DoubleValue: &v2}}}},
+	}
+
+	c := newTimeSeriesCounter().(*timeSeriesCounter)
+	c.Add("project", query, series)
+
+	require.Len(t, c.values[query], 1)
+	for _, value := range c.values[query] {
+		assert.Equal(t, 2.0, value.value)
+	}
+}
+
+func TestTimeSeriesCounter_Collect(t *testing.T) {
+	ch := make(chan prometheus.Metric, 50)
+	defer close(ch)
+
+	query := newTestQuery(t, &Query{Name: "test", MetricType: "test", Aligner: "ALIGN_MEAN", Labels: map[string]string{"zone": "resource.labels.zone"}})
+
+	c := newTimeSeriesCounter().(*timeSeriesCounter)
+	c.values[query] = map[string]timeSeriesValue{
+		"a": {labelValues: []string{"project", "us-east1-c"}, value: 1},
+		"b": {labelValues: []string{"project", "us-east1-d"}, value: 2},
+	}
+
+	c.Collect(ch)
+
+	assert.Len(t, ch, 2)
+}
+
+func TestTimeSeriesCollector_GetName(t *testing.T) {
+	collector := NewTimeSeriesCollector(&compute.Common{})
+	assert.Equal(t, "monitoring-collector", collector.GetName())
+}
+
+func TestTimeSeriesCollector_Init(t *testing.T) {
+	tests.RunOnTempDir(t, "monitoring-config", func(t *testing.T, dir string) {
+		collector := NewTimeSeriesCollector(&compute.Common{Projects: []string{"fake-project-1"}})
+		collector.ConfigFile = writeConfig(t, dir, "queries:\n  - name: test\n    metric_type: test\n    aligner: ALIGN_MEAN\n")
+
+		err := collector.Init(http.DefaultClient)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, collector.Targets())
+		assert.Equal(t, "test", collector.Configuration()["queries"])
+	})
+}
+
+func TestTimeSeriesCollector_Init_missingConfigFile(t *testing.T) {
+	collector := NewTimeSeriesCollector(&compute.Common{})
+	err := collector.Init(http.DefaultClient)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "monitoring collector requires --monitoring-config-file to be set")
+}
+
+func TestTimeSeriesCollector_GetData_withoutInitialize(t *testing.T) {
+	collector := NewTimeSeriesCollector(&compute.Common{})
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "monitoring collector not initialized")
+}
+
+func TestTimeSeriesCollector_GetData(t *testing.T) {
+	p1 := "fake-project-1"
+
+	oldTimeNow := timeNow
+	defer func() { timeNow = oldTimeNow }()
+
+	timeNow = func() time.Time {
+		return time.Date(2026, 10, 19, 12, 7, 30, 0, time.UTC)
+	}
+
+	query := newTestQuery(t, &Query{
+		Name:            "test",
+		MetricType:      "test.googleapis.com/metric",
+		AlignmentPeriod: 5 * time.Minute,
+		Aligner:         "ALIGN_MAX",
+		Reducer:         "REDUCE_SUM",
+		GroupBy:         []string{"resource.labels.zone"},
+	})
+
+	collector := NewTimeSeriesCollector(&compute.Common{Projects: []string{p1}})
+	collector.config = &Config{Queries: []*Query{query}}
+
+	list := []*monitoringapi.TimeSeries{{}}
+
+	service := &services.MockMonitoringServiceInterface{}
+	service.On("ListTimeSeries", mock.Anything, p1, services.TimeSeriesQuery{
+		Filter:          `metric.type = "test.googleapis.com/metric"`,
+		StartTime:       time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		EndTime:         time.Date(2026, 10, 19, 12, 5, 0, 0, time.UTC),
+		AlignmentPeriod: 5 * time.Minute,
+		Aligner:         "ALIGN_MAX",
+		Reducer:         "REDUCE_SUM",
+		GroupBy:         []string{"resource.labels.zone"},
+	}, int64(compute.PerPage)).Return(list, nil).Once()
+	collector.service = service
+
+	ct := &mockTimeSeriesCounterInterface{}
+	ct.On("Add", p1, query, list).Once()
+
+	newTimeSeriesCounter = func() timeSeriesCounterInterface {
+		return ct
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.NoError(t, err)
+	service.AssertExpectations(t)
+	ct.AssertExpectations(t)
+}
+
+func TestTimeSeriesCollector_GetData_ListTimeSeriesError(t *testing.T) {
+	query := newTestQuery(t, &Query{Name: "test", MetricType: "test", Aligner: "ALIGN_MEAN"})
+
+	collector := NewTimeSeriesCollector(&compute.Common{Projects: []string{"fake-project-1"}})
+	collector.config = &Config{Queries: []*Query{query}}
+
+	service := &services.MockMonitoringServiceInterface{}
+	service.On("ListTimeSeries", mock.Anything, "fake-project-1", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("fake-list-error")).Once()
+	collector.service = service
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error while requesting time series data for query test: fake-list-error")
+	service.AssertExpectations(t)
+}
+
+func TestTimeSeriesCollector_Describe(t *testing.T) {
+	ch := make(chan<- *prometheus.Desc, 50)
+	defer close(ch)
+
+	collector := NewTimeSeriesCollector(&compute.Common{})
+	collector.config = &Config{Queries: []*Query{
+		newTestQuery(t, &Query{Name: "test_1", MetricType: "test", Aligner: "ALIGN_MEAN"}),
+		newTestQuery(t, &Query{Name: "test_2", MetricType: "test", Aligner: "ALIGN_MEAN"}),
+	}}
+	collector.Describe(ch)
+
+	assert.Len(t, ch, 2)
+}
+
+func TestTimeSeriesCollector_Collect(t *testing.T) {
+	ch := make(chan<- prometheus.Metric, 50)
+	defer close(ch)
+
+	ct := &mockTimeSeriesCounterInterface{}
+	ct.On("Collect", ch).Once()
+
+	newTimeSeriesCounter = func() timeSeriesCounterInterface {
+		return ct
+	}
+
+	collector := NewTimeSeriesCollector(&compute.Common{})
+	collector.Collect(ch)
+
+	ct.AssertExpectations(t)
+}
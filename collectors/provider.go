@@ -4,14 +4,29 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/urfave/cli"
+	"go.opentelemetry.io/otel/attribute"
 
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/asset"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/billing"
 	col "gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/collector"
 	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/container"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/iam"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/monitoring"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/pubsub"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/serverless"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/sql"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/storage"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tracing"
 )
 
 const (
@@ -41,6 +56,18 @@ type ProviderInterface interface {
 
 	Init(*cli.Context) error
 	GetData(ctx context.Context)
+	Status() []CollectorStatus
+	Ready() error
+}
+
+type CollectorStatus struct {
+	Name          string            `json:"name"`
+	Configuration map[string]string `json:"configuration"`
+	Targets       int               `json:"targets"`
+	LastRefresh   time.Time         `json:"last_refresh"`
+	LastSuccess   time.Time         `json:"last_success"`
+	LastError     string            `json:"last_error"`
+	Errors        uint64            `json:"errors"`
 }
 
 type Provider struct {
@@ -49,6 +76,9 @@ type Provider struct {
 
 	getDataErrors        uint64
 	lastGetDataTimestamp time.Time
+
+	statuses     map[string]*CollectorStatus
+	statusesLock sync.RWMutex
 }
 
 func (p *Provider) Init(context *cli.Context) error {
@@ -74,23 +104,114 @@ func (p *Provider) Init(context *cli.Context) error {
 func (p *Provider) registerCollector(collectorName string, collector col.Interface) error {
 	logrus.Infof("Enabling %s", collectorName)
 	p.collectors = append(p.collectors, collector)
+
+	p.statusesLock.Lock()
+	p.statuses[collectorName] = &CollectorStatus{Name: collectorName}
+	p.statusesLock.Unlock()
+
 	return collector.Init(p.client)
 }
 
+func (p *Provider) EnabledCollectors() []col.Interface {
+	return p.collectors
+}
+
 func (p *Provider) GetData(ctx context.Context) {
 	logrus.Infoln("Getting data from GCP")
 
+	ctx, span := tracing.Start(ctx, "Provider.GetData")
+	defer span.End()
+
 	for _, collector := range p.collectors {
-		err := collector.GetData(ctx)
+		err := p.getCollectorData(ctx, collector)
 		if err != nil {
 			logrus.WithError(err).Errorln("Error while getting data from GCP")
 			p.getDataErrors++
 		}
+
+		p.updateStatus(collector, err)
 	}
 
 	p.lastGetDataTimestamp = time.Now()
 }
 
+func (p *Provider) getCollectorData(ctx context.Context, collector col.Interface) error {
+	ctx, span := tracing.Start(ctx, "Collector.GetData", attribute.String("collector", collector.GetName()))
+
+	err := collector.GetData(ctx)
+	tracing.End(span, err)
+
+	return err
+}
+
+func (p *Provider) updateStatus(collector col.Interface, err error) {
+	p.statusesLock.Lock()
+	defer p.statusesLock.Unlock()
+
+	status, ok := p.statuses[collector.GetName()]
+	if !ok {
+		status = &CollectorStatus{Name: collector.GetName()}
+		p.statuses[collector.GetName()] = status
+	}
+
+	status.LastRefresh = time.Now()
+	if err != nil {
+		status.LastError = err.Error()
+		status.Errors++
+
+		return
+	}
+
+	status.LastSuccess = status.LastRefresh
+	status.LastError = ""
+}
+
+func (p *Provider) Status() []CollectorStatus {
+	p.statusesLock.RLock()
+	defer p.statusesLock.RUnlock()
+
+	statuses := make([]CollectorStatus, 0, len(p.collectors))
+	for _, collector := range p.collectors {
+		status := CollectorStatus{Name: collector.GetName()}
+		if s, ok := p.statuses[collector.GetName()]; ok {
+			status = *s
+		}
+
+		if reporter, ok := collector.(col.StatusReporterInterface); ok {
+			status.Configuration = reporter.Configuration()
+			status.Targets = reporter.Targets()
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].Name < statuses[j].Name
+	})
+
+	return statuses
+}
+
+func (p *Provider) Ready() error {
+	p.statusesLock.RLock()
+	defer p.statusesLock.RUnlock()
+
+	notRefreshed := make([]string, 0)
+	for _, collector := range p.collectors {
+		status, ok := p.statuses[collector.GetName()]
+		if !ok || status.LastSuccess.IsZero() {
+			notRefreshed = append(notRefreshed, collector.GetName())
+		}
+	}
+
+	if len(notRefreshed) > 0 {
+		sort.Strings(notRefreshed)
+		return fmt.Errorf("no successful data refresh for: %s", strings.Join(notRefreshed, ", "))
+	}
+
+	return nil
+}
+
 func (p *Provider) Describe(ch chan<- *prometheus.Desc) {
 	ch <- timeOfLastDataRefresh
 	ch <- numberOfDataRerfeshErrors
@@ -114,6 +235,7 @@ func NewProvider(client *http.Client) *Provider {
 		client:               client,
 		getDataErrors:        0,
 		lastGetDataTimestamp: time.Unix(0, 0),
+		statuses:             make(map[string]*CollectorStatus),
 	}
 	provider.collectors = make([]col.Interface, 0)
 
@@ -121,12 +243,28 @@ func NewProvider(client *http.Client) *Provider {
 }
 
 func init() {
+	Collectors.AddFlagsFrom(services.DefaultAPICallPolicy)
+
 	computeCommon := &compute.Common{}
 	Collectors.AddFlagsFrom(computeCommon)
 
 	collectors := []col.Interface{
 		compute.NewInstancesCollector(computeCommon),
 		compute.NewRegionsCollector(computeCommon),
+		compute.NewInstanceGroupsCollector(computeCommon),
+		compute.NewAutoscalersCollector(computeCommon),
+		compute.NewNetworkingCollector(computeCommon),
+		compute.NewBackendServicesCollector(computeCommon),
+		compute.NewSubnetsCollector(computeCommon),
+		container.NewClustersCollector(computeCommon),
+		sql.NewInstancesCollector(computeCommon),
+		storage.NewBucketsCollector(computeCommon),
+		monitoring.NewTimeSeriesCollector(computeCommon),
+		billing.NewCostCollector(computeCommon),
+		pubsub.NewPubSubCollector(computeCommon),
+		serverless.NewServerlessCollector(computeCommon),
+		iam.NewIAMCollector(computeCommon),
+		asset.NewAssetsCollector(computeCommon),
 	}
 
 	for _, collector := range collectors {
@@ -12,7 +12,10 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/urfave/cli"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
 
 	col "gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/collector"
 	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tests"
@@ -119,6 +122,7 @@ func TestProvider_GetData(t *testing.T) {
 		c1 := &col.MockInterface{}
 		c1.On("Init", http.DefaultClient).Return(nil).Once()
 		c1.On("GetData", mock.Anything).Return(nil).Once()
+		c1.On("GetName").Return("first-fake-collector")
 		defer c1.AssertExpectations(t)
 
 		p := NewProvider(http.DefaultClient)
@@ -139,6 +143,7 @@ func TestProvider_GetDataFailure(t *testing.T) {
 		c1 := &col.MockInterface{}
 		c1.On("Init", http.DefaultClient).Return(nil).Once()
 		c1.On("GetData", mock.Anything).Return(fmt.Errorf("fake-error")).Once()
+		c1.On("GetName").Return("first-fake-collector")
 		defer c1.AssertExpectations(t)
 
 		p := NewProvider(http.DefaultClient)
@@ -153,6 +158,30 @@ func TestProvider_GetDataFailure(t *testing.T) {
 	})
 }
 
+func TestProvider_GetData_tracing(t *testing.T) {
+	tests.RunWithSpanRecorder(t, func(t *testing.T, recorder *tracetest.SpanRecorder) {
+		c1 := &col.MockInterface{}
+		c1.On("Init", http.DefaultClient).Return(nil).Once()
+		c1.On("GetData", mock.Anything).Return(fmt.Errorf("fake-error")).Once()
+		c1.On("GetName").Return("first-fake-collector")
+		defer c1.AssertExpectations(t)
+
+		p := NewProvider(http.DefaultClient)
+		p.registerCollector("first-fake-collector", c1)
+
+		p.GetData(context.Background())
+
+		providerSpans := tests.FindSpans(recorder, "Provider.GetData")
+		require.Len(t, providerSpans, 1)
+
+		collectorSpans := tests.FindSpans(recorder, "Collector.GetData")
+		require.Len(t, collectorSpans, 1)
+		assert.Equal(t, providerSpans[0].SpanContext().SpanID(), collectorSpans[0].Parent().SpanID())
+		assert.Equal(t, "first-fake-collector", tests.SpanAttributes(collectorSpans[0])["collector"].AsString())
+		assert.Equal(t, codes.Error, collectorSpans[0].Status().Code)
+	})
+}
+
 func TestProvider_Describe(t *testing.T) {
 	tests.RunOnHijackedLogrusOutput(t, func(t *testing.T, output *bytes.Buffer) {
 		ch := make(chan<- *prometheus.Desc, 10)
@@ -186,3 +215,99 @@ func TestProvider_Collect(t *testing.T) {
 		close(ch)
 	})
 }
+
+func TestProvider_Status(t *testing.T) {
+	tests.RunOnHijackedLogrusOutput(t, func(t *testing.T, output *bytes.Buffer) {
+		c1 := &col.MockInterface{}
+		c1.On("Init", http.DefaultClient).Return(nil).Once()
+		c1.On("GetData", mock.Anything).Return(nil).Once()
+		c1.On("GetName").Return("first-fake-collector")
+		defer c1.AssertExpectations(t)
+
+		c2 := &col.MockInterface{}
+		c2.On("Init", http.DefaultClient).Return(nil).Once()
+		c2.On("GetData", mock.Anything).Return(fmt.Errorf("fake-error")).Once()
+		c2.On("GetName").Return("second-fake-collector")
+		defer c2.AssertExpectations(t)
+
+		p := NewProvider(http.DefaultClient)
+		p.registerCollector("second-fake-collector", c2)
+		p.registerCollector("first-fake-collector", c1)
+
+		statuses := p.Status()
+		require.Len(t, statuses, 2)
+		assert.True(t, statuses[0].LastRefresh.IsZero())
+		assert.True(t, statuses[1].LastRefresh.IsZero())
+
+		p.GetData(context.Background())
+
+		statuses = p.Status()
+		require.Len(t, statuses, 2)
+
+		assert.Equal(t, "first-fake-collector", statuses[0].Name)
+		assert.False(t, statuses[0].LastSuccess.IsZero())
+		assert.Empty(t, statuses[0].LastError)
+		assert.Equal(t, uint64(0), statuses[0].Errors)
+
+		assert.Equal(t, "second-fake-collector", statuses[1].Name)
+		assert.False(t, statuses[1].LastRefresh.IsZero())
+		assert.True(t, statuses[1].LastSuccess.IsZero())
+		assert.Equal(t, "fake-error", statuses[1].LastError)
+		assert.Equal(t, uint64(1), statuses[1].Errors)
+	})
+}
+
+type fakeStatusReporterCollector struct {
+	col.MockInterface
+}
+
+func (fc *fakeStatusReporterCollector) Configuration() map[string]string {
+	return map[string]string{"projects": "fake-project"}
+}
+
+func (fc *fakeStatusReporterCollector) Targets() int {
+	return 3
+}
+
+func TestProvider_Status_withStatusReporter(t *testing.T) {
+	tests.RunOnHijackedLogrusOutput(t, func(t *testing.T, output *bytes.Buffer) {
+		c1 := &fakeStatusReporterCollector{}
+		c1.On("Init", http.DefaultClient).Return(nil).Once()
+		c1.On("GetName").Return("first-fake-collector")
+		defer c1.AssertExpectations(t)
+
+		p := NewProvider(http.DefaultClient)
+		p.registerCollector("first-fake-collector", c1)
+
+		statuses := p.Status()
+		require.Len(t, statuses, 1)
+		assert.Equal(t, "fake-project", statuses[0].Configuration["projects"])
+		assert.Equal(t, 3, statuses[0].Targets)
+	})
+}
+
+func TestProvider_Ready(t *testing.T) {
+	tests.RunOnHijackedLogrusOutput(t, func(t *testing.T, output *bytes.Buffer) {
+		c1 := &col.MockInterface{}
+		c1.On("Init", http.DefaultClient).Return(nil).Once()
+		c1.On("GetData", mock.Anything).Return(fmt.Errorf("fake-error")).Once()
+		c1.On("GetData", mock.Anything).Return(nil).Once()
+		c1.On("GetName").Return("first-fake-collector")
+		defer c1.AssertExpectations(t)
+
+		p := NewProvider(http.DefaultClient)
+		assert.NoError(t, p.Ready())
+
+		p.registerCollector("first-fake-collector", c1)
+
+		err := p.Ready()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no successful data refresh for: first-fake-collector")
+
+		p.GetData(context.Background())
+		assert.Error(t, p.Ready())
+
+		p.GetData(context.Background())
+		assert.NoError(t, p.Ready())
+	})
+}
@@ -0,0 +1,26 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package pubsub
+
+import (
+	prometheus "github.com/prometheus/client_golang/prometheus"
+	mock "github.com/stretchr/testify/mock"
+	pubsub "google.golang.org/api/pubsub/v1"
+)
+
+// mockPubSubCounterInterface is an autogenerated mock type for the pubSubCounterInterface type
+type mockPubSubCounterInterface struct {
+	mock.Mock
+}
+
+// Add provides a mock function with given fields: _a0, _a1, _a2
+func (_m *mockPubSubCounterInterface) Add(_a0 string, _a1 []*pubsub.Topic, _a2 []*pubsub.Subscription) {
+	_m.Called(_a0, _a1, _a2)
+}
+
+// Collect provides a mock function with given fields: _a0
+func (_m *mockPubSubCounterInterface) Collect(_a0 chan<- prometheus.Metric) {
+	_m.Called(_a0)
+}
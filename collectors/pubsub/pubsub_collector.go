@@ -0,0 +1,357 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/pubsub/v1"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/helpers"
+)
+
+const (
+	PubSubCollectorName = "pubsub-collector"
+
+	DeliveryTypePull         = "pull"
+	DeliveryTypePush         = "push"
+	DeliveryTypeBigQuery     = "bigquery"
+	DeliveryTypeCloudStorage = "cloud_storage"
+)
+
+var (
+	numberOfTopics = prometheus.NewDesc(
+		"gcp_exporter_pubsub_topics_count",
+		"Current number of Pub/Sub topics",
+		[]string{"project"},
+		nil,
+	)
+
+	topicSubscriptions = prometheus.NewDesc(
+		"gcp_exporter_pubsub_topic_subscriptions",
+		"Number of Pub/Sub subscriptions attached to the topic",
+		[]string{"project", "topic"},
+		nil,
+	)
+
+	numberOfSubscriptions = prometheus.NewDesc(
+		"gcp_exporter_pubsub_subscriptions_count",
+		"Current number of Pub/Sub subscriptions",
+		[]string{"project", "delivery_type", "state"},
+		nil,
+	)
+
+	subscriptionPushEndpoint = prometheus.NewDesc(
+		"gcp_exporter_pubsub_subscription_push_endpoint_configured",
+		"Whether push endpoint is configured (1) or not (0) for Pub/Sub subscription",
+		[]string{"project", "subscription", "topic"},
+		nil,
+	)
+
+	subscriptionDeadLetterPolicy = prometheus.NewDesc(
+		"gcp_exporter_pubsub_subscription_dead_letter_policy_configured",
+		"Whether dead-letter policy is configured (1) or not (0) for Pub/Sub subscription",
+		[]string{"project", "subscription", "topic"},
+		nil,
+	)
+
+	subscriptionAckDeadline = prometheus.NewDesc(
+		"gcp_exporter_pubsub_subscription_ack_deadline_seconds",
+		"Acknowledgement deadline of Pub/Sub subscription",
+		[]string{"project", "subscription", "topic"},
+		nil,
+	)
+
+	subscriptionMessageRetention = prometheus.NewDesc(
+		"gcp_exporter_pubsub_subscription_message_retention_seconds",
+		"Message retention duration of Pub/Sub subscription",
+		[]string{"project", "subscription", "topic"},
+		nil,
+	)
+)
+
+type subscriptionMetricType int
+
+const (
+	SubscriptionMetricTypePushEndpoint subscriptionMetricType = iota
+	SubscriptionMetricTypeDeadLetterPolicy
+	SubscriptionMetricTypeAckDeadline
+	SubscriptionMetricTypeMessageRetention
+)
+
+var subscriptionMetricDescs = map[subscriptionMetricType]*prometheus.Desc{
+	SubscriptionMetricTypePushEndpoint:     subscriptionPushEndpoint,
+	SubscriptionMetricTypeDeadLetterPolicy: subscriptionDeadLetterPolicy,
+	SubscriptionMetricTypeAckDeadline:      subscriptionAckDeadline,
+	SubscriptionMetricTypeMessageRetention: subscriptionMessageRetention,
+}
+
+type topicPermutation struct {
+	Project string
+	Topic   string
+}
+
+type subscriptionsPermutation struct {
+	Project      string
+	DeliveryType string
+	State        string
+}
+
+type subscriptionPermutation struct {
+	Project      string
+	Subscription string
+	Topic        string
+}
+
+type pubSubCounterInterface interface {
+	Add(string, []*pubsub.Topic, []*pubsub.Subscription)
+	Collect(chan<- prometheus.Metric)
+}
+
+type pubSubCounter struct {
+	topics             map[string]int
+	topicSubscriptions map[topicPermutation]int
+	subscriptions      map[subscriptionsPermutation]int
+	subscription       map[subscriptionPermutation]map[subscriptionMetricType]float64
+	lock               sync.RWMutex
+}
+
+func (pc *pubSubCounter) Add(project string, topics []*pubsub.Topic, subscriptions []*pubsub.Subscription) {
+	pc.lock.Lock()
+	defer pc.lock.Unlock()
+
+	pc.topics[project] += len(topics)
+
+	subscriptionsByTopic := make(map[string]int)
+	for _, topic := range topics {
+		subscriptionsByTopic[topic.Name] = 0
+	}
+
+	for _, subscription := range subscriptions {
+		if _, ok := subscriptionsByTopic[subscription.Topic]; ok {
+			subscriptionsByTopic[subscription.Topic]++
+		}
+
+		pc.subscriptions[subscriptionsPermutation{
+			Project:      project,
+			DeliveryType: deliveryType(subscription),
+			State:        subscription.State,
+		}]++
+
+		metrics := map[subscriptionMetricType]float64{
+			SubscriptionMetricTypePushEndpoint:     helpers.BoolToFloat64(subscription.PushConfig != nil && subscription.PushConfig.PushEndpoint != ""),
+			SubscriptionMetricTypeDeadLetterPolicy: helpers.BoolToFloat64(subscription.DeadLetterPolicy != nil),
+			SubscriptionMetricTypeAckDeadline:      float64(subscription.AckDeadlineSeconds),
+		}
+
+		retention, err := time.ParseDuration(subscription.MessageRetentionDuration)
+		if err == nil {
+			metrics[SubscriptionMetricTypeMessageRetention] = retention.Seconds()
+		}
+
+		pc.subscription[subscriptionPermutation{
+			Project:      project,
+			Subscription: strings.TrimPrefix(subscription.Name, "projects/"+project+"/subscriptions/"),
+			Topic:        topicName(project, subscription.Topic),
+		}] = metrics
+	}
+
+	for topic, count := range subscriptionsByTopic {
+		pc.topicSubscriptions[topicPermutation{Project: project, Topic: topicName(project, topic)}] = count
+	}
+}
+
+// topicName returns the ID of a topic from the given project and the full
+// name of a topic from another project. Subscriptions of deleted topics
+// reference the _deleted-topic_ name, which is returned unchanged.
+func topicName(project string, name string) string {
+	return strings.TrimPrefix(name, "projects/"+project+"/topics/")
+}
+
+func deliveryType(subscription *pubsub.Subscription) string {
+	switch {
+	case subscription.PushConfig != nil && subscription.PushConfig.PushEndpoint != "":
+		return DeliveryTypePush
+	case subscription.BigqueryConfig != nil:
+		return DeliveryTypeBigQuery
+	case subscription.CloudStorageConfig != nil:
+		return DeliveryTypeCloudStorage
+	}
+
+	return DeliveryTypePull
+}
+
+func (pc *pubSubCounter) Collect(ch chan<- prometheus.Metric) {
+	for project, count := range pc.topics {
+		ch <- prometheus.MustNewConstMetric(
+			numberOfTopics,
+			prometheus.GaugeValue,
+			float64(count),
+			project,
+		)
+	}
+
+	for permutation, count := range pc.topicSubscriptions {
+		ch <- prometheus.MustNewConstMetric(
+			topicSubscriptions,
+			prometheus.GaugeValue,
+			float64(count),
+			permutation.Project,
+			permutation.Topic,
+		)
+	}
+
+	for permutation, count := range pc.subscriptions {
+		ch <- prometheus.MustNewConstMetric(
+			numberOfSubscriptions,
+			prometheus.GaugeValue,
+			float64(count),
+			permutation.Project,
+			permutation.DeliveryType,
+			permutation.State,
+		)
+	}
+
+	for permutation, metrics := range pc.subscription {
+		for metricType, value := range metrics {
+			ch <- prometheus.MustNewConstMetric(
+				subscriptionMetricDescs[metricType],
+				prometheus.GaugeValue,
+				value,
+				permutation.Project,
+				permutation.Subscription,
+				permutation.Topic,
+			)
+		}
+	}
+}
+
+var newPubSubCounter = func() pubSubCounterInterface {
+	return &pubSubCounter{
+		topics:             make(map[string]int),
+		topicSubscriptions: make(map[topicPermutation]int),
+		subscriptions:      make(map[subscriptionsPermutation]int),
+		subscription:       make(map[subscriptionPermutation]map[subscriptionMetricType]float64),
+	}
+}
+
+type PubSubCollector struct {
+	*compute.Common
+
+	service services.PubSubServiceInterface
+	pubsub  pubSubCounterInterface
+
+	initialized    bool
+	initalizedLock sync.RWMutex
+}
+
+func (c *PubSubCollector) GetName() string {
+	return PubSubCollectorName
+}
+
+func (c *PubSubCollector) GetData(ctx context.Context) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("pubsub collector not initialized")
+	}
+
+	if c.service == nil {
+		return fmt.Errorf("pubsub collector pubsub.Service is not initialized")
+	}
+
+	count := newPubSubCounter()
+	for _, project := range c.GetProjects() {
+		logrus.WithField("project", project).Debugf("Requesting topics")
+
+		topics, err := c.service.ListTopics(ctx, project, compute.PerPage)
+		if err != nil {
+			return fmt.Errorf("error while requesting topics data: %v", err)
+		}
+
+		logrus.WithField("count", len(topics)).Debugln("Found topics")
+		logrus.WithField("project", project).Debugf("Requesting subscriptions")
+
+		subscriptions, err := c.service.ListSubscriptions(ctx, project, compute.PerPage)
+		if err != nil {
+			return fmt.Errorf("error while requesting subscriptions data: %v", err)
+		}
+
+		logrus.WithField("count", len(subscriptions)).Debugln("Found subscriptions")
+
+		count.Add(project, topics, subscriptions)
+	}
+
+	c.pubsub = count
+
+	return nil
+}
+
+func (c *PubSubCollector) isInitialized() bool {
+	c.initalizedLock.RLock()
+	defer c.initalizedLock.RUnlock()
+
+	return c.initialized
+}
+
+func (c *PubSubCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- numberOfTopics
+	ch <- topicSubscriptions
+	ch <- numberOfSubscriptions
+	ch <- subscriptionPushEndpoint
+	ch <- subscriptionDeadLetterPolicy
+	ch <- subscriptionAckDeadline
+	ch <- subscriptionMessageRetention
+}
+
+func (c *PubSubCollector) Collect(ch chan<- prometheus.Metric) {
+	c.pubsub.Collect(ch)
+}
+
+func (c *PubSubCollector) Configuration() map[string]string {
+	return map[string]string{
+		"projects": strings.Join(c.GetProjects(), ","),
+	}
+}
+
+func (c *PubSubCollector) Targets() int {
+	return len(c.GetProjects())
+}
+
+func (c *PubSubCollector) RequiredPermissions() []string {
+	return []string{"pubsub.topics.list", "pubsub.subscriptions.list"}
+}
+
+func (c *PubSubCollector) Init(client *http.Client) error {
+	var err error
+
+	c.service, err = services.NewPubSubService(client)
+	if err != nil {
+		return fmt.Errorf("error while initializing pubSubService: %v", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"projects": strings.Join(c.GetProjects(), ","),
+	}).Info("Registered collector")
+
+	c.initalizedLock.Lock()
+	defer c.initalizedLock.Unlock()
+
+	c.initialized = true
+
+	return nil
+}
+
+func NewPubSubCollector(c *compute.Common) *PubSubCollector {
+	return &PubSubCollector{
+		Common:      c,
+		pubsub:      newPubSubCounter(),
+		initialized: false,
+	}
+}
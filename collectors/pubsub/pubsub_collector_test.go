@@ -0,0 +1,231 @@
+package pubsub
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/pubsub/v1"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+)
+
+func TestPubSubCounter_Add(t *testing.T) {
+	topics := []*pubsub.Topic{
+		{Name: "projects/project/topics/topic-1"},
+		{Name: "projects/project/topics/topic-2"},
+	}
+
+	subscriptions := []*pubsub.Subscription{
+		{
+			Name:                     "projects/project/subscriptions/sub-1",
+			Topic:                    "projects/project/topics/topic-1",
+			State:                    "ACTIVE",
+			PushConfig:               &pubsub.PushConfig{PushEndpoint: "https://example.com/push"},
+			DeadLetterPolicy:         &pubsub.DeadLetterPolicy{DeadLetterTopic: "projects/project/topics/dead-letter"},
+			AckDeadlineSeconds:       30,
+			MessageRetentionDuration: "604800s",
+		},
+		{
+			Name:               "projects/project/subscriptions/sub-2",
+			Topic:              "projects/project/topics/topic-1",
+			State:              "ACTIVE",
+			PushConfig:         &pubsub.PushConfig{},
+			AckDeadlineSeconds: 10,
+		},
+		{
+			Name:               "projects/project/subscriptions/sub-3",
+			Topic:              "_deleted-topic_",
+			State:              "ACTIVE",
+			AckDeadlineSeconds: 10,
+		},
+		{
+			Name:               "projects/project/subscriptions/sub-4",
+			Topic:              "projects/other-project/topics/topic-3",
+			State:              "RESOURCE_ERROR",
+			BigqueryConfig:     &pubsub.BigQueryConfig{Table: "project.dataset.table"},
+			AckDeadlineSeconds: 60,
+		},
+	}
+
+	c := newPubSubCounter().(*pubSubCounter)
+	c.Add("project", topics, subscriptions)
+
+	assert.Equal(t, map[string]int{"project": 2}, c.topics)
+
+	assert.Equal(t, map[topicPermutation]int{
+		{Project: "project", Topic: "topic-1"}: 2,
+		{Project: "project", Topic: "topic-2"}: 0,
+	}, c.topicSubscriptions)
+
+	assert.Equal(t, map[subscriptionsPermutation]int{
+		{Project: "project", DeliveryType: DeliveryTypePush, State: "ACTIVE"}:             1,
+		{Project: "project", DeliveryType: DeliveryTypePull, State: "ACTIVE"}:             2,
+		{Project: "project", DeliveryType: DeliveryTypeBigQuery, State: "RESOURCE_ERROR"}: 1,
+	}, c.subscriptions)
+
+	assert.Equal(t, map[subscriptionPermutation]map[subscriptionMetricType]float64{
+		{Project: "project", Subscription: "sub-1", Topic: "topic-1"}: {
+			SubscriptionMetricTypePushEndpoint:     1,
+			SubscriptionMetricTypeDeadLetterPolicy: 1,
+			SubscriptionMetricTypeAckDeadline:      30,
+			SubscriptionMetricTypeMessageRetention: 604800,
+		},
+		{Project: "project", Subscription: "sub-2", Topic: "topic-1"}: {
+			SubscriptionMetricTypePushEndpoint:     0,
+			SubscriptionMetricTypeDeadLetterPolicy: 0,
+			SubscriptionMetricTypeAckDeadline:      10,
+		},
+		{Project: "project", Subscription: "sub-3", Topic: "_deleted-topic_"}: {
+			SubscriptionMetricTypePushEndpoint:     0,
+			SubscriptionMetricTypeDeadLetterPolicy: 0,
+			SubscriptionMetricTypeAckDeadline:      10,
+		},
+		{Project: "project", Subscription: "sub-4", Topic: "projects/other-project/topics/topic-3"}: {
+			SubscriptionMetricTypePushEndpoint:     0,
+			SubscriptionMetricTypeDeadLetterPolicy: 0,
+			SubscriptionMetricTypeAckDeadline:      60,
+		},
+	}, c.subscription)
+}
+
+func TestPubSubCounter_Collect(t *testing.T) {
+	ch := make(chan prometheus.Metric, 50)
+	defer close(ch)
+
+	c := newPubSubCounter().(*pubSubCounter)
+	c.topics["project"] = 1
+	c.topicSubscriptions[topicPermutation{Project: "project", Topic: "topic-1"}] = 1
+	c.subscriptions[subscriptionsPermutation{Project: "project", DeliveryType: DeliveryTypePull, State: "ACTIVE"}] = 1
+	c.subscription[subscriptionPermutation{Project: "project", Subscription: "sub-1", Topic: "topic-1"}] = map[subscriptionMetricType]float64{
+		SubscriptionMetricTypePushEndpoint: 0,
+		SubscriptionMetricTypeAckDeadline:  10,
+	}
+
+	c.Collect(ch)
+
+	assert.Len(t, ch, 5)
+}
+
+func TestPubSubCollector_GetName(t *testing.T) {
+	collector := NewPubSubCollector(&compute.Common{})
+	assert.Equal(t, "pubsub-collector", collector.GetName())
+}
+
+func TestPubSubCollector_Init(t *testing.T) {
+	collector := NewPubSubCollector(&compute.Common{})
+	err := collector.Init(http.DefaultClient)
+
+	assert.NoError(t, err)
+}
+
+func TestPubSubCollector_GetData_withoutInitialize(t *testing.T) {
+	collector := NewPubSubCollector(&compute.Common{})
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "pubsub collector not initialized")
+}
+
+func TestPubSubCollector_GetData(t *testing.T) {
+	p1 := "fake-project-1"
+	p2 := "fake-project-2"
+
+	collector := NewPubSubCollector(&compute.Common{Projects: []string{p1, p2}})
+
+	topics1 := []*pubsub.Topic{{Name: "projects/fake-project-1/topics/topic-1"}}
+	topics2 := make([]*pubsub.Topic, 0)
+	subscriptions1 := []*pubsub.Subscription{{Name: "projects/fake-project-1/subscriptions/sub-1"}}
+	subscriptions2 := make([]*pubsub.Subscription, 0)
+
+	service := &services.MockPubSubServiceInterface{}
+	service.On("ListTopics", mock.Anything, p1, int64(compute.PerPage)).Return(topics1, nil).Once()
+	service.On("ListTopics", mock.Anything, p2, int64(compute.PerPage)).Return(topics2, nil).Once()
+	service.On("ListSubscriptions", mock.Anything, p1, int64(compute.PerPage)).Return(subscriptions1, nil).Once()
+	service.On("ListSubscriptions", mock.Anything, p2, int64(compute.PerPage)).Return(subscriptions2, nil).Once()
+	collector.service = service
+
+	ct := &mockPubSubCounterInterface{}
+	ct.On("Add", p1, topics1, subscriptions1).Once()
+	ct.On("Add", p2, topics2, subscriptions2).Once()
+
+	newPubSubCounter = func() pubSubCounterInterface {
+		return ct
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.NoError(t, err)
+	service.AssertExpectations(t)
+	ct.AssertExpectations(t)
+}
+
+func TestPubSubCollector_GetData_ListTopicsError(t *testing.T) {
+	collector := NewPubSubCollector(&compute.Common{Projects: []string{"fake-project-1"}})
+
+	service := &services.MockPubSubServiceInterface{}
+	service.On("ListTopics", mock.Anything, "fake-project-1", mock.Anything).Return(nil, fmt.Errorf("fake-list-error")).Once()
+	collector.service = service
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error while requesting topics data: fake-list-error")
+	service.AssertExpectations(t)
+}
+
+func TestPubSubCollector_GetData_ListSubscriptionsError(t *testing.T) {
+	collector := NewPubSubCollector(&compute.Common{Projects: []string{"fake-project-1"}})
+
+	service := &services.MockPubSubServiceInterface{}
+	service.On("ListTopics", mock.Anything, "fake-project-1", mock.Anything).Return([]*pubsub.Topic{}, nil).Once()
+	service.On("ListSubscriptions", mock.Anything, "fake-project-1", mock.Anything).Return(nil, fmt.Errorf("fake-list-error")).Once()
+	collector.service = service
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error while requesting subscriptions data: fake-list-error")
+	service.AssertExpectations(t)
+}
+
+func TestPubSubCollector_Describe(t *testing.T) {
+	ch := make(chan<- *prometheus.Desc, 50)
+	defer close(ch)
+
+	collector := NewPubSubCollector(&compute.Common{})
+	collector.Describe(ch)
+
+	assert.Len(t, ch, 7)
+}
+
+func TestPubSubCollector_Collect(t *testing.T) {
+	ch := make(chan<- prometheus.Metric, 50)
+	defer close(ch)
+
+	ct := &mockPubSubCounterInterface{}
+	ct.On("Collect", ch).Once()
+
+	newPubSubCounter = func() pubSubCounterInterface {
+		return ct
+	}
+
+	collector := NewPubSubCollector(&compute.Common{})
+	collector.Collect(ch)
+
+	ct.AssertExpectations(t)
+}
@@ -0,0 +1,32 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package serverless
+
+import (
+	prometheus "github.com/prometheus/client_golang/prometheus"
+	mock "github.com/stretchr/testify/mock"
+	cloudfunctions "google.golang.org/api/cloudfunctions/v2"
+	run "google.golang.org/api/run/v2"
+)
+
+// mockServerlessCounterInterface is an autogenerated mock type for the serverlessCounterInterface type
+type mockServerlessCounterInterface struct {
+	mock.Mock
+}
+
+// AddFunctions provides a mock function with given fields: _a0, _a1, _a2
+func (_m *mockServerlessCounterInterface) AddFunctions(_a0 string, _a1 string, _a2 []*cloudfunctions.Function) {
+	_m.Called(_a0, _a1, _a2)
+}
+
+// AddRunServices provides a mock function with given fields: _a0, _a1, _a2, _a3
+func (_m *mockServerlessCounterInterface) AddRunServices(_a0 string, _a1 string, _a2 []*run.GoogleCloudRunV2Service, _a3 []*run.GoogleCloudRunV2Revision) {
+	_m.Called(_a0, _a1, _a2, _a3)
+}
+
+// Collect provides a mock function with given fields: _a0
+func (_m *mockServerlessCounterInterface) Collect(_a0 chan<- prometheus.Metric) {
+	_m.Called(_a0)
+}
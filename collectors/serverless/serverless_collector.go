@@ -0,0 +1,465 @@
+package serverless
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/api/cloudfunctions/v2"
+	"google.golang.org/api/run/v2"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+)
+
+const (
+	ServerlessCollectorName = "serverless-collector"
+
+	conditionSucceeded = "CONDITION_SUCCEEDED"
+	functionActive     = "ACTIVE"
+)
+
+var timeNow = time.Now
+
+var (
+	numberOfRunServices = prometheus.NewDesc(
+		"gcp_exporter_run_services_count",
+		"Current number of Cloud Run services",
+		[]string{"project", "region", "ready"},
+		nil,
+	)
+
+	runServiceMinInstances = prometheus.NewDesc(
+		"gcp_exporter_run_service_min_instances",
+		"Minimum number of instances configured for Cloud Run service",
+		[]string{"project", "region", "service"},
+		nil,
+	)
+
+	runServiceMaxInstances = prometheus.NewDesc(
+		"gcp_exporter_run_service_max_instances",
+		"Maximum number of instances configured for Cloud Run service; 0 when not set",
+		[]string{"project", "region", "service"},
+		nil,
+	)
+
+	runServiceLatestRevisionAge = prometheus.NewDesc(
+		"gcp_exporter_run_service_latest_revision_age_seconds",
+		"Time since the latest ready revision of Cloud Run service was created",
+		[]string{"project", "region", "service"},
+		nil,
+	)
+
+	numberOfFunctions = prometheus.NewDesc(
+		"gcp_exporter_functions_count",
+		"Current number of Cloud Functions",
+		[]string{"project", "region", "runtime", "environment", "ready"},
+		nil,
+	)
+
+	functionMinInstances = prometheus.NewDesc(
+		"gcp_exporter_function_min_instances",
+		"Minimum number of instances configured for Cloud Function",
+		[]string{"project", "region", "function"},
+		nil,
+	)
+
+	functionMaxInstances = prometheus.NewDesc(
+		"gcp_exporter_function_max_instances",
+		"Maximum number of instances configured for Cloud Function; 0 when not set",
+		[]string{"project", "region", "function"},
+		nil,
+	)
+
+	functionLatestRevisionAge = prometheus.NewDesc(
+		"gcp_exporter_function_latest_revision_age_seconds",
+		"Time since Cloud Function was last deployed",
+		[]string{"project", "region", "function"},
+		nil,
+	)
+)
+
+type serverlessMetricType int
+
+const (
+	ServerlessMetricTypeMinInstances serverlessMetricType = iota
+	ServerlessMetricTypeMaxInstances
+	ServerlessMetricTypeLatestRevisionAge
+)
+
+var runServiceMetricDescs = map[serverlessMetricType]*prometheus.Desc{
+	ServerlessMetricTypeMinInstances:      runServiceMinInstances,
+	ServerlessMetricTypeMaxInstances:      runServiceMaxInstances,
+	ServerlessMetricTypeLatestRevisionAge: runServiceLatestRevisionAge,
+}
+
+var functionMetricDescs = map[serverlessMetricType]*prometheus.Desc{
+	ServerlessMetricTypeMinInstances:      functionMinInstances,
+	ServerlessMetricTypeMaxInstances:      functionMaxInstances,
+	ServerlessMetricTypeLatestRevisionAge: functionLatestRevisionAge,
+}
+
+type runServicesPermutation struct {
+	Project string
+	Region  string
+	Ready   string
+}
+
+type functionsPermutation struct {
+	Project     string
+	Region      string
+	Runtime     string
+	Environment string
+	Ready       string
+}
+
+type resourcePermutation struct {
+	Project string
+	Region  string
+	Name    string
+}
+
+type serverlessCounterInterface interface {
+	AddRunServices(string, string, []*run.GoogleCloudRunV2Service, []*run.GoogleCloudRunV2Revision)
+	AddFunctions(string, string, []*cloudfunctions.Function)
+	Collect(chan<- prometheus.Metric)
+}
+
+type serverlessCounter struct {
+	runServicesCount map[runServicesPermutation]int
+	runServices      map[resourcePermutation]map[serverlessMetricType]float64
+	functionsCount   map[functionsPermutation]int
+	functions        map[resourcePermutation]map[serverlessMetricType]float64
+	lock             sync.RWMutex
+}
+
+func (sc *serverlessCounter) AddRunServices(project string, region string, runServices []*run.GoogleCloudRunV2Service, revisions []*run.GoogleCloudRunV2Revision) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	revisionCreateTimes := make(map[string]string)
+	for _, revision := range revisions {
+		revisionCreateTimes[revision.Name] = revision.CreateTime
+	}
+
+	now := timeNow()
+
+	for _, service := range runServices {
+		ready := service.TerminalCondition != nil && service.TerminalCondition.State == conditionSucceeded
+
+		sc.runServicesCount[runServicesPermutation{
+			Project: project,
+			Region:  region,
+			Ready:   strconv.FormatBool(ready),
+		}]++
+
+		metrics := map[serverlessMetricType]float64{
+			ServerlessMetricTypeMinInstances: 0,
+			ServerlessMetricTypeMaxInstances: 0,
+		}
+
+		if service.Template != nil && service.Template.Scaling != nil {
+			metrics[ServerlessMetricTypeMinInstances] = float64(service.Template.Scaling.MinInstanceCount)
+			metrics[ServerlessMetricTypeMaxInstances] = float64(service.Template.Scaling.MaxInstanceCount)
+		}
+
+		age, ok := ageOf(revisionCreateTimes[service.LatestReadyRevision], now)
+		if ok {
+			metrics[ServerlessMetricTypeLatestRevisionAge] = age
+		}
+
+		sc.runServices[resourcePermutation{Project: project, Region: region, Name: resourceID(service.Name)}] = metrics
+	}
+}
+
+func (sc *serverlessCounter) AddFunctions(project string, region string, functions []*cloudfunctions.Function) {
+	sc.lock.Lock()
+	defer sc.lock.Unlock()
+
+	now := timeNow()
+
+	for _, function := range functions {
+		runtime := ""
+		if function.BuildConfig != nil {
+			runtime = function.BuildConfig.Runtime
+		}
+
+		sc.functionsCount[functionsPermutation{
+			Project:     project,
+			Region:      region,
+			Runtime:     runtime,
+			Environment: function.Environment,
+			Ready:       strconv.FormatBool(function.State == functionActive),
+		}]++
+
+		metrics := map[serverlessMetricType]float64{
+			ServerlessMetricTypeMinInstances: 0,
+			ServerlessMetricTypeMaxInstances: 0,
+		}
+
+		if function.ServiceConfig != nil {
+			metrics[ServerlessMetricTypeMinInstances] = float64(function.ServiceConfig.MinInstanceCount)
+			metrics[ServerlessMetricTypeMaxInstances] = float64(function.ServiceConfig.MaxInstanceCount)
+		}
+
+		age, ok := ageOf(function.UpdateTime, now)
+		if ok {
+			metrics[ServerlessMetricTypeLatestRevisionAge] = age
+		}
+
+		sc.functions[resourcePermutation{Project: project, Region: region, Name: resourceID(function.Name)}] = metrics
+	}
+}
+
+func ageOf(timestamp string, now time.Time) (float64, bool) {
+	if timestamp == "" {
+		return 0, false
+	}
+
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return 0, false
+	}
+
+	return now.Sub(t).Seconds(), true
+}
+
+func resourceID(name string) string {
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+func (sc *serverlessCounter) Collect(ch chan<- prometheus.Metric) {
+	for permutation, count := range sc.runServicesCount {
+		ch <- prometheus.MustNewConstMetric(
+			numberOfRunServices,
+			prometheus.GaugeValue,
+			float64(count),
+			permutation.Project,
+			permutation.Region,
+			permutation.Ready,
+		)
+	}
+
+	collectResources(ch, sc.runServices, runServiceMetricDescs)
+
+	for permutation, count := range sc.functionsCount {
+		ch <- prometheus.MustNewConstMetric(
+			numberOfFunctions,
+			prometheus.GaugeValue,
+			float64(count),
+			permutation.Project,
+			permutation.Region,
+			permutation.Runtime,
+			permutation.Environment,
+			permutation.Ready,
+		)
+	}
+
+	collectResources(ch, sc.functions, functionMetricDescs)
+}
+
+func collectResources(ch chan<- prometheus.Metric, resources map[resourcePermutation]map[serverlessMetricType]float64, descs map[serverlessMetricType]*prometheus.Desc) {
+	for permutation, metrics := range resources {
+		for metricType, value := range metrics {
+			ch <- prometheus.MustNewConstMetric(
+				descs[metricType],
+				prometheus.GaugeValue,
+				value,
+				permutation.Project,
+				permutation.Region,
+				permutation.Name,
+			)
+		}
+	}
+}
+
+var newServerlessCounter = func() serverlessCounterInterface {
+	return &serverlessCounter{
+		runServicesCount: make(map[runServicesPermutation]int),
+		runServices:      make(map[resourcePermutation]map[serverlessMetricType]float64),
+		functionsCount:   make(map[functionsPermutation]int),
+		functions:        make(map[resourcePermutation]map[serverlessMetricType]float64),
+	}
+}
+
+type ServerlessCollector struct {
+	*compute.Common
+
+	runService       services.RunServiceInterface
+	functionsService services.CloudFunctionsServiceInterface
+	serverless       serverlessCounterInterface
+
+	initialized    bool
+	initalizedLock sync.RWMutex
+}
+
+func (c *ServerlessCollector) GetName() string {
+	return ServerlessCollectorName
+}
+
+func (c *ServerlessCollector) GetData(ctx context.Context) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("serverless collector not initialized")
+	}
+
+	if c.runService == nil {
+		return fmt.Errorf("serverless collector run.Service is not initialized")
+	}
+
+	if c.functionsService == nil {
+		return fmt.Errorf("serverless collector cloudfunctions.Service is not initialized")
+	}
+
+	count := newServerlessCounter()
+	for _, project := range c.GetProjects() {
+		for _, region := range c.GetRegions() {
+			err := c.addRunServices(ctx, count, project, region)
+			if err != nil {
+				return err
+			}
+
+			err = c.addFunctions(ctx, count, project, region)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	c.serverless = count
+
+	return nil
+}
+
+func (c *ServerlessCollector) addRunServices(ctx context.Context, count serverlessCounterInterface, project string, region string) error {
+	log := logrus.WithFields(logrus.Fields{
+		"project": project,
+		"region":  region,
+	})
+
+	log.Debugf("Requesting Cloud Run services")
+
+	runServices, err := c.runService.ListServices(ctx, project, region, compute.PerPage)
+	if err != nil {
+		return fmt.Errorf("error while requesting Cloud Run services data: %v", err)
+	}
+
+	logrus.WithField("count", len(runServices)).Debugln("Found Cloud Run services")
+
+	// Only the latest ready revisions are requested; listing revisions
+	// would return all revisions retained for each service
+	revisions := make([]*run.GoogleCloudRunV2Revision, 0)
+	for _, service := range runServices {
+		if service.LatestReadyRevision == "" {
+			continue
+		}
+
+		log.WithField("revision", service.LatestReadyRevision).Debugf("Requesting Cloud Run revision")
+
+		revision, err := c.runService.GetRevision(ctx, project, service.LatestReadyRevision)
+		if err != nil {
+			return fmt.Errorf("error while requesting Cloud Run revisions data: %v", err)
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	count.AddRunServices(project, region, runServices, revisions)
+
+	return nil
+}
+
+func (c *ServerlessCollector) addFunctions(ctx context.Context, count serverlessCounterInterface, project string, region string) error {
+	logrus.WithFields(logrus.Fields{
+		"project": project,
+		"region":  region,
+	}).Debugf("Requesting Cloud Functions")
+
+	functions, err := c.functionsService.ListFunctions(ctx, project, region, compute.PerPage)
+	if err != nil {
+		return fmt.Errorf("error while requesting Cloud Functions data: %v", err)
+	}
+
+	logrus.WithField("count", len(functions)).Debugln("Found Cloud Functions")
+
+	count.AddFunctions(project, region, functions)
+
+	return nil
+}
+
+func (c *ServerlessCollector) isInitialized() bool {
+	c.initalizedLock.RLock()
+	defer c.initalizedLock.RUnlock()
+
+	return c.initialized
+}
+
+func (c *ServerlessCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- numberOfRunServices
+	ch <- runServiceMinInstances
+	ch <- runServiceMaxInstances
+	ch <- runServiceLatestRevisionAge
+	ch <- numberOfFunctions
+	ch <- functionMinInstances
+	ch <- functionMaxInstances
+	ch <- functionLatestRevisionAge
+}
+
+func (c *ServerlessCollector) Collect(ch chan<- prometheus.Metric) {
+	c.serverless.Collect(ch)
+}
+
+func (c *ServerlessCollector) Configuration() map[string]string {
+	return map[string]string{
+		"projects": strings.Join(c.GetProjects(), ","),
+		"regions":  strings.Join(c.GetRegions(), ","),
+	}
+}
+
+func (c *ServerlessCollector) Targets() int {
+	return len(c.GetProjects()) * len(c.GetRegions())
+}
+
+func (c *ServerlessCollector) RequiredPermissions() []string {
+	return []string{"run.services.list", "run.revisions.get", "cloudfunctions.functions.list"}
+}
+
+func (c *ServerlessCollector) Init(client *http.Client) error {
+	var err error
+
+	c.runService, err = services.NewRunService(client)
+	if err != nil {
+		return fmt.Errorf("error while initializing runService: %v", err)
+	}
+
+	c.functionsService, err = services.NewCloudFunctionsService(client)
+	if err != nil {
+		return fmt.Errorf("error while initializing cloudFunctionsService: %v", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"projects": strings.Join(c.GetProjects(), ","),
+		"regions":  strings.Join(c.GetRegions(), ","),
+	}).Info("Registered collector")
+
+	c.initalizedLock.Lock()
+	defer c.initalizedLock.Unlock()
+
+	c.initialized = true
+
+	return nil
+}
+
+func NewServerlessCollector(c *compute.Common) *ServerlessCollector {
+	return &ServerlessCollector{
+		Common:      c,
+		serverless:  newServerlessCounter(),
+		initialized: false,
+	}
+}
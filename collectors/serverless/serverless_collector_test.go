@@ -0,0 +1,297 @@
+package serverless
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"google.golang.org/api/cloudfunctions/v2"
+	"google.golang.org/api/run/v2"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+)
+
+func TestServerlessCounter_AddRunServices(t *testing.T) {
+	oldTimeNow := timeNow
+	defer func() { timeNow = oldTimeNow }()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time {
+		return now
+	}
+
+	runServices := []*run.GoogleCloudRunV2Service{
+		{
+			Name:                "projects/project/locations/us-east1/services/service-1",
+			TerminalCondition:   &run.GoogleCloudRunV2Condition{Type: "Ready", State: "CONDITION_SUCCEEDED"},
+			LatestReadyRevision: "projects/project/locations/us-east1/services/service-1/revisions/service-1-00002",
+			Template: &run.GoogleCloudRunV2RevisionTemplate{
+				Scaling: &run.GoogleCloudRunV2RevisionScaling{MinInstanceCount: 1, MaxInstanceCount: 10},
+			},
+		},
+		{
+			Name:              "projects/project/locations/us-east1/services/service-2",
+			TerminalCondition: &run.GoogleCloudRunV2Condition{Type: "Ready", State: "CONDITION_FAILED"},
+		},
+	}
+
+	revisions := []*run.GoogleCloudRunV2Revision{
+		{
+			Name:       "projects/project/locations/us-east1/services/service-1/revisions/service-1-00001",
+			CreateTime: "2026-10-01T12:00:00Z",
+		},
+		{
+			Name:       "projects/project/locations/us-east1/services/service-1/revisions/service-1-00002",
+			CreateTime: "2026-10-19T11:00:00.123456Z",
+		},
+	}
+
+	c := newServerlessCounter().(*serverlessCounter)
+	c.AddRunServices("project", "us-east1", runServices, revisions)
+
+	assert.Equal(t, map[runServicesPermutation]int{
+		{Project: "project", Region: "us-east1", Ready: "true"}:  1,
+		{Project: "project", Region: "us-east1", Ready: "false"}: 1,
+	}, c.runServicesCount)
+
+	p1 := resourcePermutation{Project: "project", Region: "us-east1", Name: "service-1"}
+	require.Contains(t, c.runServices, p1)
+	assert.Equal(t, float64(1), c.runServices[p1][ServerlessMetricTypeMinInstances])
+	assert.Equal(t, float64(10), c.runServices[p1][ServerlessMetricTypeMaxInstances])
+	assert.InDelta(t, 3599.876544, c.runServices[p1][ServerlessMetricTypeLatestRevisionAge], 0.000001)
+
+	p2 := resourcePermutation{Project: "project", Region: "us-east1", Name: "service-2"}
+	assert.Equal(t, map[serverlessMetricType]float64{
+		ServerlessMetricTypeMinInstances: 0,
+		ServerlessMetricTypeMaxInstances: 0,
+	}, c.runServices[p2])
+}
+
+func TestServerlessCounter_AddFunctions(t *testing.T) {
+	oldTimeNow := timeNow
+	defer func() { timeNow = oldTimeNow }()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	timeNow = func() time.Time {
+		return now
+	}
+
+	functions := []*cloudfunctions.Function{
+		{
+			Name:          "projects/project/locations/us-east1/functions/function-1",
+			Environment:   "GEN_2",
+			State:         "ACTIVE",
+			BuildConfig:   &cloudfunctions.BuildConfig{Runtime: "go121"},
+			ServiceConfig: &cloudfunctions.ServiceConfig{MinInstanceCount: 2, MaxInstanceCount: 5},
+			UpdateTime:    "2026-10-18T12:00:00Z",
+		},
+		{
+			Name:        "projects/project/locations/us-east1/functions/function-2",
+			Environment: "GEN_1",
+			State:       "FAILED",
+		},
+	}
+
+	c := newServerlessCounter().(*serverlessCounter)
+	c.AddFunctions("project", "us-east1", functions)
+
+	assert.Equal(t, map[functionsPermutation]int{
+		{Project: "project", Region: "us-east1", Runtime: "go121", Environment: "GEN_2", Ready: "true"}: 1,
+		{Project: "project", Region: "us-east1", Runtime: "", Environment: "GEN_1", Ready: "false"}:     1,
+	}, c.functionsCount)
+
+	assert.Equal(t, map[resourcePermutation]map[serverlessMetricType]float64{
+		{Project: "project", Region: "us-east1", Name: "function-1"}: {
+			ServerlessMetricTypeMinInstances:      2,
+			ServerlessMetricTypeMaxInstances:      5,
+			ServerlessMetricTypeLatestRevisionAge: 86400,
+		},
+		{Project: "project", Region: "us-east1", Name: "function-2"}: {
+			ServerlessMetricTypeMinInstances: 0,
+			ServerlessMetricTypeMaxInstances: 0,
+		},
+	}, c.functions)
+}
+
+func TestServerlessCounter_Collect(t *testing.T) {
+	ch := make(chan prometheus.Metric, 50)
+	defer close(ch)
+
+	p := resourcePermutation{Project: "project", Region: "us-east1", Name: "name"}
+
+	c := newServerlessCounter().(*serverlessCounter)
+	c.runServicesCount[runServicesPermutation{Project: "project", Region: "us-east1", Ready: "true"}] = 1
+	c.runServices[p] = map[serverlessMetricType]float64{
+		ServerlessMetricTypeMinInstances:      0,
+		ServerlessMetricTypeMaxInstances:      10,
+		ServerlessMetricTypeLatestRevisionAge: 60,
+	}
+	c.functionsCount[functionsPermutation{Project: "project", Region: "us-east1", Runtime: "go121", Environment: "GEN_2", Ready: "true"}] = 1
+	c.functions[p] = map[serverlessMetricType]float64{
+		ServerlessMetricTypeMinInstances: 0,
+		ServerlessMetricTypeMaxInstances: 0,
+	}
+
+	c.Collect(ch)
+
+	assert.Len(t, ch, 7)
+}
+
+func TestServerlessCollector_GetName(t *testing.T) {
+	collector := NewServerlessCollector(&compute.Common{})
+	assert.Equal(t, "serverless-collector", collector.GetName())
+}
+
+func TestServerlessCollector_Init(t *testing.T) {
+	collector := NewServerlessCollector(&compute.Common{})
+	err := collector.Init(http.DefaultClient)
+
+	assert.NoError(t, err)
+}
+
+func TestServerlessCollector_GetData_withoutInitialize(t *testing.T) {
+	collector := NewServerlessCollector(&compute.Common{})
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "serverless collector not initialized")
+}
+
+func TestServerlessCollector_GetData(t *testing.T) {
+	p1 := "fake-project-1"
+	r1 := "us-east1"
+	r2 := "us-east4"
+
+	collector := NewServerlessCollector(&compute.Common{Projects: []string{p1}, Zones: []string{"us-east1-c", "us-east4-a"}})
+
+	runServices1 := []*run.GoogleCloudRunV2Service{
+		{Name: "service-1", LatestReadyRevision: "revision-1"},
+		{Name: "service-2"},
+	}
+	runServices2 := make([]*run.GoogleCloudRunV2Service, 0)
+	revision1 := &run.GoogleCloudRunV2Revision{Name: "revision-1"}
+	functions1 := []*cloudfunctions.Function{{Name: "function-1"}}
+	functions2 := make([]*cloudfunctions.Function, 0)
+
+	runService := &services.MockRunServiceInterface{}
+	runService.On("ListServices", mock.Anything, p1, r1, int64(compute.PerPage)).Return(runServices1, nil).Once()
+	runService.On("ListServices", mock.Anything, p1, r2, int64(compute.PerPage)).Return(runServices2, nil).Once()
+	runService.On("GetRevision", mock.Anything, p1, "revision-1").Return(revision1, nil).Once()
+	collector.runService = runService
+
+	functionsService := &services.MockCloudFunctionsServiceInterface{}
+	functionsService.On("ListFunctions", mock.Anything, p1, r1, int64(compute.PerPage)).Return(functions1, nil).Once()
+	functionsService.On("ListFunctions", mock.Anything, p1, r2, int64(compute.PerPage)).Return(functions2, nil).Once()
+	collector.functionsService = functionsService
+
+	ct := &mockServerlessCounterInterface{}
+	ct.On("AddRunServices", p1, r1, runServices1, []*run.GoogleCloudRunV2Revision{revision1}).Once()
+	ct.On("AddRunServices", p1, r2, runServices2, []*run.GoogleCloudRunV2Revision{}).Once()
+	ct.On("AddFunctions", p1, r1, functions1).Once()
+	ct.On("AddFunctions", p1, r2, functions2).Once()
+
+	newServerlessCounter = func() serverlessCounterInterface {
+		return ct
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.NoError(t, err)
+	runService.AssertExpectations(t)
+	functionsService.AssertExpectations(t)
+	ct.AssertExpectations(t)
+}
+
+func TestServerlessCollector_GetData_errors(t *testing.T) {
+	examples := map[string]struct {
+		servicesErr   error
+		revisionsErr  error
+		functionsErr  error
+		expectedError string
+	}{
+		"services error": {
+			servicesErr:   fmt.Errorf("fake-list-error"),
+			expectedError: "error while requesting Cloud Run services data: fake-list-error",
+		},
+		"revisions error": {
+			revisionsErr:  fmt.Errorf("fake-get-error"),
+			expectedError: "error while requesting Cloud Run revisions data: fake-get-error",
+		},
+		"functions error": {
+			functionsErr:  fmt.Errorf("fake-list-error"),
+			expectedError: "error while requesting Cloud Functions data: fake-list-error",
+		},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			collector := NewServerlessCollector(&compute.Common{Projects: []string{"fake-project-1"}, Zones: []string{"us-east1-c"}})
+
+			var runServices []*run.GoogleCloudRunV2Service
+			if example.servicesErr == nil {
+				runServices = []*run.GoogleCloudRunV2Service{{Name: "service-1", LatestReadyRevision: "revision-1"}}
+			}
+
+			runService := &services.MockRunServiceInterface{}
+			runService.On("ListServices", mock.Anything, "fake-project-1", "us-east1", mock.Anything).Return(runServices, example.servicesErr).Maybe()
+			runService.On("GetRevision", mock.Anything, "fake-project-1", "revision-1").Return(&run.GoogleCloudRunV2Revision{}, example.revisionsErr).Maybe()
+			collector.runService = runService
+
+			functionsService := &services.MockCloudFunctionsServiceInterface{}
+			functionsService.On("ListFunctions", mock.Anything, "fake-project-1", "us-east1", mock.Anything).Return(nil, example.functionsErr).Maybe()
+			collector.functionsService = functionsService
+
+			ct := &mockServerlessCounterInterface{}
+			ct.On("AddRunServices", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
+
+			newServerlessCounter = func() serverlessCounterInterface {
+				return ct
+			}
+
+			collector.initialized = true
+
+			err := collector.GetData(context.Background())
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), example.expectedError)
+		})
+	}
+}
+
+func TestServerlessCollector_Describe(t *testing.T) {
+	ch := make(chan<- *prometheus.Desc, 50)
+	defer close(ch)
+
+	collector := NewServerlessCollector(&compute.Common{})
+	collector.Describe(ch)
+
+	assert.Len(t, ch, 8)
+}
+
+func TestServerlessCollector_Collect(t *testing.T) {
+	ch := make(chan<- prometheus.Metric, 50)
+	defer close(ch)
+
+	ct := &mockServerlessCounterInterface{}
+	ct.On("Collect", ch).Once()
+
+	newServerlessCounter = func() serverlessCounterInterface {
+		return ct
+	}
+
+	collector := NewServerlessCollector(&compute.Common{})
+	collector.Collect(ch)
+
+	ct.AssertExpectations(t)
+}
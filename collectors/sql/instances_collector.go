@@ -0,0 +1,290 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/sqladmin/v1"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/helpers"
+)
+
+const (
+	InstancesCollectorName = "sql-collector"
+
+	bytesInGB = 1 << 30
+)
+
+var (
+	numberOfInstances = prometheus.NewDesc(
+		"gcp_exporter_sql_instances_count",
+		"Current number of Cloud SQL instances",
+		[]string{"project", "region", "database_version", "tier", "state", "availability_type"},
+		nil,
+	)
+
+	instanceStorageSize = prometheus.NewDesc(
+		"gcp_exporter_sql_instance_storage_size_bytes",
+		"Configured storage size of Cloud SQL instance",
+		[]string{"project", "region", "instance"},
+		nil,
+	)
+
+	instanceStorageAutoResize = prometheus.NewDesc(
+		"gcp_exporter_sql_instance_storage_auto_resize_enabled",
+		"Whether automatic storage increase is enabled (1) or not (0) for Cloud SQL instance",
+		[]string{"project", "region", "instance"},
+		nil,
+	)
+
+	instanceStorageAutoResizeLimit = prometheus.NewDesc(
+		"gcp_exporter_sql_instance_storage_auto_resize_limit_bytes",
+		"Maximum size to which storage of Cloud SQL instance can be automatically increased; 0 means no limit",
+		[]string{"project", "region", "instance"},
+		nil,
+	)
+
+	instanceMaintenanceWindow = prometheus.NewDesc(
+		"gcp_exporter_sql_instance_maintenance_window_configured",
+		"Whether maintenance window is configured (1) or not (0) for Cloud SQL instance",
+		[]string{"project", "region", "instance"},
+		nil,
+	)
+
+	instanceBackupWindow = prometheus.NewDesc(
+		"gcp_exporter_sql_instance_backup_window_configured",
+		"Whether automated backups with a start time are configured (1) or not (0) for Cloud SQL instance",
+		[]string{"project", "region", "instance"},
+		nil,
+	)
+)
+
+type instanceMetricType int
+
+const (
+	InstanceMetricTypeStorageSize instanceMetricType = iota
+	InstanceMetricTypeStorageAutoResize
+	InstanceMetricTypeStorageAutoResizeLimit
+	InstanceMetricTypeMaintenanceWindow
+	InstanceMetricTypeBackupWindow
+)
+
+var instanceMetricDescs = map[instanceMetricType]*prometheus.Desc{
+	InstanceMetricTypeStorageSize:            instanceStorageSize,
+	InstanceMetricTypeStorageAutoResize:      instanceStorageAutoResize,
+	InstanceMetricTypeStorageAutoResizeLimit: instanceStorageAutoResizeLimit,
+	InstanceMetricTypeMaintenanceWindow:      instanceMaintenanceWindow,
+	InstanceMetricTypeBackupWindow:           instanceBackupWindow,
+}
+
+type instancesPermutation struct {
+	Project          string
+	Region           string
+	DatabaseVersion  string
+	Tier             string
+	State            string
+	AvailabilityType string
+}
+
+type instancePermutation struct {
+	Project  string
+	Region   string
+	Instance string
+}
+
+type instancesCounterInterface interface {
+	Add(string, []*sqladmin.DatabaseInstance)
+	Collect(chan<- prometheus.Metric)
+}
+
+type instancesCounter struct {
+	count     map[instancesPermutation]int
+	instances map[instancePermutation]map[instanceMetricType]float64
+	lock      sync.RWMutex
+}
+
+func (ic *instancesCounter) Add(project string, instances []*sqladmin.DatabaseInstance) {
+	ic.lock.Lock()
+	defer ic.lock.Unlock()
+
+	for _, instance := range instances {
+		permutation := instancesPermutation{
+			Project:         project,
+			Region:          instance.Region,
+			DatabaseVersion: instance.DatabaseVersion,
+			State:           instance.State,
+		}
+
+		metrics := make(map[instanceMetricType]float64)
+
+		settings := instance.Settings
+		if settings != nil {
+			permutation.Tier = settings.Tier
+			permutation.AvailabilityType = settings.AvailabilityType
+
+			autoResize := settings.StorageAutoResize != nil && *settings.StorageAutoResize
+
+			metrics[InstanceMetricTypeStorageSize] = float64(settings.DataDiskSizeGb * bytesInGB)
+			metrics[InstanceMetricTypeStorageAutoResize] = helpers.BoolToFloat64(autoResize)
+			if autoResize {
+				metrics[InstanceMetricTypeStorageAutoResizeLimit] = float64(settings.StorageAutoResizeLimit * bytesInGB)
+			}
+
+			metrics[InstanceMetricTypeMaintenanceWindow] = helpers.BoolToFloat64(settings.MaintenanceWindow != nil && settings.MaintenanceWindow.Day > 0)
+
+			backup := settings.BackupConfiguration
+			metrics[InstanceMetricTypeBackupWindow] = helpers.BoolToFloat64(backup != nil && backup.Enabled && backup.StartTime != "")
+		}
+
+		ic.count[permutation]++
+		ic.instances[instancePermutation{Project: project, Region: instance.Region, Instance: instance.Name}] = metrics
+	}
+}
+
+func (ic *instancesCounter) Collect(ch chan<- prometheus.Metric) {
+	for permutation, count := range ic.count {
+		ch <- prometheus.MustNewConstMetric(
+			numberOfInstances,
+			prometheus.GaugeValue,
+			float64(count),
+			permutation.Project,
+			permutation.Region,
+			permutation.DatabaseVersion,
+			permutation.Tier,
+			permutation.State,
+			permutation.AvailabilityType,
+		)
+	}
+
+	for permutation, metrics := range ic.instances {
+		for metricType, value := range metrics {
+			ch <- prometheus.MustNewConstMetric(
+				instanceMetricDescs[metricType],
+				prometheus.GaugeValue,
+				value,
+				permutation.Project,
+				permutation.Region,
+				permutation.Instance,
+			)
+		}
+	}
+}
+
+var newInstancesCounter = func() instancesCounterInterface {
+	return &instancesCounter{
+		count:     make(map[instancesPermutation]int),
+		instances: make(map[instancePermutation]map[instanceMetricType]float64),
+	}
+}
+
+type InstancesCollector struct {
+	*compute.Common
+
+	service   services.SQLAdminServiceInterface
+	instances instancesCounterInterface
+
+	initialized    bool
+	initalizedLock sync.RWMutex
+}
+
+func (c *InstancesCollector) GetName() string {
+	return InstancesCollectorName
+}
+
+func (c *InstancesCollector) GetData(ctx context.Context) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("sql collector not initialized")
+	}
+
+	if c.service == nil {
+		return fmt.Errorf("sql collector sqladmin.Service is not initialized")
+	}
+
+	count := newInstancesCounter()
+	for _, project := range c.GetProjects() {
+		logrus.WithField("project", project).Debugf("Requesting Cloud SQL instances")
+
+		instances, err := c.service.ListInstances(ctx, project, compute.PerPage)
+		if err != nil {
+			return fmt.Errorf("error while requesting Cloud SQL instances data: %v", err)
+		}
+
+		logrus.WithField("count", len(instances)).Debugln("Found Cloud SQL instances")
+
+		count.Add(project, instances)
+	}
+
+	c.instances = count
+
+	return nil
+}
+
+func (c *InstancesCollector) isInitialized() bool {
+	c.initalizedLock.RLock()
+	defer c.initalizedLock.RUnlock()
+
+	return c.initialized
+}
+
+func (c *InstancesCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- numberOfInstances
+	ch <- instanceStorageSize
+	ch <- instanceStorageAutoResize
+	ch <- instanceStorageAutoResizeLimit
+	ch <- instanceMaintenanceWindow
+	ch <- instanceBackupWindow
+}
+
+func (c *InstancesCollector) Collect(ch chan<- prometheus.Metric) {
+	c.instances.Collect(ch)
+}
+
+func (c *InstancesCollector) Configuration() map[string]string {
+	return map[string]string{
+		"projects": strings.Join(c.GetProjects(), ","),
+	}
+}
+
+func (c *InstancesCollector) Targets() int {
+	return len(c.GetProjects())
+}
+
+func (c *InstancesCollector) RequiredPermissions() []string {
+	return []string{"cloudsql.instances.list"}
+}
+
+func (c *InstancesCollector) Init(client *http.Client) error {
+	var err error
+
+	c.service, err = services.NewSQLAdminService(client)
+	if err != nil {
+		return fmt.Errorf("error while initializing sqlAdminService: %v", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"projects": strings.Join(c.GetProjects(), ","),
+	}).Info("Registered collector")
+
+	c.initalizedLock.Lock()
+	defer c.initalizedLock.Unlock()
+
+	c.initialized = true
+
+	return nil
+}
+
+func NewInstancesCollector(c *compute.Common) *InstancesCollector {
+	return &InstancesCollector{
+		Common:      c,
+		instances:   newInstancesCounter(),
+		initialized: false,
+	}
+}
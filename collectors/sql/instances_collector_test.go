@@ -0,0 +1,191 @@
+package sql
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/sqladmin/v1"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+)
+
+func TestInstancesCounter_Add(t *testing.T) {
+	autoResize := true
+
+	instance1 := &sqladmin.DatabaseInstance{
+		Name:            "db-1",
+		Region:          "us-east1",
+		DatabaseVersion: "POSTGRES_15",
+		State:           "RUNNABLE",
+		Settings: &sqladmin.Settings{
+			Tier:                   "db-custom-2-7680",
+			AvailabilityType:       "REGIONAL",
+			DataDiskSizeGb:         100,
+			StorageAutoResize:      &autoResize,
+			StorageAutoResizeLimit: 500,
+			MaintenanceWindow:      &sqladmin.MaintenanceWindow{Day: 7, Hour: 3},
+			BackupConfiguration:    &sqladmin.BackupConfiguration{Enabled: true, StartTime: "02:00"},
+		},
+	}
+	instance2 := &sqladmin.DatabaseInstance{
+		Name:            "db-2",
+		Region:          "us-east1",
+		DatabaseVersion: "POSTGRES_15",
+		State:           "RUNNABLE",
+		Settings: &sqladmin.Settings{
+			Tier:                "db-custom-2-7680",
+			AvailabilityType:    "REGIONAL",
+			DataDiskSizeGb:      10,
+			MaintenanceWindow:   &sqladmin.MaintenanceWindow{},
+			BackupConfiguration: &sqladmin.BackupConfiguration{Enabled: false, StartTime: "02:00"},
+		},
+	}
+
+	c := newInstancesCounter().(*instancesCounter)
+	c.Add("project", []*sqladmin.DatabaseInstance{instance1, instance2})
+
+	p := instancesPermutation{
+		Project:          "project",
+		Region:           "us-east1",
+		DatabaseVersion:  "POSTGRES_15",
+		Tier:             "db-custom-2-7680",
+		State:            "RUNNABLE",
+		AvailabilityType: "REGIONAL",
+	}
+	assert.Len(t, c.count, 1)
+	assert.Equal(t, 2, c.count[p])
+
+	assert.Equal(t, map[instanceMetricType]float64{
+		InstanceMetricTypeStorageSize:            100 * 1024 * 1024 * 1024,
+		InstanceMetricTypeStorageAutoResize:      1,
+		InstanceMetricTypeStorageAutoResizeLimit: 500 * 1024 * 1024 * 1024,
+		InstanceMetricTypeMaintenanceWindow:      1,
+		InstanceMetricTypeBackupWindow:           1,
+	}, c.instances[instancePermutation{Project: "project", Region: "us-east1", Instance: "db-1"}])
+
+	assert.Equal(t, map[instanceMetricType]float64{
+		InstanceMetricTypeStorageSize:       10 * 1024 * 1024 * 1024,
+		InstanceMetricTypeStorageAutoResize: 0,
+		InstanceMetricTypeMaintenanceWindow: 0,
+		InstanceMetricTypeBackupWindow:      0,
+	}, c.instances[instancePermutation{Project: "project", Region: "us-east1", Instance: "db-2"}])
+}
+
+func TestInstancesCounter_Collect(t *testing.T) {
+	ch := make(chan prometheus.Metric, 50)
+	defer close(ch)
+
+	c := newInstancesCounter().(*instancesCounter)
+	c.count[instancesPermutation{Project: "project", Region: "us-east1"}] = 1
+	c.instances[instancePermutation{Project: "project", Region: "us-east1", Instance: "db-1"}] = map[instanceMetricType]float64{
+		InstanceMetricTypeStorageSize:       1,
+		InstanceMetricTypeStorageAutoResize: 0,
+	}
+
+	c.Collect(ch)
+
+	assert.Len(t, ch, 3)
+}
+
+func TestInstancesCollector_GetName(t *testing.T) {
+	collector := NewInstancesCollector(&compute.Common{})
+	assert.Equal(t, "sql-collector", collector.GetName())
+}
+
+func TestInstancesCollector_Init(t *testing.T) {
+	collector := NewInstancesCollector(&compute.Common{})
+	err := collector.Init(http.DefaultClient)
+
+	assert.NoError(t, err)
+}
+
+func TestInstancesCollector_GetData_withoutInitialize(t *testing.T) {
+	collector := NewInstancesCollector(&compute.Common{})
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sql collector not initialized")
+}
+
+func TestInstancesCollector_GetData(t *testing.T) {
+	p1 := "fake-project-1"
+	p2 := "fake-project-2"
+
+	collector := NewInstancesCollector(&compute.Common{Projects: []string{p1, p2}})
+
+	list1 := []*sqladmin.DatabaseInstance{{Name: "db-1"}}
+	list2 := make([]*sqladmin.DatabaseInstance, 0)
+
+	service := &services.MockSQLAdminServiceInterface{}
+	service.On("ListInstances", mock.Anything, p1, int64(compute.PerPage)).Return(list1, nil).Once()
+	service.On("ListInstances", mock.Anything, p2, int64(compute.PerPage)).Return(list2, nil).Once()
+	collector.service = service
+
+	ct := &mockInstancesCounterInterface{}
+	ct.On("Add", p1, list1).Once()
+	ct.On("Add", p2, list2).Once()
+
+	newInstancesCounter = func() instancesCounterInterface {
+		return ct
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.NoError(t, err)
+	service.AssertExpectations(t)
+	ct.AssertExpectations(t)
+}
+
+func TestInstancesCollector_GetData_ListInstancesError(t *testing.T) {
+	collector := NewInstancesCollector(&compute.Common{Projects: []string{"fake-project-1"}})
+
+	service := &services.MockSQLAdminServiceInterface{}
+	service.On("ListInstances", mock.Anything, "fake-project-1", mock.Anything).Return(nil, fmt.Errorf("fake-list-error")).Once()
+	collector.service = service
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error while requesting Cloud SQL instances data: fake-list-error")
+	service.AssertExpectations(t)
+}
+
+func TestInstancesCollector_Describe(t *testing.T) {
+	ch := make(chan<- *prometheus.Desc, 50)
+	defer close(ch)
+
+	collector := NewInstancesCollector(&compute.Common{})
+	collector.Describe(ch)
+
+	assert.Len(t, ch, 6)
+}
+
+func TestInstancesCollector_Collect(t *testing.T) {
+	ch := make(chan<- prometheus.Metric, 50)
+	defer close(ch)
+
+	ct := &mockInstancesCounterInterface{}
+	ct.On("Collect", ch).Once()
+
+	newInstancesCounter = func() instancesCounterInterface {
+		return ct
+	}
+
+	collector := NewInstancesCollector(&compute.Common{})
+	collector.Collect(ch)
+
+	ct.AssertExpectations(t)
+}
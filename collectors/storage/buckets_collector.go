@@ -0,0 +1,305 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"google.golang.org/api/storage/v1"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/helpers"
+)
+
+const (
+	BucketsCollectorName = "storage-collector"
+)
+
+var (
+	numberOfBuckets = prometheus.NewDesc(
+		"gcp_exporter_storage_buckets_count",
+		"Current number of Cloud Storage buckets",
+		[]string{"project", "location", "storage_class"},
+		nil,
+	)
+
+	bucketVersioning = prometheus.NewDesc(
+		"gcp_exporter_storage_bucket_versioning_enabled",
+		"Whether object versioning is enabled (1) or not (0) for Cloud Storage bucket",
+		[]string{"project", "location", "bucket"},
+		nil,
+	)
+
+	bucketRetentionPolicy = prometheus.NewDesc(
+		"gcp_exporter_storage_bucket_retention_policy_configured",
+		"Whether retention policy is configured (1) or not (0) for Cloud Storage bucket",
+		[]string{"project", "location", "bucket"},
+		nil,
+	)
+
+	bucketRetentionPolicyLocked = prometheus.NewDesc(
+		"gcp_exporter_storage_bucket_retention_policy_locked",
+		"Whether retention policy is locked (1) or not (0) for Cloud Storage bucket",
+		[]string{"project", "location", "bucket"},
+		nil,
+	)
+
+	bucketUniformAccess = prometheus.NewDesc(
+		"gcp_exporter_storage_bucket_uniform_access_enabled",
+		"Whether uniform bucket-level access is enabled (1) or not (0) for Cloud Storage bucket",
+		[]string{"project", "location", "bucket"},
+		nil,
+	)
+
+	bucketPublicAccessPrevention = prometheus.NewDesc(
+		"gcp_exporter_storage_bucket_public_access_prevention",
+		"Public access prevention status of Cloud Storage bucket; always 1",
+		[]string{"project", "location", "bucket", "status"},
+		nil,
+	)
+
+	bucketLifecycleRules = prometheus.NewDesc(
+		"gcp_exporter_storage_bucket_lifecycle_rules",
+		"Number of lifecycle rules configured for Cloud Storage bucket",
+		[]string{"project", "location", "bucket"},
+		nil,
+	)
+)
+
+type bucketMetricType int
+
+const (
+	BucketMetricTypeVersioning bucketMetricType = iota
+	BucketMetricTypeRetentionPolicy
+	BucketMetricTypeRetentionPolicyLocked
+	BucketMetricTypeUniformAccess
+	BucketMetricTypeLifecycleRules
+)
+
+var bucketMetricDescs = map[bucketMetricType]*prometheus.Desc{
+	BucketMetricTypeVersioning:            bucketVersioning,
+	BucketMetricTypeRetentionPolicy:       bucketRetentionPolicy,
+	BucketMetricTypeRetentionPolicyLocked: bucketRetentionPolicyLocked,
+	BucketMetricTypeUniformAccess:         bucketUniformAccess,
+	BucketMetricTypeLifecycleRules:        bucketLifecycleRules,
+}
+
+type bucketsPermutation struct {
+	Project      string
+	Location     string
+	StorageClass string
+}
+
+type bucketPermutation struct {
+	Project  string
+	Location string
+	Bucket   string
+}
+
+type bucketsCounterInterface interface {
+	Add(string, []*storage.Bucket)
+	Collect(chan<- prometheus.Metric)
+}
+
+type bucketsCounter struct {
+	count                  map[bucketsPermutation]int
+	buckets                map[bucketPermutation]map[bucketMetricType]float64
+	publicAccessPrevention map[bucketPermutation]string
+	lock                   sync.RWMutex
+}
+
+func (bc *bucketsCounter) Add(project string, buckets []*storage.Bucket) {
+	bc.lock.Lock()
+	defer bc.lock.Unlock()
+
+	for _, bucket := range buckets {
+		bc.count[bucketsPermutation{
+			Project:      project,
+			Location:     bucket.Location,
+			StorageClass: bucket.StorageClass,
+		}]++
+
+		permutation := bucketPermutation{Project: project, Location: bucket.Location, Bucket: bucket.Name}
+
+		retention := bucket.RetentionPolicy
+		metrics := map[bucketMetricType]float64{
+			BucketMetricTypeVersioning:            helpers.BoolToFloat64(bucket.Versioning != nil && bucket.Versioning.Enabled),
+			BucketMetricTypeRetentionPolicy:       helpers.BoolToFloat64(retention != nil),
+			BucketMetricTypeRetentionPolicyLocked: helpers.BoolToFloat64(retention != nil && retention.IsLocked),
+			BucketMetricTypeUniformAccess:         0,
+			BucketMetricTypeLifecycleRules:        0,
+		}
+
+		iam := bucket.IamConfiguration
+		if iam != nil {
+			metrics[BucketMetricTypeUniformAccess] = helpers.BoolToFloat64(iam.UniformBucketLevelAccess != nil && iam.UniformBucketLevelAccess.Enabled)
+
+			if iam.PublicAccessPrevention != "" {
+				bc.publicAccessPrevention[permutation] = iam.PublicAccessPrevention
+			}
+		}
+
+		if bucket.Lifecycle != nil {
+			metrics[BucketMetricTypeLifecycleRules] = float64(len(bucket.Lifecycle.Rule))
+		}
+
+		bc.buckets[permutation] = metrics
+	}
+}
+
+func (bc *bucketsCounter) Collect(ch chan<- prometheus.Metric) {
+	for permutation, count := range bc.count {
+		ch <- prometheus.MustNewConstMetric(
+			numberOfBuckets,
+			prometheus.GaugeValue,
+			float64(count),
+			permutation.Project,
+			permutation.Location,
+			permutation.StorageClass,
+		)
+	}
+
+	for permutation, metrics := range bc.buckets {
+		for metricType, value := range metrics {
+			ch <- prometheus.MustNewConstMetric(
+				bucketMetricDescs[metricType],
+				prometheus.GaugeValue,
+				value,
+				permutation.Project,
+				permutation.Location,
+				permutation.Bucket,
+			)
+		}
+	}
+
+	for permutation, status := range bc.publicAccessPrevention {
+		ch <- prometheus.MustNewConstMetric(
+			bucketPublicAccessPrevention,
+			prometheus.GaugeValue,
+			1,
+			permutation.Project,
+			permutation.Location,
+			permutation.Bucket,
+			status,
+		)
+	}
+}
+
+var newBucketsCounter = func() bucketsCounterInterface {
+	return &bucketsCounter{
+		count:                  make(map[bucketsPermutation]int),
+		buckets:                make(map[bucketPermutation]map[bucketMetricType]float64),
+		publicAccessPrevention: make(map[bucketPermutation]string),
+	}
+}
+
+type BucketsCollector struct {
+	*compute.Common
+
+	service services.StorageServiceInterface
+	buckets bucketsCounterInterface
+
+	initialized    bool
+	initalizedLock sync.RWMutex
+}
+
+func (c *BucketsCollector) GetName() string {
+	return BucketsCollectorName
+}
+
+func (c *BucketsCollector) GetData(ctx context.Context) error {
+	if !c.isInitialized() {
+		return fmt.Errorf("storage collector not initialized")
+	}
+
+	if c.service == nil {
+		return fmt.Errorf("storage collector storage.Service is not initialized")
+	}
+
+	count := newBucketsCounter()
+	for _, project := range c.GetProjects() {
+		logrus.WithField("project", project).Debugf("Requesting buckets")
+
+		buckets, err := c.service.ListBuckets(ctx, project, compute.PerPage)
+		if err != nil {
+			return fmt.Errorf("error while requesting buckets data: %v", err)
+		}
+
+		logrus.WithField("count", len(buckets)).Debugln("Found buckets")
+
+		count.Add(project, buckets)
+	}
+
+	c.buckets = count
+
+	return nil
+}
+
+func (c *BucketsCollector) isInitialized() bool {
+	c.initalizedLock.RLock()
+	defer c.initalizedLock.RUnlock()
+
+	return c.initialized
+}
+
+func (c *BucketsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- numberOfBuckets
+	ch <- bucketVersioning
+	ch <- bucketRetentionPolicy
+	ch <- bucketRetentionPolicyLocked
+	ch <- bucketUniformAccess
+	ch <- bucketPublicAccessPrevention
+	ch <- bucketLifecycleRules
+}
+
+func (c *BucketsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.buckets.Collect(ch)
+}
+
+func (c *BucketsCollector) Configuration() map[string]string {
+	return map[string]string{
+		"projects": strings.Join(c.GetProjects(), ","),
+	}
+}
+
+func (c *BucketsCollector) Targets() int {
+	return len(c.GetProjects())
+}
+
+func (c *BucketsCollector) RequiredPermissions() []string {
+	return []string{"storage.buckets.list"}
+}
+
+func (c *BucketsCollector) Init(client *http.Client) error {
+	var err error
+
+	c.service, err = services.NewStorageService(client)
+	if err != nil {
+		return fmt.Errorf("error while initializing storageService: %v", err)
+	}
+
+	logrus.WithFields(logrus.Fields{
+		"projects": strings.Join(c.GetProjects(), ","),
+	}).Info("Registered collector")
+
+	c.initalizedLock.Lock()
+	defer c.initalizedLock.Unlock()
+
+	c.initialized = true
+
+	return nil
+}
+
+func NewBucketsCollector(c *compute.Common) *BucketsCollector {
+	return &BucketsCollector{
+		Common:      c,
+		buckets:     newBucketsCounter(),
+		initialized: false,
+	}
+}
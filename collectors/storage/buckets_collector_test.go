@@ -0,0 +1,181 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"google.golang.org/api/storage/v1"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+)
+
+func TestBucketsCounter_Add(t *testing.T) {
+	bucket1 := &storage.Bucket{
+		Name:            "bucket-1",
+		Location:        "US-EAST1",
+		StorageClass:    "STANDARD",
+		Versioning:      &storage.BucketVersioning{Enabled: true},
+		RetentionPolicy: &storage.BucketRetentionPolicy{RetentionPeriod: 86400, IsLocked: true},
+		IamConfiguration: &storage.BucketIamConfiguration{
+			PublicAccessPrevention:   "enforced",
+			UniformBucketLevelAccess: &storage.BucketIamConfigurationUniformBucketLevelAccess{Enabled: true},
+		},
+		Lifecycle: &storage.BucketLifecycle{
+			Rule: []*storage.BucketLifecycleRule{{}, {}},
+		},
+	}
+	bucket2 := &storage.Bucket{
+		Name:         "bucket-2",
+		Location:     "US-EAST1",
+		StorageClass: "STANDARD",
+	}
+
+	c := newBucketsCounter().(*bucketsCounter)
+	c.Add("project", []*storage.Bucket{bucket1, bucket2})
+
+	assert.Len(t, c.count, 1)
+	assert.Equal(t, 2, c.count[bucketsPermutation{Project: "project", Location: "US-EAST1", StorageClass: "STANDARD"}])
+
+	p1 := bucketPermutation{Project: "project", Location: "US-EAST1", Bucket: "bucket-1"}
+	p2 := bucketPermutation{Project: "project", Location: "US-EAST1", Bucket: "bucket-2"}
+
+	assert.Equal(t, map[bucketMetricType]float64{
+		BucketMetricTypeVersioning:            1,
+		BucketMetricTypeRetentionPolicy:       1,
+		BucketMetricTypeRetentionPolicyLocked: 1,
+		BucketMetricTypeUniformAccess:         1,
+		BucketMetricTypeLifecycleRules:        2,
+	}, c.buckets[p1])
+
+	assert.Equal(t, map[bucketMetricType]float64{
+		BucketMetricTypeVersioning:            0,
+		BucketMetricTypeRetentionPolicy:       0,
+		BucketMetricTypeRetentionPolicyLocked: 0,
+		BucketMetricTypeUniformAccess:         0,
+		BucketMetricTypeLifecycleRules:        0,
+	}, c.buckets[p2])
+
+	assert.Equal(t, map[bucketPermutation]string{p1: "enforced"}, c.publicAccessPrevention)
+}
+
+func TestBucketsCounter_Collect(t *testing.T) {
+	ch := make(chan prometheus.Metric, 50)
+	defer close(ch)
+
+	p := bucketPermutation{Project: "project", Location: "US-EAST1", Bucket: "bucket-1"}
+
+	c := newBucketsCounter().(*bucketsCounter)
+	c.count[bucketsPermutation{Project: "project", Location: "US-EAST1", StorageClass: "STANDARD"}] = 1
+	c.buckets[p] = map[bucketMetricType]float64{
+		BucketMetricTypeVersioning:     1,
+		BucketMetricTypeLifecycleRules: 3,
+	}
+	c.publicAccessPrevention[p] = "inherited"
+
+	c.Collect(ch)
+
+	assert.Len(t, ch, 4)
+}
+
+func TestBucketsCollector_GetName(t *testing.T) {
+	collector := NewBucketsCollector(&compute.Common{})
+	assert.Equal(t, "storage-collector", collector.GetName())
+}
+
+func TestBucketsCollector_Init(t *testing.T) {
+	collector := NewBucketsCollector(&compute.Common{})
+	err := collector.Init(http.DefaultClient)
+
+	assert.NoError(t, err)
+}
+
+func TestBucketsCollector_GetData_withoutInitialize(t *testing.T) {
+	collector := NewBucketsCollector(&compute.Common{})
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "storage collector not initialized")
+}
+
+func TestBucketsCollector_GetData(t *testing.T) {
+	p1 := "fake-project-1"
+	p2 := "fake-project-2"
+
+	collector := NewBucketsCollector(&compute.Common{Projects: []string{p1, p2}})
+
+	list1 := []*storage.Bucket{{Name: "bucket-1"}}
+	list2 := make([]*storage.Bucket, 0)
+
+	service := &services.MockStorageServiceInterface{}
+	service.On("ListBuckets", mock.Anything, p1, int64(compute.PerPage)).Return(list1, nil).Once()
+	service.On("ListBuckets", mock.Anything, p2, int64(compute.PerPage)).Return(list2, nil).Once()
+	collector.service = service
+
+	ct := &mockBucketsCounterInterface{}
+	ct.On("Add", p1, list1).Once()
+	ct.On("Add", p2, list2).Once()
+
+	newBucketsCounter = func() bucketsCounterInterface {
+		return ct
+	}
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.NoError(t, err)
+	service.AssertExpectations(t)
+	ct.AssertExpectations(t)
+}
+
+func TestBucketsCollector_GetData_ListBucketsError(t *testing.T) {
+	collector := NewBucketsCollector(&compute.Common{Projects: []string{"fake-project-1"}})
+
+	service := &services.MockStorageServiceInterface{}
+	service.On("ListBuckets", mock.Anything, "fake-project-1", mock.Anything).Return(nil, fmt.Errorf("fake-list-error")).Once()
+	collector.service = service
+
+	collector.initialized = true
+
+	err := collector.GetData(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error while requesting buckets data: fake-list-error")
+	service.AssertExpectations(t)
+}
+
+func TestBucketsCollector_Describe(t *testing.T) {
+	ch := make(chan<- *prometheus.Desc, 50)
+	defer close(ch)
+
+	collector := NewBucketsCollector(&compute.Common{})
+	collector.Describe(ch)
+
+	assert.Len(t, ch, 7)
+}
+
+func TestBucketsCollector_Collect(t *testing.T) {
+	ch := make(chan<- prometheus.Metric, 50)
+	defer close(ch)
+
+	ct := &mockBucketsCounterInterface{}
+	ct.On("Collect", ch).Once()
+
+	newBucketsCounter = func() bucketsCounterInterface {
+		return ct
+	}
+
+	collector := NewBucketsCollector(&compute.Common{})
+	collector.Collect(ch)
+
+	ct.AssertExpectations(t)
+}
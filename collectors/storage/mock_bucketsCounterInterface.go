@@ -0,0 +1,26 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package storage
+
+import (
+	prometheus "github.com/prometheus/client_golang/prometheus"
+	mock "github.com/stretchr/testify/mock"
+	storage "google.golang.org/api/storage/v1"
+)
+
+// mockBucketsCounterInterface is an autogenerated mock type for the bucketsCounterInterface type
+type mockBucketsCounterInterface struct {
+	mock.Mock
+}
+
+// Add provides a mock function with given fields: _a0, _a1
+func (_m *mockBucketsCounterInterface) Add(_a0 string, _a1 []*storage.Bucket) {
+	_m.Called(_a0, _a1)
+}
+
+// Collect provides a mock function with given fields: _a0
+func (_m *mockBucketsCounterInterface) Collect(_a0 chan<- prometheus.Metric) {
+	_m.Called(_a0)
+}
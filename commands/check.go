@@ -0,0 +1,219 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/urfave/cli"
+	"golang.org/x/oauth2"
+
+	google_client "gitlab.com/gitlab-org/ci-cd/gcp-exporter/client"
+	google_services "gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors"
+	col "gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/collector"
+)
+
+const (
+	checkStatusOK      = "OK"
+	checkStatusFailed  = "FAILED"
+	checkStatusSkipped = "SKIPPED"
+)
+
+type checkResult struct {
+	Check   string
+	Project string
+	Status  string
+	Details string
+}
+
+func (cr checkResult) failed() bool {
+	return cr.Status == checkStatusFailed
+}
+
+var newResourceManagerService = func(client *http.Client) (google_services.ResourceManagerServiceInterface, error) {
+	return google_services.NewResourceManagerService(client)
+}
+
+type CheckCommand struct {
+	ServiceAccountFile string `long:"service-account-file" env:"GCP_EXPORTER_SERVICE_ACCOUNT_FILE" description:"Path to GCP Service Account JSON file"`
+
+	client *http.Client
+}
+
+func (cc *CheckCommand) Execute(cliCtx *cli.Context) {
+	results := cc.run(context.Background(), cliCtx)
+
+	writeCheckSummary(os.Stdout, results)
+
+	failed := 0
+	for _, result := range results {
+		if result.failed() {
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		logrus.Fatalf("%d of %d checks failed", failed, len(results))
+	}
+}
+
+func (cc *CheckCommand) run(ctx context.Context, cliCtx *cli.Context) []checkResult {
+	var err error
+	var tokenSource oauth2.TokenSource
+
+	cc.client, tokenSource, err = google_client.NewWithTokenSource(cliCtx.String("service-account-file"))
+	if err != nil {
+		return []checkResult{{Check: "credentials", Status: checkStatusFailed, Details: err.Error()}}
+	}
+
+	results := []checkResult{{Check: "credentials", Status: checkStatusOK}}
+
+	_, err = tokenSource.Token()
+	if err != nil {
+		return append(results, checkResult{Check: "token", Status: checkStatusFailed, Details: err.Error()})
+	}
+	results = append(results, checkResult{Check: "token", Status: checkStatusOK})
+
+	provider := collectors.NewProvider(cc.client)
+	err = provider.Init(cliCtx)
+	if err != nil {
+		return append(results, checkResult{Check: "collectors", Status: checkStatusFailed, Details: err.Error()})
+	}
+
+	if len(provider.EnabledCollectors()) < 1 {
+		return append(results, checkResult{Check: "collectors", Status: checkStatusFailed, Details: "no collectors enabled"})
+	}
+
+	rms, err := newResourceManagerService(cc.client)
+	if err != nil {
+		return append(results, checkResult{Check: "permissions", Status: checkStatusFailed, Details: err.Error()})
+	}
+
+	return append(results, checkPermissions(ctx, rms, provider.EnabledCollectors())...)
+}
+
+func checkPermissions(ctx context.Context, rms google_services.ResourceManagerServiceInterface, enabledCollectors []col.Interface) []checkResult {
+	results := make([]checkResult, 0)
+
+	for _, collector := range enabledCollectors {
+		reporter, ok := collector.(col.PermissionsReporterInterface)
+		if !ok {
+			results = append(results, checkResult{Check: collector.GetName(), Status: checkStatusSkipped, Details: "collector doesn't report required permissions"})
+			continue
+		}
+
+		required := reporter.RequiredPermissions()
+
+		scopedReporter, ok := collector.(col.ScopedPermissionsReporterInterface)
+		if ok {
+			results = append(results, checkScopesPermissions(ctx, rms, collector.GetName(), scopedReporter.GetScopes(), required)...)
+			continue
+		}
+
+		if len(reporter.GetProjects()) < 1 {
+			results = append(results, checkResult{Check: collector.GetName(), Status: checkStatusFailed, Details: "no projects configured"})
+			continue
+		}
+
+		for _, project := range reporter.GetProjects() {
+			granted, err := rms.TestIamPermissions(ctx, project, required)
+			results = append(results, newPermissionsCheckResult(collector.GetName(), project, required, granted, err))
+		}
+	}
+
+	return results
+}
+
+func checkScopesPermissions(ctx context.Context, rms google_services.ResourceManagerServiceInterface, name string, scopes []string, required []string) []checkResult {
+	if len(scopes) < 1 {
+		return []checkResult{{Check: name, Status: checkStatusFailed, Details: "no scopes configured"}}
+	}
+
+	results := make([]checkResult, 0)
+	for _, scope := range scopes {
+		granted, err := testScopePermissions(ctx, rms, scope, required)
+		results = append(results, newPermissionsCheckResult(name, scope, required, granted, err))
+	}
+
+	return results
+}
+
+// testScopePermissions uses the testIamPermissions method of the resource
+// type of the scope; projects/ prefix is optional for projects
+func testScopePermissions(ctx context.Context, rms google_services.ResourceManagerServiceInterface, scope string, required []string) ([]string, error) {
+	switch {
+	case strings.HasPrefix(scope, "folders/"):
+		return rms.TestFolderIamPermissions(ctx, scope, required)
+	case strings.HasPrefix(scope, "organizations/"):
+		return rms.TestOrganizationIamPermissions(ctx, scope, required)
+	}
+
+	return rms.TestIamPermissions(ctx, strings.TrimPrefix(scope, "projects/"), required)
+}
+
+func newPermissionsCheckResult(name string, target string, required []string, granted []string, err error) checkResult {
+	result := checkResult{Check: name, Project: target}
+
+	if err != nil {
+		result.Status = checkStatusFailed
+		result.Details = err.Error()
+
+		return result
+	}
+
+	missing := missingPermissions(required, granted)
+	if len(missing) > 0 {
+		result.Status = checkStatusFailed
+		result.Details = fmt.Sprintf("missing permissions: %s", strings.Join(missing, ", "))
+	} else {
+		result.Status = checkStatusOK
+	}
+
+	return result
+}
+
+func missingPermissions(required []string, granted []string) []string {
+	grantedMap := make(map[string]bool, len(granted))
+	for _, permission := range granted {
+		grantedMap[permission] = true
+	}
+
+	missing := make([]string, 0)
+	for _, permission := range required {
+		if !grantedMap[permission] {
+			missing = append(missing, permission)
+		}
+	}
+
+	return missing
+}
+
+func writeCheckSummary(w io.Writer, results []checkResult) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "CHECK\tPROJECT\tSTATUS\tDETAILS")
+
+	for _, result := range results {
+		project := result.Project
+		if project == "" {
+			project = "-"
+		}
+
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", result.Check, project, result.Status, result.Details)
+	}
+
+	tw.Flush()
+}
+
+func NewCheckCommand() cli.Command {
+	cmd := &CheckCommand{
+		ServiceAccountFile: collectors.DefaultServiceAccountFile,
+	}
+
+	return PrepareCommand("check", "Validate credentials and IAM permissions required by enabled collectors", cmd, collectors.Collectors.Flags()...)
+}
@@ -0,0 +1,152 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	google_services "gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/billing"
+	col "gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/collector"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors/compute"
+)
+
+type fakePermissionsCollector struct {
+	*col.MockInterface
+	*col.MockPermissionsReporterInterface
+}
+
+func newFakePermissionsCollector(name string, projects []string, permissions []string) *fakePermissionsCollector {
+	c := &fakePermissionsCollector{
+		MockInterface:                    &col.MockInterface{},
+		MockPermissionsReporterInterface: &col.MockPermissionsReporterInterface{},
+	}
+
+	c.MockInterface.On("GetName").Return(name)
+	c.MockPermissionsReporterInterface.On("GetProjects").Return(projects)
+	c.MockPermissionsReporterInterface.On("RequiredPermissions").Return(permissions)
+
+	return c
+}
+
+func TestCheckPermissions(t *testing.T) {
+	ctx := context.Background()
+	permissions := []string{"compute.instances.list", "compute.zones.get"}
+
+	rms := &google_services.MockResourceManagerServiceInterface{}
+	rms.On("TestIamPermissions", ctx, "project-1", permissions).Return(permissions, nil).Once()
+	rms.On("TestIamPermissions", ctx, "project-2", permissions).Return([]string{"compute.zones.get"}, nil).Once()
+	rms.On("TestIamPermissions", ctx, "project-3", permissions).Return(nil, errors.New("fake-error")).Once()
+	defer rms.AssertExpectations(t)
+
+	withoutPermissions := &col.MockInterface{}
+	withoutPermissions.On("GetName").Return("fake-collector-without-permissions")
+
+	enabledCollectors := []col.Interface{
+		newFakePermissionsCollector("fake-collector", []string{"project-1", "project-2", "project-3"}, permissions),
+		newFakePermissionsCollector("fake-collector-without-projects", []string{}, permissions),
+		withoutPermissions,
+	}
+
+	results := checkPermissions(ctx, rms, enabledCollectors)
+
+	expectedResults := []checkResult{
+		{Check: "fake-collector", Project: "project-1", Status: checkStatusOK},
+		{Check: "fake-collector", Project: "project-2", Status: checkStatusFailed, Details: "missing permissions: compute.instances.list"},
+		{Check: "fake-collector", Project: "project-3", Status: checkStatusFailed, Details: "fake-error"},
+		{Check: "fake-collector-without-projects", Status: checkStatusFailed, Details: "no projects configured"},
+		{Check: "fake-collector-without-permissions", Status: checkStatusSkipped, Details: "collector doesn't report required permissions"},
+	}
+
+	assert.Equal(t, expectedResults, results)
+	rms.AssertNotCalled(t, "TestIamPermissions", mock.Anything, "", mock.Anything)
+}
+
+type fakeScopedPermissionsCollector struct {
+	*col.MockInterface
+	*col.MockScopedPermissionsReporterInterface
+}
+
+func TestCheckPermissions_scopes(t *testing.T) {
+	ctx := context.Background()
+	permissions := []string{"cloudasset.assets.searchAllResources"}
+
+	rms := &google_services.MockResourceManagerServiceInterface{}
+	rms.On("TestIamPermissions", ctx, "project-1", permissions).Return(permissions, nil).Once()
+	rms.On("TestFolderIamPermissions", ctx, "folders/1", permissions).Return([]string{}, nil).Once()
+	rms.On("TestOrganizationIamPermissions", ctx, "organizations/2", permissions).Return(permissions, nil).Once()
+	defer rms.AssertExpectations(t)
+
+	collector := &fakeScopedPermissionsCollector{
+		MockInterface:                          &col.MockInterface{},
+		MockScopedPermissionsReporterInterface: &col.MockScopedPermissionsReporterInterface{},
+	}
+	collector.MockInterface.On("GetName").Return("fake-scoped-collector")
+	collector.MockScopedPermissionsReporterInterface.On("GetScopes").Return([]string{"projects/project-1", "folders/1", "organizations/2"})
+	collector.MockScopedPermissionsReporterInterface.On("RequiredPermissions").Return(permissions)
+
+	withoutScopes := &fakeScopedPermissionsCollector{
+		MockInterface:                          &col.MockInterface{},
+		MockScopedPermissionsReporterInterface: &col.MockScopedPermissionsReporterInterface{},
+	}
+	withoutScopes.MockInterface.On("GetName").Return("fake-collector-without-scopes")
+	withoutScopes.MockScopedPermissionsReporterInterface.On("GetScopes").Return([]string{})
+	withoutScopes.MockScopedPermissionsReporterInterface.On("RequiredPermissions").Return(permissions)
+
+	results := checkPermissions(ctx, rms, []col.Interface{collector, withoutScopes})
+
+	expectedResults := []checkResult{
+		{Check: "fake-scoped-collector", Project: "projects/project-1", Status: checkStatusOK},
+		{Check: "fake-scoped-collector", Project: "folders/1", Status: checkStatusFailed, Details: "missing permissions: cloudasset.assets.searchAllResources"},
+		{Check: "fake-scoped-collector", Project: "organizations/2", Status: checkStatusOK},
+		{Check: "fake-collector-without-scopes", Status: checkStatusFailed, Details: "no scopes configured"},
+	}
+
+	assert.Equal(t, expectedResults, results)
+	collector.MockScopedPermissionsReporterInterface.AssertNotCalled(t, "GetProjects")
+}
+
+func TestCheckPermissions_billingProject(t *testing.T) {
+	ctx := context.Background()
+	permissions := []string{"bigquery.jobs.create", "bigquery.tables.getData"}
+
+	collector := billing.NewCostCollector(&compute.Common{Projects: []string{"project-1", "project-2"}})
+	collector.Project = "billing-project"
+	collector.Table = "billing-project.billing.gcp_billing_export_v1"
+	require.NoError(t, collector.Init(http.DefaultClient))
+
+	rms := &google_services.MockResourceManagerServiceInterface{}
+	rms.On("TestIamPermissions", ctx, "billing-project", permissions).Return(permissions, nil).Once()
+	defer rms.AssertExpectations(t)
+
+	results := checkPermissions(ctx, rms, []col.Interface{collector})
+
+	expectedResults := []checkResult{
+		{Check: "billing-collector", Project: "billing-project", Status: checkStatusOK},
+	}
+
+	assert.Equal(t, expectedResults, results)
+}
+
+func TestWriteCheckSummary(t *testing.T) {
+	results := []checkResult{
+		{Check: "token", Status: checkStatusOK},
+		{Check: "instances-collector", Project: "project-1", Status: checkStatusFailed, Details: "missing permissions: compute.instances.list"},
+	}
+
+	buf := new(bytes.Buffer)
+	writeCheckSummary(buf, results)
+
+	expected := `CHECK                PROJECT    STATUS  DETAILS
+token                -          OK      
+instances-collector  project-1  FAILED  missing permissions: compute.instances.list
+`
+
+	assert.Equal(t, expected, buf.String())
+}
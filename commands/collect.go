@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/urfave/cli"
+
+	google_client "gitlab.com/gitlab-org/ci-cd/gcp-exporter/client"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors"
+)
+
+const (
+	CollectFormatText        = "text"
+	CollectFormatOpenMetrics = "openmetrics"
+	CollectFormatJSON        = "json"
+	CollectFormatTable       = "table"
+)
+
+var collectOutputWriters = map[string]func(w io.Writer, registry prometheus.Gatherer) error{
+	CollectFormatText:        writeText,
+	CollectFormatOpenMetrics: writeOpenMetrics,
+	CollectFormatJSON:        writeJSON,
+	CollectFormatTable:       writeTable,
+}
+
+type CollectCommand struct {
+	ServiceAccountFile string `long:"service-account-file" env:"GCP_EXPORTER_SERVICE_ACCOUNT_FILE" description:"Path to GCP Service Account JSON file"`
+	Format             string `long:"format" env:"GCP_EXPORTER_COLLECT_FORMAT" description:"Output format; one of: text, openmetrics, json, table"`
+}
+
+func (cc *CollectCommand) Execute(cliCtx *cli.Context) {
+	format := cliCtx.String("format")
+
+	writeOutput, ok := collectOutputWriters[format]
+	if !ok {
+		logrus.Fatalf("unknown output format %q", format)
+	}
+
+	client, err := google_client.New(cliCtx.String("service-account-file"))
+	if err != nil {
+		logrus.WithError(err).Fatalln("failed to create HTTP client")
+	}
+
+	provider := collectors.NewProvider(client)
+	err = provider.Init(cliCtx)
+	if err != nil {
+		logrus.WithError(err).Fatalln("failed to initialize collectors")
+	}
+
+	provider.GetData(context.Background())
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(provider)
+
+	err = writeOutput(os.Stdout, registry)
+	if err != nil {
+		logrus.WithError(err).Fatalln("error while writing metrics")
+	}
+
+	failed := failedCollectors(provider.Status())
+	if len(failed) > 0 {
+		logrus.Fatalf("data gathering failed for collectors: %s", strings.Join(failed, "; "))
+	}
+}
+
+func failedCollectors(statuses []collectors.CollectorStatus) []string {
+	failed := make([]string, 0)
+	for _, status := range statuses {
+		if status.LastError != "" {
+			failed = append(failed, fmt.Sprintf("%s: %s", status.Name, status.LastError))
+		}
+	}
+
+	return failed
+}
+
+func NewCollectCommand() cli.Command {
+	cmd := &CollectCommand{
+		ServiceAccountFile: collectors.DefaultServiceAccountFile,
+		Format:             CollectFormatText,
+	}
+
+	return PrepareCommand("collect", "Gather data from GCP once, print metrics and exit", cmd, collectors.Collectors.Flags()...)
+}
@@ -0,0 +1,132 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/services"
+)
+
+type jsonMetricFamily struct {
+	Name    string            `json:"name"`
+	Help    string            `json:"help"`
+	Type    string            `json:"type"`
+	Samples []services.Sample `json:"samples"`
+}
+
+func writeText(w io.Writer, gatherer prometheus.Gatherer) error {
+	families, err := gatherer.Gather()
+	if err != nil {
+		return err
+	}
+
+	enc := expfmt.NewEncoder(w, expfmt.FmtText)
+	for _, family := range families {
+		err = enc.Encode(family)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeOpenMetrics(w io.Writer, gatherer prometheus.Gatherer) error {
+	families, err := gatherer.Gather()
+	if err != nil {
+		return err
+	}
+
+	for _, family := range families {
+		_, err = expfmt.MetricFamilyToOpenMetrics(w, family)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = expfmt.FinalizeOpenMetrics(w)
+
+	return err
+}
+
+func openMetricsType(metricType dto.MetricType) string {
+	switch metricType {
+	case dto.MetricType_COUNTER:
+		return "counter"
+	case dto.MetricType_GAUGE:
+		return "gauge"
+	case dto.MetricType_HISTOGRAM:
+		return "histogram"
+	case dto.MetricType_SUMMARY:
+		return "summary"
+	}
+
+	return "unknown"
+}
+
+func writeJSON(w io.Writer, gatherer prometheus.Gatherer) error {
+	families, err := gatherer.Gather()
+	if err != nil {
+		return err
+	}
+
+	output := make([]jsonMetricFamily, 0, len(families))
+	for _, family := range families {
+		output = append(output, jsonMetricFamily{
+			Name:    family.GetName(),
+			Help:    family.GetHelp(),
+			Type:    openMetricsType(family.GetType()),
+			Samples: services.FlattenMetricFamily(family),
+		})
+	}
+
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, string(data))
+
+	return err
+}
+
+func writeTable(w io.Writer, gatherer prometheus.Gatherer) error {
+	families, err := gatherer.Gather()
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "METRIC\tLABELS\tVALUE")
+
+	for _, family := range families {
+		for _, s := range services.FlattenMetricFamily(family) {
+			labels := make([]string, 0, len(s.Labels))
+			for _, name := range sortedLabelNames(s.Labels) {
+				labels = append(labels, fmt.Sprintf("%s=%s", name, s.Labels[name]))
+			}
+
+			fmt.Fprintf(tw, "%s\t%s\t%s\n", s.Name, strings.Join(labels, " "), services.FormatSampleValue(s.Value))
+		}
+	}
+
+	return tw.Flush()
+}
+
+func sortedLabelNames(labels map[string]string) []string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors"
+)
+
+func newTestRegistry(t *testing.T) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+
+	instances := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test_instances", Help: "Test \"instances\""}, []string{"project", "zone"})
+	instances.WithLabelValues("project-1", "us-east1-c").Set(3)
+	require.NoError(t, registry.Register(instances))
+
+	requests := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_requests_total", Help: "Test requests"})
+	requests.Add(7)
+	require.NoError(t, registry.Register(requests))
+
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_duration_seconds", Help: "Test histogram", Buckets: []float64{1}})
+	histogram.Observe(0.5)
+	require.NoError(t, registry.Register(histogram))
+
+	return registry
+}
+
+func TestWriteText(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, writeText(buf, newTestRegistry(t)))
+
+	assert.Contains(t, buf.String(), "# TYPE test_requests_total counter\ntest_requests_total 7\n")
+	assert.Contains(t, buf.String(), `test_instances{project="project-1",zone="us-east1-c"} 3`)
+}
+
+func TestWriteOpenMetrics(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, writeOpenMetrics(buf, newTestRegistry(t)))
+
+	expected := `# HELP test_duration_seconds Test histogram
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{le="1.0"} 1
+test_duration_seconds_bucket{le="+Inf"} 1
+test_duration_seconds_sum 0.5
+test_duration_seconds_count 1
+# HELP test_instances Test \"instances\"
+# TYPE test_instances gauge
+test_instances{project="project-1",zone="us-east1-c"} 3.0
+# HELP test_requests Test requests
+# TYPE test_requests counter
+test_requests_total 7.0
+# EOF
+`
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, writeJSON(buf, newTestRegistry(t)))
+
+	var families []jsonMetricFamily
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &families))
+	require.Len(t, families, 3)
+
+	assert.Equal(t, "test_instances", families[1].Name)
+	assert.Equal(t, "gauge", families[1].Type)
+	require.Len(t, families[1].Samples, 1)
+	assert.Equal(t, map[string]string{"project": "project-1", "zone": "us-east1-c"}, families[1].Samples[0].Labels)
+	assert.Equal(t, 3.0, families[1].Samples[0].Value)
+}
+
+func TestWriteTable(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, writeTable(buf, newTestRegistry(t)))
+
+	expected := `METRIC                        LABELS                             VALUE
+test_duration_seconds_bucket  le=1                               1
+test_duration_seconds_bucket  le=+Inf                            1
+test_duration_seconds_sum                                        0.5
+test_duration_seconds_count                                      1
+test_instances                project=project-1 zone=us-east1-c  3
+test_requests_total                                              7
+`
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestFailedCollectors(t *testing.T) {
+	statuses := []collectors.CollectorStatus{
+		{Name: "instances"},
+		{Name: "regions", LastError: "fake-error"},
+	}
+
+	assert.Equal(t, []string{"regions: fake-error"}, failedCollectors(statuses))
+}
@@ -2,55 +2,205 @@ package commands
 
 import (
 	"bytes"
+	"context"
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
 	"text/template"
+	"time"
 
 	"github.com/Sirupsen/logrus"
 	"github.com/urfave/cli"
+	"golang.org/x/oauth2"
 
 	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client"
 	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors"
 )
 
+const (
+	GetTokenOutputText = "text"
+	GetTokenOutputJSON = "json"
+	GetTokenOutputEnv  = "env"
+	GetTokenOutputRaw  = "raw"
+)
+
 var (
 	getTokenOutputTpl = `
       Token: {{.AccessToken}}
        Type: {{.TokenType}}
  Expires at: {{.Expiry}}
+{{- with .Info}}
+
+   Identity: {{.Email}}
+{{- if .Audience}}
+   Audience: {{.Audience}}
+{{- end}}
+{{- if .Scopes}}
+     Scopes: {{join .Scopes ", "}}
+{{- end}}
+ Expires in: {{.ExpiresIn}}s
+{{- end}}
 
  ----
  example usage: curl -s -X GET \
                      -H "Authorization: {{.TokenType}} {{.AccessToken}}" \
                      https://www.googleapis.com/compute/v1/projects/[PROJECT]/zones/[ZONE]/instances
 `
+
+	getTokenOutputWriters = map[string]func(w io.Writer, output *getTokenOutput) error{
+		GetTokenOutputText: writeGetTokenText,
+		GetTokenOutputJSON: writeGetTokenJSON,
+		GetTokenOutputEnv:  writeGetTokenEnv,
+		GetTokenOutputRaw:  writeGetTokenRaw,
+	}
 )
 
+type getTokenOutput struct {
+	AccessToken string            `json:"access_token,omitempty"`
+	IDToken     string            `json:"id_token,omitempty"`
+	TokenType   string            `json:"token_type"`
+	Expiry      time.Time         `json:"expiry"`
+	Info        *client.TokenInfo `json:"tokeninfo,omitempty"`
+}
+
+func newGetTokenOutput(token *oauth2.Token) *getTokenOutput {
+	output := &getTokenOutput{
+		AccessToken: token.AccessToken,
+		TokenType:   token.TokenType,
+		Expiry:      token.Expiry,
+	}
+
+	if idToken, ok := token.Extra("id_token").(string); ok && idToken != "" {
+		output.AccessToken = ""
+		output.IDToken = idToken
+	}
+
+	return output
+}
+
 type GetTokenCommand struct {
-	ServiceAccountFile string `long:"service-account-file" env:"GCP_EXPORTER_SERVICE_ACCOUNT_FILE" description:"Path to GCP Service Account JSON file"`
+	ServiceAccountFile string   `long:"service-account-file" env:"GCP_EXPORTER_SERVICE_ACCOUNT_FILE" description:"Path to GCP Service Account JSON file"`
+	Output             string   `long:"output" env:"GCP_EXPORTER_GET_TOKEN_OUTPUT" description:"Output format; one of: text, json, env, raw"`
+	Scopes             []string `long:"scope" description:"Request the token for selected scope instead of the default ones; may be used multiple times"`
+	Audience           string   `long:"audience" description:"Request an ID token for selected audience instead of an access token"`
+	TokenInfo          bool     `long:"tokeninfo" description:"Show the identity, scopes and remaining lifetime of the token using the tokeninfo endpoint"`
 }
 
 func (gtc *GetTokenCommand) Execute(*cli.Context) {
-	ts := client.NewGCPServiceAccountTokenSource(gtc.ServiceAccountFile)
+	writeOutput, ok := getTokenOutputWriters[gtc.Output]
+	if !ok {
+		logrus.Fatalf("unknown output format %q", gtc.Output)
+	}
+
+	options := client.TokenOptions{
+		Scopes:   gtc.Scopes,
+		Audience: gtc.Audience,
+	}
+	ts := client.NewGCPServiceAccountTokenSourceWithOptions(gtc.ServiceAccountFile, options)
 
 	token, err := ts.Token()
 	if err != nil {
 		logrus.WithError(err).Fatalln("error while requesting new token")
 	}
 
-	tpl, err := template.New("output").Parse(getTokenOutputTpl)
+	output := newGetTokenOutput(token)
+
+	if gtc.TokenInfo {
+		output.Info, err = client.GetTokenInfo(context.Background(), http.DefaultClient, token)
+		if err != nil {
+			logrus.WithError(err).Fatalln("error while requesting token info")
+		}
+	}
+
+	err = writeOutput(os.Stdout, output)
+	if err != nil {
+		logrus.WithError(err).Fatalln("error while writing output")
+	}
+}
+
+func writeGetTokenText(w io.Writer, output *getTokenOutput) error {
+	tpl, err := template.New("output").Funcs(template.FuncMap{"join": strings.Join}).Parse(getTokenOutputTpl)
 	if err != nil {
-		logrus.WithError(err).Fatalln("error while parsing template")
+		return fmt.Errorf("error while parsing template: %v", err)
+	}
+
+	data := *output
+	if data.IDToken != "" {
+		data.AccessToken = data.IDToken
 	}
 
 	buff := bytes.NewBufferString("")
-	tpl.Execute(buff, token)
+	err = tpl.Execute(buff, data)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, buff.String())
+
+	return err
+}
+
+func writeGetTokenJSON(w io.Writer, output *getTokenOutput) error {
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(w, string(data))
+
+	return err
+}
+
+func writeGetTokenEnv(w io.Writer, output *getTokenOutput) error {
+	variables := make([][2]string, 0)
+	if output.IDToken != "" {
+		variables = append(variables, [2]string{"GCP_ID_TOKEN", output.IDToken})
+	} else {
+		variables = append(variables, [2]string{"GCP_ACCESS_TOKEN", output.AccessToken})
+	}
+
+	variables = append(variables,
+		[2]string{"GCP_TOKEN_TYPE", output.TokenType},
+		[2]string{"GCP_TOKEN_EXPIRY", output.Expiry.UTC().Format(time.RFC3339)},
+	)
+
+	if output.Info != nil {
+		variables = append(variables,
+			[2]string{"GCP_TOKEN_EMAIL", output.Info.Email},
+			[2]string{"GCP_TOKEN_AUDIENCE", output.Info.Audience},
+			[2]string{"GCP_TOKEN_SCOPES", strings.Join(output.Info.Scopes, " ")},
+			[2]string{"GCP_TOKEN_EXPIRES_IN", fmt.Sprintf("%d", output.Info.ExpiresIn)},
+		)
+	}
+
+	for _, variable := range variables {
+		_, err := fmt.Fprintf(w, "%s='%s'\n", variable[0], strings.Replace(variable[1], "'", `'\''`, -1))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeGetTokenRaw(w io.Writer, output *getTokenOutput) error {
+	token := output.AccessToken
+	if output.IDToken != "" {
+		token = output.IDToken
+	}
+
+	_, err := fmt.Fprintln(w, token)
 
-	fmt.Println(buff.String())
+	return err
 }
 
 func NewGetTokenCommand() cli.Command {
 	cmd := &GetTokenCommand{
 		ServiceAccountFile: collectors.DefaultServiceAccountFile,
+		Output:             GetTokenOutputText,
 	}
 
 	return PrepareCommand("get-token", "Request the oAuth2 Token from GCP", cmd)
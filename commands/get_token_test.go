@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/client"
+)
+
+func newTestGetTokenOutput() *getTokenOutput {
+	token := &oauth2.Token{
+		AccessToken: "fake-token",
+		TokenType:   "Bearer",
+		Expiry:      time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	output := newGetTokenOutput(token)
+	output.Info = &client.TokenInfo{
+		Email:     "service-account@example.com",
+		Scopes:    []string{"scope-1", "scope-2"},
+		ExpiresIn: 3599,
+	}
+
+	return output
+}
+
+func TestNewGetTokenOutput_IDToken(t *testing.T) {
+	token := (&oauth2.Token{AccessToken: "fake-id-token", TokenType: "Bearer"}).WithExtra(map[string]interface{}{"id_token": "fake-id-token"})
+
+	output := newGetTokenOutput(token)
+	assert.Empty(t, output.AccessToken)
+	assert.Equal(t, "fake-id-token", output.IDToken)
+}
+
+func TestWriteGetTokenText(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, writeGetTokenText(buf, newTestGetTokenOutput()))
+
+	assert.Contains(t, buf.String(), "      Token: fake-token\n")
+	assert.Contains(t, buf.String(), "   Identity: service-account@example.com\n")
+	assert.Contains(t, buf.String(), "     Scopes: scope-1, scope-2\n")
+	assert.Contains(t, buf.String(), " Expires in: 3599s\n")
+	assert.Contains(t, buf.String(), `-H "Authorization: Bearer fake-token"`)
+}
+
+func TestWriteGetTokenJSON(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, writeGetTokenJSON(buf, newTestGetTokenOutput()))
+
+	var output map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+
+	assert.Equal(t, "fake-token", output["access_token"])
+	assert.Equal(t, "Bearer", output["token_type"])
+	assert.Equal(t, "2018-01-02T03:04:05Z", output["expiry"])
+	assert.NotContains(t, output, "id_token")
+	assert.Equal(t, "service-account@example.com", output["tokeninfo"].(map[string]interface{})["email"])
+}
+
+func TestWriteGetTokenEnv(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, writeGetTokenEnv(buf, newTestGetTokenOutput()))
+
+	expected := `GCP_ACCESS_TOKEN='fake-token'
+GCP_TOKEN_TYPE='Bearer'
+GCP_TOKEN_EXPIRY='2018-01-02T03:04:05Z'
+GCP_TOKEN_EMAIL='service-account@example.com'
+GCP_TOKEN_AUDIENCE=''
+GCP_TOKEN_SCOPES='scope-1 scope-2'
+GCP_TOKEN_EXPIRES_IN='3599'
+`
+
+	assert.Equal(t, expected, buf.String())
+}
+
+func TestWriteGetTokenRaw(t *testing.T) {
+	buf := new(bytes.Buffer)
+	require.NoError(t, writeGetTokenRaw(buf, &getTokenOutput{IDToken: "fake-id-token"}))
+
+	assert.Equal(t, "fake-id-token\n", buf.String())
+}
@@ -8,29 +8,54 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/urfave/cli"
+	"golang.org/x/oauth2"
 
 	google_client "gitlab.com/gitlab-org/ci-cd/gcp-exporter/client"
+	google_services "gitlab.com/gitlab-org/ci-cd/gcp-exporter/client/services"
 	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors"
 	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/services"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tracing"
 
 	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/version"
 )
 
 const (
 	DefaultInterval = 60
+	DefaultPushJob  = "gcp_exporter"
+
+	TracingShutdownTimeout = 5 * time.Second
 )
 
 type StartExporterServiceCommand struct {
 	ListenAddr         string `long:"listen" env:"GCP_EXPORTER_LISTEN" description:"Metrics and debug server listen address"`
 	Interval           int    `long:"interval" env:"GCP_EXPORTER_INTERVAL" description:"Number of seconds between requesting data from GCP"`
 	ServiceAccountFile string `long:"service-account-file" env:"GCP_EXPORTER_SERVICE_ACCOUNT_FILE" description:"Path to GCP Service Account JSON file"`
-
-	ctx      context.Context
-	client   *http.Client
-	provider collectors.ProviderInterface
+	WebConfigFile      string `long:"web-config-file" env:"GCP_EXPORTER_WEB_CONFIG_FILE" description:"Path to configuration file that enables TLS and authentication for the HTTP server"`
+	PprofListenAddr    string `long:"pprof-listen" env:"GCP_EXPORTER_PPROF_LISTEN" description:"Serve pprof endpoints on a separate listen address instead of the metrics server"`
+	PprofDisable       bool   `long:"pprof-disable" env:"GCP_EXPORTER_PPROF_DISABLE" description:"Disable pprof endpoints"`
+	PushgatewayURL     string `long:"push-gateway-url" env:"GCP_EXPORTER_PUSH_GATEWAY_URL" description:"Push metrics to the Pushgateway at this URL after each data refresh"`
+	PushJob            string `long:"push-job" env:"GCP_EXPORTER_PUSH_JOB" description:"Job name used when pushing metrics to the Pushgateway"`
+	RemoteWriteURL     string `long:"remote-write-url" env:"GCP_EXPORTER_REMOTE_WRITE_URL" description:"Send metrics to this Prometheus remote_write endpoint after each data refresh"`
+	OTLPEndpoint       string `long:"otlp-endpoint" env:"GCP_EXPORTER_OTLP_ENDPOINT" description:"Export metrics to this OpenTelemetry collector endpoint after each data refresh"`
+	OTLPProtocol       string `long:"otlp-protocol" env:"GCP_EXPORTER_OTLP_PROTOCOL" description:"Protocol used to export metrics with OTLP (http/protobuf or grpc)"`
+	OTLPInsecure       bool   `long:"otlp-insecure" env:"GCP_EXPORTER_OTLP_INSECURE" description:"Disable TLS when exporting metrics with OTLP"`
+	TracingEndpoint    string `long:"tracing-endpoint" env:"GCP_EXPORTER_TRACING_ENDPOINT" description:"Export traces of data refreshes and GCP API calls to this OpenTelemetry collector endpoint; tracing is disabled when empty"`
+	TracingProtocol    string `long:"tracing-protocol" env:"GCP_EXPORTER_TRACING_PROTOCOL" description:"Protocol used to export traces with OTLP (http/protobuf or grpc)"`
+	TracingInsecure    bool   `long:"tracing-insecure" env:"GCP_EXPORTER_TRACING_INSECURE" description:"Disable TLS when exporting traces with OTLP"`
+	PushMaxRetries     int    `long:"push-max-retries" env:"GCP_EXPORTER_PUSH_MAX_RETRIES" description:"Maximum number of retries of a failed metrics push"`
+	PushBufferSize     int    `long:"push-buffer-size" env:"GCP_EXPORTER_PUSH_BUFFER_SIZE" description:"Number of unsent remote_write payloads kept in the local buffer"`
+
+	ctx         context.Context
+	client      *http.Client
+	tokenSource oauth2.TokenSource
+	provider    collectors.ProviderInterface
+
+	shutdownTracing tracing.ShutdownFunc
 
 	wg *sync.WaitGroup
 }
@@ -40,6 +65,7 @@ func (sc *StartExporterServiceCommand) Execute(cliCtx *cli.Context) {
 
 	methods := []func(context *cli.Context) error{
 		sc.registerSignalHandler,
+		sc.prepareTracing,
 		sc.prepareClient,
 		sc.prepareProvider,
 		sc.startMetricsServer,
@@ -77,11 +103,35 @@ func (sc *StartExporterServiceCommand) registerSignalHandler(cliCtx *cli.Context
 	return nil
 }
 
+func (sc *StartExporterServiceCommand) prepareTracing(cliCtx *cli.Context) error {
+	endpoint := cliCtx.String("tracing-endpoint")
+	if endpoint == "" {
+		logrus.Debugln("Tracing disabled")
+		return nil
+	}
+
+	config := tracing.Config{
+		Endpoint: endpoint,
+		Protocol: cliCtx.String("tracing-protocol"),
+		Insecure: cliCtx.Bool("tracing-insecure"),
+	}
+
+	shutdown, err := tracing.Setup(sc.ctx, config)
+	if err != nil {
+		return fmt.Errorf("failed to set up tracing: %v", err)
+	}
+
+	logrus.WithFields(logrus.Fields{"endpoint": config.Endpoint, "protocol": config.Protocol}).Infoln("Exporting traces with OTLP")
+	sc.shutdownTracing = shutdown
+
+	return nil
+}
+
 func (sc *StartExporterServiceCommand) prepareClient(cliCtx *cli.Context) error {
 	var err error
 	serviceAccountFilePath := cliCtx.String("service-account-file")
 
-	sc.client, err = google_client.New(serviceAccountFilePath)
+	sc.client, sc.tokenSource, err = google_client.NewWithTokenSource(serviceAccountFilePath)
 	if err != nil {
 		return fmt.Errorf("failed to create HTTP client: %v", err)
 	}
@@ -100,9 +150,29 @@ func (sc *StartExporterServiceCommand) startMetricsServer(cliCtx *cli.Context) e
 	sc.wg.Add(1)
 
 	ms := services.NewMetricsService(sc.ctx, listenAddr, sc.wg)
+
+	webConfigFile := cliCtx.String("web-config-file")
+	if webConfigFile != "" {
+		webConfig, err := services.LoadWebConfig(webConfigFile)
+		if err != nil {
+			return err
+		}
+
+		ms.SetWebConfig(webConfig)
+	}
+
+	ms.SetPprofListenAddr(cliCtx.String("pprof-listen"))
+	if cliCtx.Bool("pprof-disable") {
+		ms.DisablePprof()
+	}
+
 	ms.RegisterDefaultCollectors()
 	ms.MustRegisterPrometheusCollector(sc.provider)
+	ms.MustRegisterPrometheusCollector(google_services.DefaultAPICaller)
 	ms.MustRegisterPrometheusCollector(version.AppVersion.VersionCollector())
+	ms.AddReadinessCheck("collectors", sc.provider.Ready)
+	ms.AddReadinessCheck("token", sc.checkToken)
+	ms.SetStatusSource(sc.provider)
 	err := ms.StartServer()
 	if err != nil {
 		return fmt.Errorf("failed to start metrics HTTP server: %v", err)
@@ -111,17 +181,85 @@ func (sc *StartExporterServiceCommand) startMetricsServer(cliCtx *cli.Context) e
 	return nil
 }
 
+func (sc *StartExporterServiceCommand) checkToken() error {
+	token, err := sc.tokenSource.Token()
+	if err != nil {
+		return err
+	}
+
+	if !token.Valid() {
+		return fmt.Errorf("token is not valid")
+	}
+
+	return nil
+}
+
 func (sc *StartExporterServiceCommand) startExporterService(cliCtx *cli.Context) error {
 	interval := cliCtx.Int("interval")
 
 	sc.wg.Add(1)
 	es := services.NewExporterService(sc.ctx, interval, sc.provider, sc.wg)
 
-	err := es.Run()
+	err := sc.addPushers(cliCtx, es)
+	if err != nil {
+		return err
+	}
+
+	err = es.Run()
 	if err != nil {
 		return fmt.Errorf("failure during exporter service execution: %v", err)
 	}
 
+	if sc.shutdownTracing != nil {
+		ctx, cancelFn := context.WithTimeout(context.Background(), TracingShutdownTimeout)
+		defer cancelFn()
+
+		err = sc.shutdownTracing(ctx)
+		if err != nil {
+			logrus.WithError(err).Warningln("Failed to flush traces")
+		}
+	}
+
+	return nil
+}
+
+func (sc *StartExporterServiceCommand) addPushers(cliCtx *cli.Context, es *services.ExporterService) error {
+	pushgatewayURL := cliCtx.String("push-gateway-url")
+	remoteWriteURL := cliCtx.String("remote-write-url")
+	otlpEndpoint := cliCtx.String("otlp-endpoint")
+
+	if pushgatewayURL == "" && remoteWriteURL == "" && otlpEndpoint == "" {
+		return nil
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(sc.provider)
+	registry.MustRegister(google_services.DefaultAPICaller)
+	registry.MustRegister(version.AppVersion.VersionCollector())
+
+	maxRetries := cliCtx.Int("push-max-retries")
+
+	if pushgatewayURL != "" {
+		logrus.WithField("url", pushgatewayURL).Infoln("Pushing metrics to Pushgateway")
+		es.AddPusher(services.NewPushgatewayPusher(pushgatewayURL, cliCtx.String("push-job"), registry, maxRetries))
+	}
+
+	if remoteWriteURL != "" {
+		logrus.WithField("url", remoteWriteURL).Infoln("Pushing metrics to remote_write endpoint")
+		es.AddPusher(services.NewRemoteWritePusher(remoteWriteURL, registry, maxRetries, cliCtx.Int("push-buffer-size")))
+	}
+
+	if otlpEndpoint != "" {
+		protocol := cliCtx.String("otlp-protocol")
+		pusher, err := services.NewOTLPPusher(otlpEndpoint, protocol, cliCtx.Bool("otlp-insecure"), registry, maxRetries, cliCtx.Int("push-buffer-size"))
+		if err != nil {
+			return fmt.Errorf("failed to create OTLP exporter: %v", err)
+		}
+
+		logrus.WithFields(logrus.Fields{"endpoint": otlpEndpoint, "protocol": protocol}).Infoln("Exporting metrics with OTLP")
+		es.AddPusher(pusher)
+	}
+
 	return nil
 }
 
@@ -129,6 +267,11 @@ func NewStartCommand() cli.Command {
 	cmd := &StartExporterServiceCommand{
 		Interval:           DefaultInterval,
 		ServiceAccountFile: collectors.DefaultServiceAccountFile,
+		PushJob:            DefaultPushJob,
+		OTLPProtocol:       services.OTLPProtocolHTTP,
+		TracingProtocol:    tracing.ProtocolHTTP,
+		PushMaxRetries:     services.DefaultPushMaxRetries,
+		PushBufferSize:     services.DefaultPushBufferSize,
 	}
 
 	return PrepareCommand("start", "Start exporter service", cmd, collectors.Collectors.Flags()...)
@@ -0,0 +1,43 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/urfave/cli"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/version"
+)
+
+const (
+	VersionFormatJSON = "json"
+	VersionFormatText = "text"
+)
+
+type VersionCommand struct {
+	Format string `long:"format" env:"GCP_EXPORTER_VERSION_FORMAT" description:"Output format; one of: json, text"`
+}
+
+func (vc *VersionCommand) Execute(*cli.Context) {
+	switch vc.Format {
+	case VersionFormatJSON:
+		output, err := version.AppVersion.JSON()
+		if err != nil {
+			logrus.WithError(err).Fatalln("error while preparing version info")
+		}
+
+		fmt.Println(output)
+	case VersionFormatText:
+		fmt.Print(version.AppVersion.Extended())
+	default:
+		logrus.Fatalf("unknown output format %q", vc.Format)
+	}
+}
+
+func NewVersionCommand() cli.Command {
+	cmd := &VersionCommand{
+		Format: VersionFormatJSON,
+	}
+
+	return PrepareCommand("version", "Print version information", cmd)
+}
@@ -94,6 +94,9 @@ func main() {
 	app.Commands = []cli.Command{
 		commands.NewStartCommand(),
 		commands.NewGetTokenCommand(),
+		commands.NewCollectCommand(),
+		commands.NewCheckCommand(),
+		commands.NewVersionCommand(),
 	}
 
 	if err := app.Run(os.Args); err != nil {
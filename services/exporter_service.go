@@ -16,16 +16,21 @@ type ExporterService struct {
 	wg       *sync.WaitGroup
 
 	collectorProvider collectors.ProviderInterface
+	pushers           []PusherInterface
+}
+
+func (es *ExporterService) AddPusher(pusher PusherInterface) {
+	es.pushers = append(es.pushers, pusher)
 }
 
 func (es *ExporterService) Run() error {
 	logrus.Infof("GCP data gathering interval: %s", es.interval)
 
-	es.collectorProvider.GetData(es.ctx)
+	es.refresh()
 	for {
 		select {
 		case <-time.After(es.interval):
-			es.collectorProvider.GetData(es.ctx)
+			es.refresh()
 		case <-es.ctx.Done():
 			es.wg.Done()
 			return nil
@@ -33,12 +38,24 @@ func (es *ExporterService) Run() error {
 	}
 }
 
+func (es *ExporterService) refresh() {
+	es.collectorProvider.GetData(es.ctx)
+
+	for _, pusher := range es.pushers {
+		err := pusher.Push(es.ctx)
+		if err != nil {
+			logrus.WithError(err).Errorln("Error while pushing metrics")
+		}
+	}
+}
+
 func NewExporterService(ctx context.Context, interval int, collectorProvider collectors.ProviderInterface, wg *sync.WaitGroup) *ExporterService {
 	es := &ExporterService{
 		ctx:               ctx,
 		interval:          time.Duration(interval) * time.Second,
 		wg:                wg,
 		collectorProvider: collectorProvider,
+		pushers:           make([]PusherInterface, 0),
 	}
 
 	return es
@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -34,3 +35,33 @@ func TestExporterService_Run(t *testing.T) {
 	assert.NoError(t, err)
 	assert.True(t, finishedAt.Sub(startetAt).Seconds() > 2, "Run operation with two GetData() calls and interval set to 1 should take at least 2 seconds")
 }
+
+func TestExporterService_Run_withPushers(t *testing.T) {
+	ctx, cancelFn := context.WithCancel(context.Background())
+
+	p := &collectors.MockProviderInterface{}
+	p.On("GetData", ctx).Once()
+	defer p.AssertExpectations(t)
+
+	failingPusher := &MockPusherInterface{}
+	failingPusher.On("Push", ctx).Return(errors.New("test-error")).Once()
+	defer failingPusher.AssertExpectations(t)
+
+	pusher := &MockPusherInterface{}
+	pusher.On("Push", ctx).Return(nil).Run(func(args mock.Arguments) {
+		cancelFn()
+	}).Once()
+	defer pusher.AssertExpectations(t)
+
+	wg := &sync.WaitGroup{}
+	wg.Add(1)
+
+	es := NewExporterService(ctx, 60, p, wg)
+	es.AddPusher(failingPusher)
+	es.AddPusher(pusher)
+
+	err := es.Run()
+
+	wg.Wait()
+	assert.NoError(t, err)
+}
@@ -2,11 +2,11 @@ package services
 
 import (
 	"context"
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
-	_ "net/http/pprof" // PPROF is loading everything in its init function
-	"os"
+	"net/http/pprof"
 	"sync"
 	"time"
 
@@ -23,6 +23,7 @@ type HTTPServerInterface interface {
 	SetContext(context.Context)
 	SetAddr(string)
 	SetHandler(http.Handler)
+	SetTLSConfig(*tls.Config)
 	ListenAndServe() error
 }
 
@@ -44,10 +45,19 @@ func (hs *HTTPServer) SetHandler(handler http.Handler) {
 	hs.s.Handler = handler
 }
 
+func (hs *HTTPServer) SetTLSConfig(config *tls.Config) {
+	hs.s.TLSConfig = config
+}
+
 func (hs *HTTPServer) ListenAndServe() error {
 	finished := make(chan error, 1)
 
 	go func() {
+		if hs.s.TLSConfig != nil {
+			finished <- hs.s.ListenAndServeTLS("", "")
+			return
+		}
+
 		finished <- hs.s.ListenAndServe()
 	}()
 
@@ -58,7 +68,7 @@ func (hs *HTTPServer) ListenAndServe() error {
 		return err
 	}
 
-	logrus.Infoln("Shutting down Metrics HTTP server")
+	logrus.WithField("address", hs.s.Addr).Infoln("Shutting down HTTP server")
 	timeoutCtx, cancelFn := context.WithTimeout(context.Background(), HTTPServerShutdownTimeout)
 	defer cancelFn()
 
@@ -77,6 +87,26 @@ type MetricsService struct {
 	listenAddr string
 	registry   PrometheusRegistryInterface
 	server     HTTPServerInterface
+
+	readinessChecks []readinessCheck
+	statusSource    StatusSourceInterface
+
+	webConfig       *WebConfig
+	pprofListenAddr string
+	pprofDisabled   bool
+	pprofServer     HTTPServerInterface
+}
+
+func (ms *MetricsService) SetWebConfig(config *WebConfig) {
+	ms.webConfig = config
+}
+
+func (ms *MetricsService) SetPprofListenAddr(addr string) {
+	ms.pprofListenAddr = addr
+}
+
+func (ms *MetricsService) DisablePprof() {
+	ms.pprofDisabled = true
 }
 
 func (ms *MetricsService) StartServer() error {
@@ -90,22 +120,39 @@ func (ms *MetricsService) StartServer() error {
 		return err
 	}
 
+	separatePprof := ms.pprofListenAddr != "" && !ms.pprofDisabled
+	if separatePprof {
+		err = ms.preparePprofServer()
+		if err != nil {
+			return err
+		}
+	}
+
+	ms.serve("Metrics", ms.server, ms.listenAddr)
+
+	if separatePprof {
+		ms.wg.Add(1)
+		ms.serve("Debug", ms.pprofServer, ms.pprofListenAddr)
+	}
+
+	return nil
+}
+
+func (ms *MetricsService) serve(name string, server HTTPServerInterface, addr string) {
 	go func() {
 		defer func() {
 			ms.wg.Done()
 		}()
 
-		err := ms.server.ListenAndServe()
+		err := server.ListenAndServe()
 		if err != nil {
-			logrus.WithError(err).Fatalln("Metrics HTTP server failure")
+			logrus.WithError(err).Fatalf("%s HTTP server failure", name)
 		}
 
-		logrus.Infoln("Metrics HTTP server closed")
+		logrus.Infof("%s HTTP server closed", name)
 	}()
 
-	logrus.Infof("Metrics HTTP server listening at: %s", ms.listenAddr)
-
-	return nil
+	logrus.Infof("%s HTTP server listening at: %s", name, addr)
 }
 
 func (ms *MetricsService) prepareServer() error {
@@ -116,6 +163,13 @@ func (ms *MetricsService) prepareServer() error {
 
 	handler := http.NewServeMux()
 	handler.Handle("/metrics", promhttp.HandlerFor(ms.registry, promhttp.HandlerOpts{}))
+	handler.HandleFunc("/healthz", ms.handleHealthz)
+	handler.HandleFunc("/readyz", ms.handleReadyz)
+	handler.HandleFunc("/status", ms.handleStatus)
+
+	if ms.pprofListenAddr == "" && !ms.pprofDisabled {
+		registerPprofHandlers(handler)
+	}
 
 	if ms.server == nil {
 		ms.server = &HTTPServer{
@@ -123,18 +177,59 @@ func (ms *MetricsService) prepareServer() error {
 		}
 	}
 
-	ms.server.SetContext(ms.ctx)
-	ms.server.SetAddr(ms.listenAddr)
-	ms.server.SetHandler(handler)
+	return ms.configureServer(ms.server, ms.listenAddr, handler)
+}
+
+func (ms *MetricsService) preparePprofServer() error {
+	_, _, err := net.SplitHostPort(ms.pprofListenAddr)
+	if err != nil {
+		return fmt.Errorf("invalid debug server address: %s", err.Error())
+	}
+
+	handler := http.NewServeMux()
+	registerPprofHandlers(handler)
+
+	if ms.pprofServer == nil {
+		ms.pprofServer = &HTTPServer{
+			s: &http.Server{},
+		}
+	}
+
+	return ms.configureServer(ms.pprofServer, ms.pprofListenAddr, handler)
+}
+
+func (ms *MetricsService) configureServer(server HTTPServerInterface, addr string, handler http.Handler) error {
+	if ms.webConfig != nil {
+		tlsConfig, err := ms.webConfig.TLSConfig()
+		if err != nil {
+			return fmt.Errorf("invalid TLS configuration: %v", err)
+		}
+
+		if tlsConfig != nil {
+			server.SetTLSConfig(tlsConfig)
+		}
+	}
+
+	server.SetContext(ms.ctx)
+	server.SetAddr(addr)
+	server.SetHandler(withAuthentication(ms.webConfig, handler))
 
 	return nil
 }
 
+func registerPprofHandlers(handler *http.ServeMux) {
+	handler.HandleFunc("/debug/pprof/", pprof.Index)
+	handler.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	handler.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	handler.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	handler.HandleFunc("/debug/pprof/trace", pprof.Trace)
+}
+
 func (ms *MetricsService) RegisterDefaultCollectors() {
 	ms.initializeRegistry()
 
 	ms.MustRegisterPrometheusCollector(prometheus.NewGoCollector())
-	ms.MustRegisterPrometheusCollector(prometheus.NewProcessCollector(os.Getpid(), ""))
+	ms.MustRegisterPrometheusCollector(prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}))
 }
 
 func (ms *MetricsService) MustRegisterPrometheusCollector(cs ...prometheus.Collector) {
@@ -3,12 +3,19 @@ package services
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
 	"strconv"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -195,3 +202,182 @@ func TestMetricsService_RegisterDefaultCollectors(t *testing.T) {
 
 	r.AssertExpectations(t)
 }
+
+func TestMetricsService_StartServer_pprofHandlers(t *testing.T) {
+	examples := map[string]struct {
+		disablePprof bool
+		expectedCode int
+	}{
+		"pprof-enabled":  {disablePprof: false, expectedCode: http.StatusOK},
+		"pprof-disabled": {disablePprof: true, expectedCode: http.StatusNotFound},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			tests.RunOnHijackedLogrusOutput(t, func(t *testing.T, output *bytes.Buffer) {
+				var handler http.Handler
+
+				s := &MockHTTPServerInterface{}
+				s.On("SetContext", mock.Anything).Once()
+				s.On("SetAddr", ":1234").Once()
+				s.On("SetHandler", mock.Anything).Run(func(args mock.Arguments) {
+					handler = args.Get(0).(http.Handler)
+				}).Once()
+				s.On("ListenAndServe").Return(nil).Once()
+				defer s.AssertExpectations(t)
+
+				wg := &sync.WaitGroup{}
+				wg.Add(1)
+
+				ms := NewMetricsService(context.Background(), ":1234", wg)
+				ms.server = s
+				ms.registry = prometheus.NewRegistry()
+				if example.disablePprof {
+					ms.DisablePprof()
+				}
+
+				require.NoError(t, ms.StartServer())
+				wg.Wait()
+
+				rw := httptest.NewRecorder()
+				handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil))
+				assert.Equal(t, example.expectedCode, rw.Code)
+			})
+		})
+	}
+}
+
+func TestMetricsService_StartServer_separatePprofListener(t *testing.T) {
+	tests.RunOnHijackedLogrusOutput(t, func(t *testing.T, output *bytes.Buffer) {
+		var metricsHandler, pprofHandler http.Handler
+
+		s := &MockHTTPServerInterface{}
+		s.On("SetContext", mock.Anything).Once()
+		s.On("SetAddr", ":1234").Once()
+		s.On("SetHandler", mock.Anything).Run(func(args mock.Arguments) {
+			metricsHandler = args.Get(0).(http.Handler)
+		}).Once()
+		s.On("ListenAndServe").Return(nil).Once()
+		defer s.AssertExpectations(t)
+
+		ps := &MockHTTPServerInterface{}
+		ps.On("SetContext", mock.Anything).Once()
+		ps.On("SetAddr", ":1235").Once()
+		ps.On("SetHandler", mock.Anything).Run(func(args mock.Arguments) {
+			pprofHandler = args.Get(0).(http.Handler)
+		}).Once()
+		ps.On("ListenAndServe").Return(nil).Once()
+		defer ps.AssertExpectations(t)
+
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+
+		ms := NewMetricsService(context.Background(), ":1234", wg)
+		ms.server = s
+		ms.pprofServer = ps
+		ms.registry = prometheus.NewRegistry()
+		ms.SetPprofListenAddr(":1235")
+
+		require.NoError(t, ms.StartServer())
+		wg.Wait()
+
+		rw := httptest.NewRecorder()
+		metricsHandler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil))
+		assert.Equal(t, http.StatusNotFound, rw.Code)
+
+		rw = httptest.NewRecorder()
+		pprofHandler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, "/debug/pprof/", nil))
+		assert.Equal(t, http.StatusOK, rw.Code)
+
+		assert.Contains(t, output.String(), "Debug HTTP server listening at: :1235")
+		assert.Contains(t, output.String(), "Metrics HTTP server listening at: :1234")
+	})
+}
+
+func TestMetricsService_StartServer_invalidPprofListenAddr(t *testing.T) {
+	s := &MockHTTPServerInterface{}
+	s.On("SetContext", mock.Anything).Once()
+	s.On("SetAddr", ":1234").Once()
+	s.On("SetHandler", mock.Anything).Once()
+	defer s.AssertExpectations(t)
+
+	ms := NewMetricsService(context.Background(), ":1234", &sync.WaitGroup{})
+	ms.server = s
+	ms.SetPprofListenAddr("1.2.3.4")
+
+	err := ms.StartServer()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid debug server address")
+	s.AssertNotCalled(t, "ListenAndServe")
+}
+
+func getFreeListenAddr(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	return listener.Addr().String()
+}
+
+func TestMetricsService_StartServer_TLSWithAuthentication(t *testing.T) {
+	tests.RunOnTempDir(t, "metrics-service-tls", func(t *testing.T, dir string) {
+		certFile, keyFile := writeCertificate(t, dir, "127.0.0.1")
+
+		ctx, cancelFn := context.WithCancel(context.Background())
+		wg := &sync.WaitGroup{}
+		wg.Add(1)
+
+		listenAddr := getFreeListenAddr(t)
+
+		ms := NewMetricsService(ctx, listenAddr, wg)
+		ms.registry = prometheus.NewRegistry()
+		ms.SetWebConfig(&WebConfig{
+			TLSServerConfig: &TLSServerConfig{CertFile: certFile, KeyFile: keyFile},
+			BearerTokens:    []string{"fake-token"},
+		})
+		require.NoError(t, ms.StartServer())
+
+		defer func() {
+			cancelFn()
+			wg.Wait()
+		}()
+
+		ca, err := ioutil.ReadFile(certFile)
+		require.NoError(t, err)
+		pool := x509.NewCertPool()
+		require.True(t, pool.AppendCertsFromPEM(ca))
+
+		client := &http.Client{
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{RootCAs: pool},
+			},
+		}
+
+		var response *http.Response
+		for i := 0; i < 50; i++ {
+			request, _ := http.NewRequest(http.MethodGet, "https://"+listenAddr+"/metrics", nil)
+			request.Header.Set("Authorization", "Bearer fake-token")
+
+			response, err = client.Do(request)
+			if err == nil {
+				break
+			}
+
+			time.Sleep(20 * time.Millisecond)
+		}
+		require.NoError(t, err)
+		response.Body.Close()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+		assert.NotNil(t, response.TLS)
+
+		response, err = client.Get("https://" + listenAddr + "/metrics")
+		require.NoError(t, err)
+		response.Body.Close()
+		assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+		response, err = client.Get("https://" + listenAddr + "/healthz")
+		require.NoError(t, err)
+		response.Body.Close()
+		assert.Equal(t, http.StatusOK, response.StatusCode)
+	})
+}
@@ -7,6 +7,7 @@ package services
 import context "context"
 import http "net/http"
 import mock "github.com/stretchr/testify/mock"
+import tls "crypto/tls"
 
 // MockHTTPServerInterface is an autogenerated mock type for the HTTPServerInterface type
 type MockHTTPServerInterface struct {
@@ -41,3 +42,8 @@ func (_m *MockHTTPServerInterface) SetContext(_a0 context.Context) {
 func (_m *MockHTTPServerInterface) SetHandler(_a0 http.Handler) {
 	_m.Called(_a0)
 }
+
+// SetTLSConfig provides a mock function with given fields: _a0
+func (_m *MockHTTPServerInterface) SetTLSConfig(_a0 *tls.Config) {
+	_m.Called(_a0)
+}
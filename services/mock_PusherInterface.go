@@ -0,0 +1,27 @@
+// Code generated by mockery v1.0.0
+
+// This comment works around https://github.com/vektra/mockery/issues/155
+
+package services
+
+import context "context"
+import mock "github.com/stretchr/testify/mock"
+
+// MockPusherInterface is an autogenerated mock type for the PusherInterface type
+type MockPusherInterface struct {
+	mock.Mock
+}
+
+// Push provides a mock function with given fields: ctx
+func (_m *MockPusherInterface) Push(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
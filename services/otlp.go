@@ -0,0 +1,400 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	collectormetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	resourcepb "go.opentelemetry.io/proto/otlp/resource/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/version"
+)
+
+const (
+	OTLPProtocolHTTP = "http/protobuf"
+	OTLPProtocolGRPC = "grpc"
+
+	otlpScopeName      = "gitlab.com/gitlab-org/ci-cd/gcp-exporter"
+	otlpProjectLabel   = "project"
+	otlpCloudProvider  = "gcp"
+	otlpHTTPMetricsURI = "/v1/metrics"
+)
+
+type otlpGRPCError struct {
+	err error
+}
+
+func (oge *otlpGRPCError) Error() string {
+	return oge.err.Error()
+}
+
+func (oge *otlpGRPCError) retryable() bool {
+	switch status.Code(oge.err) {
+	case codes.Unavailable, codes.ResourceExhausted, codes.DeadlineExceeded, codes.Aborted:
+		return true
+	}
+
+	return false
+}
+
+type otlpTarget struct {
+	endpoint  string
+	protocol  string
+	insecure  bool
+	startTime time.Time
+
+	httpClient *http.Client
+
+	grpcClient     collectormetricspb.MetricsServiceClient
+	grpcClientLock sync.Mutex
+}
+
+func (ot *otlpTarget) Name() string {
+	return "otlp"
+}
+
+func (ot *otlpTarget) KeepHistory() bool {
+	return true
+}
+
+// Encode returns the request message for gRPC, which marshals it itself,
+// and the marshaled request for HTTP
+func (ot *otlpTarget) Encode(families []*dto.MetricFamily, timestamp time.Time) (pushPayload, error) {
+	request := &collectormetricspb.ExportMetricsServiceRequest{
+		ResourceMetrics: convertToResourceMetrics(families, ot.startTime, timestamp),
+	}
+
+	if ot.protocol == OTLPProtocolGRPC {
+		return request, nil
+	}
+
+	return proto.Marshal(request)
+}
+
+func (ot *otlpTarget) Send(ctx context.Context, payload pushPayload) error {
+	if ot.protocol == OTLPProtocolGRPC {
+		return ot.sendGRPC(ctx, payload)
+	}
+
+	return ot.sendHTTP(ctx, payload)
+}
+
+func (ot *otlpTarget) sendHTTP(ctx context.Context, payload pushPayload) error {
+	data, err := payloadBytes(payload)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, ot.httpURL(), bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	request = request.WithContext(ctx)
+	request.Header.Set("Content-Type", "application/x-protobuf")
+
+	response, err := ot.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode/100 != 2 {
+		body, _ := ioutil.ReadAll(response.Body)
+		return &pushError{statusCode: response.StatusCode, message: strings.TrimSpace(string(body))}
+	}
+
+	return nil
+}
+
+func (ot *otlpTarget) httpURL() string {
+	url := ot.endpoint
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		scheme := "https://"
+		if ot.insecure {
+			scheme = "http://"
+		}
+
+		url = scheme + url
+	}
+
+	if strings.HasSuffix(url, otlpHTTPMetricsURI) {
+		return url
+	}
+
+	return strings.TrimSuffix(url, "/") + otlpHTTPMetricsURI
+}
+
+func (ot *otlpTarget) sendGRPC(ctx context.Context, payload pushPayload) error {
+	request, ok := payload.(*collectormetricspb.ExportMetricsServiceRequest)
+	if !ok {
+		return fmt.Errorf("unexpected payload type %T", payload)
+	}
+
+	client, err := ot.getGRPCClient()
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Export(ctx, request)
+	if err != nil {
+		return &otlpGRPCError{err: err}
+	}
+
+	return nil
+}
+
+func (ot *otlpTarget) getGRPCClient() (collectormetricspb.MetricsServiceClient, error) {
+	ot.grpcClientLock.Lock()
+	defer ot.grpcClientLock.Unlock()
+
+	if ot.grpcClient != nil {
+		return ot.grpcClient, nil
+	}
+
+	transportCredentials := credentials.NewTLS(nil)
+	if ot.insecure {
+		transportCredentials = insecure.NewCredentials()
+	}
+
+	conn, err := grpc.NewClient(ot.endpoint, grpc.WithTransportCredentials(transportCredentials))
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create client for OTLP endpoint %q: %v", ot.endpoint, err)
+	}
+
+	ot.grpcClient = collectormetricspb.NewMetricsServiceClient(conn)
+
+	return ot.grpcClient, nil
+}
+
+func newOTLPTarget(endpoint string, protocol string, insecure bool) (*otlpTarget, error) {
+	if protocol != OTLPProtocolHTTP && protocol != OTLPProtocolGRPC {
+		return nil, fmt.Errorf("unknown OTLP protocol %q; must be one of: %s, %s", protocol, OTLPProtocolHTTP, OTLPProtocolGRPC)
+	}
+
+	ot := &otlpTarget{
+		endpoint:   endpoint,
+		protocol:   protocol,
+		insecure:   insecure,
+		startTime:  time.Now(),
+		httpClient: &http.Client{Timeout: DefaultPushTimeout},
+	}
+
+	return ot, nil
+}
+
+func NewOTLPPusher(endpoint string, protocol string, insecure bool, gatherer prometheus.Gatherer, maxRetries int, bufferSize int) (*Pusher, error) {
+	target, err := newOTLPTarget(endpoint, protocol, insecure)
+	if err != nil {
+		return nil, err
+	}
+
+	return newPusher(target, gatherer, maxRetries, bufferSize), nil
+}
+
+func convertToResourceMetrics(families []*dto.MetricFamily, startTime time.Time, timestamp time.Time) []*metricspb.ResourceMetrics {
+	byProject := make(map[string]map[string]*metricspb.Metric)
+	order := make(map[string][]string)
+
+	for _, family := range families {
+		for _, metric := range family.GetMetric() {
+			project, attributes := splitProjectLabel(metric.GetLabel())
+
+			if byProject[project] == nil {
+				byProject[project] = make(map[string]*metricspb.Metric)
+			}
+
+			otlpMetric, ok := byProject[project][family.GetName()]
+			if !ok {
+				otlpMetric = newOTLPMetric(family)
+				byProject[project][family.GetName()] = otlpMetric
+				order[project] = append(order[project], family.GetName())
+			}
+
+			addOTLPDataPoint(otlpMetric, family.GetType(), metric, attributes, startTime, timestamp)
+		}
+	}
+
+	projects := make([]string, 0, len(byProject))
+	for project := range byProject {
+		projects = append(projects, project)
+	}
+	sort.Strings(projects)
+
+	resourceMetrics := make([]*metricspb.ResourceMetrics, 0, len(projects))
+	for _, project := range projects {
+		metrics := make([]*metricspb.Metric, 0, len(order[project]))
+		for _, name := range order[project] {
+			metrics = append(metrics, byProject[project][name])
+		}
+
+		resourceMetrics = append(resourceMetrics, &metricspb.ResourceMetrics{
+			Resource: &resourcepb.Resource{Attributes: otlpResourceAttributes(project)},
+			ScopeMetrics: []*metricspb.ScopeMetrics{
+				{
+					Scope: &commonpb.InstrumentationScope{
+						Name:    otlpScopeName,
+						Version: version.AppVersion.Version,
+					},
+					Metrics: metrics,
+				},
+			},
+		})
+	}
+
+	return resourceMetrics
+}
+
+func otlpResourceAttributes(project string) []*commonpb.KeyValue {
+	attributes := []*commonpb.KeyValue{
+		otlpStringAttribute("service.name", version.AppVersion.Name),
+		otlpStringAttribute("service.version", version.AppVersion.Version),
+		otlpStringAttribute("cloud.provider", otlpCloudProvider),
+	}
+
+	if project != "" {
+		attributes = append(attributes, otlpStringAttribute("cloud.account.id", project))
+	}
+
+	return attributes
+}
+
+func otlpStringAttribute(key string, value string) *commonpb.KeyValue {
+	return &commonpb.KeyValue{
+		Key:   key,
+		Value: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+func splitProjectLabel(pairs []*dto.LabelPair) (string, []*commonpb.KeyValue) {
+	project := ""
+	attributes := make([]*commonpb.KeyValue, 0, len(pairs))
+
+	for _, pair := range pairs {
+		if pair.GetName() == otlpProjectLabel {
+			project = pair.GetValue()
+			continue
+		}
+
+		attributes = append(attributes, otlpStringAttribute(pair.GetName(), pair.GetValue()))
+	}
+
+	return project, attributes
+}
+
+func newOTLPMetric(family *dto.MetricFamily) *metricspb.Metric {
+	metric := &metricspb.Metric{
+		Name:        family.GetName(),
+		Description: family.GetHelp(),
+	}
+
+	switch family.GetType() {
+	case dto.MetricType_COUNTER:
+		metric.Data = &metricspb.Metric_Sum{Sum: &metricspb.Sum{
+			AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+			IsMonotonic:            true,
+		}}
+	case dto.MetricType_HISTOGRAM:
+		metric.Data = &metricspb.Metric_Histogram{Histogram: &metricspb.Histogram{
+			AggregationTemporality: metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE,
+		}}
+	case dto.MetricType_SUMMARY:
+		metric.Data = &metricspb.Metric_Summary{Summary: &metricspb.Summary{}}
+	default:
+		metric.Data = &metricspb.Metric_Gauge{Gauge: &metricspb.Gauge{}}
+	}
+
+	return metric
+}
+
+func addOTLPDataPoint(metric *metricspb.Metric, metricType dto.MetricType, m *dto.Metric, attributes []*commonpb.KeyValue, startTime time.Time, timestamp time.Time) {
+	timeUnixNano := uint64(timestamp.UnixNano())
+	if m.TimestampMs != nil {
+		timeUnixNano = uint64(m.GetTimestampMs()) * uint64(time.Millisecond)
+	}
+	startTimeUnixNano := uint64(startTime.UnixNano())
+
+	switch metricType {
+	case dto.MetricType_COUNTER:
+		sum := metric.GetSum()
+		sum.DataPoints = append(sum.DataPoints, &metricspb.NumberDataPoint{
+			Attributes:        attributes,
+			StartTimeUnixNano: startTimeUnixNano,
+			TimeUnixNano:      timeUnixNano,
+			Value:             &metricspb.NumberDataPoint_AsDouble{AsDouble: m.GetCounter().GetValue()},
+		})
+	case dto.MetricType_HISTOGRAM:
+		histogram := m.GetHistogram()
+		sum := histogram.GetSampleSum()
+
+		bounds := make([]float64, 0, len(histogram.GetBucket()))
+		counts := make([]uint64, 0, len(histogram.GetBucket())+1)
+		previous := uint64(0)
+		for _, bucket := range histogram.GetBucket() {
+			bounds = append(bounds, bucket.GetUpperBound())
+			counts = append(counts, bucket.GetCumulativeCount()-previous)
+			previous = bucket.GetCumulativeCount()
+		}
+		counts = append(counts, histogram.GetSampleCount()-previous)
+
+		h := metric.GetHistogram()
+		h.DataPoints = append(h.DataPoints, &metricspb.HistogramDataPoint{
+			Attributes:        attributes,
+			StartTimeUnixNano: startTimeUnixNano,
+			TimeUnixNano:      timeUnixNano,
+			Count:             histogram.GetSampleCount(),
+			Sum:               &sum,
+			ExplicitBounds:    bounds,
+			BucketCounts:      counts,
+		})
+	case dto.MetricType_SUMMARY:
+		summary := m.GetSummary()
+
+		quantiles := make([]*metricspb.SummaryDataPoint_ValueAtQuantile, 0, len(summary.GetQuantile()))
+		for _, quantile := range summary.GetQuantile() {
+			quantiles = append(quantiles, &metricspb.SummaryDataPoint_ValueAtQuantile{
+				Quantile: quantile.GetQuantile(),
+				Value:    quantile.GetValue(),
+			})
+		}
+
+		s := metric.GetSummary()
+		s.DataPoints = append(s.DataPoints, &metricspb.SummaryDataPoint{
+			Attributes:        attributes,
+			StartTimeUnixNano: startTimeUnixNano,
+			TimeUnixNano:      timeUnixNano,
+			Count:             summary.GetSampleCount(),
+			Sum:               summary.GetSampleSum(),
+			QuantileValues:    quantiles,
+		})
+	default:
+		value := m.GetGauge().GetValue()
+		if metricType == dto.MetricType_UNTYPED {
+			value = m.GetUntyped().GetValue()
+		}
+
+		gauge := metric.GetGauge()
+		gauge.DataPoints = append(gauge.DataPoints, &metricspb.NumberDataPoint{
+			Attributes:   attributes,
+			TimeUnixNano: timeUnixNano,
+			Value:        &metricspb.NumberDataPoint_AsDouble{AsDouble: value},
+		})
+	}
+}
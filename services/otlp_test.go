@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	collectormetricspb "go.opentelemetry.io/proto/otlp/collector/metrics/v1"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	metricspb "go.opentelemetry.io/proto/otlp/metrics/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type fakeMetricsServiceServer struct {
+	collectormetricspb.UnimplementedMetricsServiceServer
+
+	requests chan *collectormetricspb.ExportMetricsServiceRequest
+}
+
+func (s *fakeMetricsServiceServer) Export(ctx context.Context, request *collectormetricspb.ExportMetricsServiceRequest) (*collectormetricspb.ExportMetricsServiceResponse, error) {
+	s.requests <- request
+
+	return &collectormetricspb.ExportMetricsServiceResponse{}, nil
+}
+
+func newTestProjectRegistry(t *testing.T) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+
+	instances := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test_instances", Help: "Test instances"}, []string{"project", "zone"})
+	instances.WithLabelValues("project-1", "us-east1-c").Set(3)
+	instances.WithLabelValues("project-2", "us-east1-d").Set(5)
+	require.NoError(t, registry.Register(instances))
+
+	requests := prometheus.NewCounter(prometheus.CounterOpts{Name: "test_requests_total", Help: "Test requests"})
+	requests.Add(7)
+	require.NoError(t, registry.Register(requests))
+
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_histogram", Help: "Test histogram", Buckets: []float64{1}})
+	histogram.Observe(0.5)
+	histogram.Observe(2)
+	histogram.Observe(3)
+	require.NoError(t, registry.Register(histogram))
+
+	return registry
+}
+
+func attributesToMap(attributes []*commonpb.KeyValue) map[string]string {
+	result := make(map[string]string)
+	for _, attribute := range attributes {
+		result[attribute.GetKey()] = attribute.GetValue().GetStringValue()
+	}
+
+	return result
+}
+
+func TestConvertToResourceMetrics(t *testing.T) {
+	families, err := newTestProjectRegistry(t).Gather()
+	require.NoError(t, err)
+
+	startTime := time.Unix(1400000000, 0)
+	timestamp := time.Unix(1500000000, 0)
+
+	resourceMetrics := convertToResourceMetrics(families, startTime, timestamp)
+	require.Len(t, resourceMetrics, 3)
+
+	noProject := resourceMetrics[0]
+	noProjectAttributes := attributesToMap(noProject.GetResource().GetAttributes())
+	assert.NotContains(t, noProjectAttributes, "cloud.account.id")
+	assert.Equal(t, "gcp", noProjectAttributes["cloud.provider"])
+	assert.Contains(t, noProjectAttributes, "service.version")
+
+	metrics := noProject.GetScopeMetrics()[0].GetMetrics()
+	require.Len(t, metrics, 2)
+
+	histogram := metrics[0].GetHistogram()
+	require.NotNil(t, histogram)
+	assert.Equal(t, metricspb.AggregationTemporality_AGGREGATION_TEMPORALITY_CUMULATIVE, histogram.GetAggregationTemporality())
+	require.Len(t, histogram.GetDataPoints(), 1)
+	assert.Equal(t, []float64{1}, histogram.GetDataPoints()[0].GetExplicitBounds())
+	assert.Equal(t, []uint64{1, 2}, histogram.GetDataPoints()[0].GetBucketCounts())
+	assert.Equal(t, uint64(3), histogram.GetDataPoints()[0].GetCount())
+	assert.Equal(t, 5.5, histogram.GetDataPoints()[0].GetSum())
+
+	sum := metrics[1].GetSum()
+	require.NotNil(t, sum)
+	assert.True(t, sum.GetIsMonotonic())
+	assert.Equal(t, 7.0, sum.GetDataPoints()[0].GetAsDouble())
+	assert.Equal(t, uint64(startTime.UnixNano()), sum.GetDataPoints()[0].GetStartTimeUnixNano())
+	assert.Equal(t, uint64(timestamp.UnixNano()), sum.GetDataPoints()[0].GetTimeUnixNano())
+
+	for i, project := range []string{"project-1", "project-2"} {
+		rm := resourceMetrics[i+1]
+		assert.Equal(t, project, attributesToMap(rm.GetResource().GetAttributes())["cloud.account.id"])
+
+		metrics := rm.GetScopeMetrics()[0].GetMetrics()
+		require.Len(t, metrics, 1)
+		assert.Equal(t, "test_instances", metrics[0].GetName())
+		assert.Equal(t, "Test instances", metrics[0].GetDescription())
+
+		dataPoints := metrics[0].GetGauge().GetDataPoints()
+		require.Len(t, dataPoints, 1)
+		assert.NotContains(t, attributesToMap(dataPoints[0].GetAttributes()), "project")
+		assert.Contains(t, attributesToMap(dataPoints[0].GetAttributes()), "zone")
+	}
+}
+
+func TestNewOTLPPusher_unknownProtocol(t *testing.T) {
+	_, err := NewOTLPPusher("localhost:4317", "unknown", true, prometheus.NewRegistry(), 0, 1)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown OTLP protocol "unknown"`)
+}
+
+func TestOTLPTarget_httpURL(t *testing.T) {
+	examples := map[string]struct {
+		endpoint    string
+		insecure    bool
+		expectedURL string
+	}{
+		"host-only":          {endpoint: "collector:4318", expectedURL: "https://collector:4318/v1/metrics"},
+		"host-only-insecure": {endpoint: "collector:4318", insecure: true, expectedURL: "http://collector:4318/v1/metrics"},
+		"url-without-path":   {endpoint: "http://collector:4318/", expectedURL: "http://collector:4318/v1/metrics"},
+		"full-url":           {endpoint: "https://collector/otlp/v1/metrics", expectedURL: "https://collector/otlp/v1/metrics"},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			target, err := newOTLPTarget(example.endpoint, OTLPProtocolHTTP, example.insecure)
+			require.NoError(t, err)
+
+			assert.Equal(t, example.expectedURL, target.httpURL())
+		})
+	}
+}
+
+func TestOTLPPusher_Push_HTTP(t *testing.T) {
+	received := &collectormetricspb.ExportMetricsServiceRequest{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/metrics", r.URL.Path)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		require.NoError(t, proto.Unmarshal(body, received))
+
+		rw.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	pusher, err := NewOTLPPusher(server.URL, OTLPProtocolHTTP, false, newTestProjectRegistry(t), 0, 1)
+	require.NoError(t, err)
+
+	require.NoError(t, pusher.Push(context.Background()))
+	assert.Len(t, received.GetResourceMetrics(), 3)
+}
+
+func TestOTLPTarget_Encode(t *testing.T) {
+	families, err := newTestProjectRegistry(t).Gather()
+	require.NoError(t, err)
+
+	httpTarget, err := newOTLPTarget("localhost:4318", OTLPProtocolHTTP, true)
+	require.NoError(t, err)
+
+	payload, err := httpTarget.Encode(families, time.Now())
+	require.NoError(t, err)
+	assert.IsType(t, []byte{}, payload)
+
+	grpcTarget, err := newOTLPTarget("localhost:4317", OTLPProtocolGRPC, true)
+	require.NoError(t, err)
+
+	payload, err = grpcTarget.Encode(families, time.Now())
+	require.NoError(t, err)
+	require.IsType(t, &collectormetricspb.ExportMetricsServiceRequest{}, payload)
+	assert.Len(t, payload.(*collectormetricspb.ExportMetricsServiceRequest).GetResourceMetrics(), 3)
+}
+
+func TestOTLPPusher_Push_GRPC(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	fakeServer := &fakeMetricsServiceServer{
+		requests: make(chan *collectormetricspb.ExportMetricsServiceRequest, 1),
+	}
+
+	server := grpc.NewServer()
+	collectormetricspb.RegisterMetricsServiceServer(server, fakeServer)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	pusher, err := NewOTLPPusher(listener.Addr().String(), OTLPProtocolGRPC, true, newTestProjectRegistry(t), 0, 1)
+	require.NoError(t, err)
+
+	require.NoError(t, pusher.Push(context.Background()))
+
+	select {
+	case request := <-fakeServer.requests:
+		assert.Len(t, request.GetResourceMetrics(), 3)
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "OTLP request was not received")
+	}
+}
+
+func TestOTLPGRPCError_retryable(t *testing.T) {
+	assert.True(t, isRetryablePushError(&otlpGRPCError{err: status.Error(codes.Unavailable, "unavailable")}))
+	assert.False(t, isRetryablePushError(&otlpGRPCError{err: status.Error(codes.InvalidArgument, "invalid")}))
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+const (
+	DefaultPushMaxRetries     = 3
+	DefaultPushBufferSize     = 100
+	DefaultPushInitialBackoff = 1 * time.Second
+	DefaultPushTimeout        = 30 * time.Second
+)
+
+type PusherInterface interface {
+	Push(ctx context.Context) error
+}
+
+// pushPayload holds metrics encoded by the Encode method of a push target,
+// in the form expected by its Send method
+type pushPayload interface{}
+
+type pushTarget interface {
+	Name() string
+	KeepHistory() bool
+	Encode(families []*dto.MetricFamily, timestamp time.Time) (pushPayload, error)
+	Send(ctx context.Context, payload pushPayload) error
+}
+
+type retryableError interface {
+	retryable() bool
+}
+
+type pushError struct {
+	statusCode int
+	message    string
+}
+
+func (pe *pushError) Error() string {
+	return fmt.Sprintf("unexpected response status %d: %s", pe.statusCode, pe.message)
+}
+
+func (pe *pushError) retryable() bool {
+	return pe.statusCode == http.StatusTooManyRequests || pe.statusCode >= http.StatusInternalServerError
+}
+
+type Pusher struct {
+	target   pushTarget
+	gatherer prometheus.Gatherer
+
+	maxRetries     int
+	bufferSize     int
+	initialBackoff time.Duration
+
+	buffer []pushPayload
+
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func (p *Pusher) Push(ctx context.Context) error {
+	families, err := p.gatherer.Gather()
+	if err != nil {
+		return fmt.Errorf("error while gathering metrics for %s: %v", p.target.Name(), err)
+	}
+
+	payload, err := p.target.Encode(families, time.Now())
+	if err != nil {
+		return fmt.Errorf("error while encoding metrics for %s: %v", p.target.Name(), err)
+	}
+
+	p.enqueue(payload)
+
+	for len(p.buffer) > 0 {
+		err = p.send(ctx, p.buffer[0])
+		if err != nil {
+			if re, ok := err.(retryableError); ok && !re.retryable() {
+				logrus.WithError(err).WithField("target", p.target.Name()).Errorln("Dropping metrics rejected by push target")
+				p.buffer = p.buffer[1:]
+				continue
+			}
+
+			return fmt.Errorf("error while pushing metrics to %s (%d payloads buffered): %v", p.target.Name(), len(p.buffer), err)
+		}
+
+		p.buffer = p.buffer[1:]
+	}
+
+	return nil
+}
+
+func (p *Pusher) enqueue(payload pushPayload) {
+	if !p.target.KeepHistory() {
+		p.buffer = []pushPayload{payload}
+		return
+	}
+
+	p.buffer = append(p.buffer, payload)
+	if len(p.buffer) > p.bufferSize {
+		dropped := len(p.buffer) - p.bufferSize
+		logrus.WithField("target", p.target.Name()).Warningf("Push buffer full; dropping %d oldest payloads", dropped)
+		p.buffer = p.buffer[dropped:]
+	}
+}
+
+func (p *Pusher) send(ctx context.Context, payload pushPayload) error {
+	var err error
+	backoff := p.initialBackoff
+
+	for attempt := 0; ; attempt++ {
+		err = p.target.Send(ctx, payload)
+		if err == nil || !isRetryablePushError(err) || attempt >= p.maxRetries || ctx.Err() != nil {
+			return err
+		}
+
+		logrus.WithError(err).WithFields(logrus.Fields{
+			"target":  p.target.Name(),
+			"attempt": attempt + 1,
+			"delay":   backoff,
+		}).Warningln("Retrying metrics push")
+
+		sleepErr := p.sleep(ctx, backoff)
+		if sleepErr != nil {
+			return err
+		}
+
+		backoff *= 2
+	}
+}
+
+func payloadBytes(payload pushPayload) ([]byte, error) {
+	data, ok := payload.([]byte)
+	if !ok {
+		return nil, fmt.Errorf("unexpected payload type %T", payload)
+	}
+
+	return data, nil
+}
+
+func isRetryablePushError(err error) bool {
+	switch e := err.(type) {
+	case retryableError:
+		return e.retryable()
+	case net.Error:
+		return true
+	}
+
+	return false
+}
+
+func newPusher(target pushTarget, gatherer prometheus.Gatherer, maxRetries int, bufferSize int) *Pusher {
+	if bufferSize < 1 {
+		bufferSize = 1
+	}
+
+	return &Pusher{
+		target:         target,
+		gatherer:       gatherer,
+		maxRetries:     maxRetries,
+		bufferSize:     bufferSize,
+		initialBackoff: DefaultPushInitialBackoff,
+		buffer:         make([]pushPayload, 0),
+		sleep: func(ctx context.Context, d time.Duration) error {
+			select {
+			case <-time.After(d):
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		},
+	}
+}
+
+func NewPushgatewayPusher(url string, job string, gatherer prometheus.Gatherer, maxRetries int) *Pusher {
+	return newPusher(newPushgatewayTarget(url, job), gatherer, maxRetries, 1)
+}
+
+func NewRemoteWritePusher(url string, gatherer prometheus.Gatherer, maxRetries int, bufferSize int) *Pusher {
+	return newPusher(newRemoteWriteTarget(url), gatherer, maxRetries, bufferSize)
+}
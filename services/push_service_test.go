@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePushTarget struct {
+	keepHistory bool
+	encoded     int
+	sent        []string
+	errors      []error
+}
+
+func (fpt *fakePushTarget) Name() string {
+	return "fake"
+}
+
+func (fpt *fakePushTarget) KeepHistory() bool {
+	return fpt.keepHistory
+}
+
+func (fpt *fakePushTarget) Encode(families []*dto.MetricFamily, timestamp time.Time) (pushPayload, error) {
+	fpt.encoded++
+
+	return []byte{byte('0' + fpt.encoded)}, nil
+}
+
+func (fpt *fakePushTarget) Send(ctx context.Context, payload pushPayload) error {
+	if len(fpt.errors) > 0 {
+		err := fpt.errors[0]
+		fpt.errors = fpt.errors[1:]
+
+		if err != nil {
+			return err
+		}
+	}
+
+	fpt.sent = append(fpt.sent, string(payload.([]byte)))
+
+	return nil
+}
+
+func newTestRegistry(t *testing.T) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+
+	gauge := prometheus.NewGaugeVec(prometheus.GaugeOpts{Name: "test_gauge", Help: "Test gauge"}, []string{"zone"})
+	gauge.WithLabelValues("us-east1-c").Set(3)
+	require.NoError(t, registry.Register(gauge))
+
+	histogram := prometheus.NewHistogram(prometheus.HistogramOpts{Name: "test_histogram", Help: "Test histogram", Buckets: []float64{1}})
+	histogram.Observe(0.5)
+	histogram.Observe(2)
+	require.NoError(t, registry.Register(histogram))
+
+	return registry
+}
+
+func newTestPusher(t *testing.T, target pushTarget, maxRetries int, bufferSize int) (*Pusher, *[]time.Duration) {
+	sleeps := make([]time.Duration, 0)
+
+	pusher := newPusher(target, newTestRegistry(t), maxRetries, bufferSize)
+	pusher.sleep = func(ctx context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		return nil
+	}
+
+	return pusher, &sleeps
+}
+
+func TestPusher_Push_retries(t *testing.T) {
+	target := &fakePushTarget{
+		errors: []error{
+			&pushError{statusCode: http.StatusServiceUnavailable},
+			&pushError{statusCode: http.StatusTooManyRequests},
+		},
+	}
+
+	pusher, sleeps := newTestPusher(t, target, 3, 10)
+
+	assert.NoError(t, pusher.Push(context.Background()))
+	assert.Equal(t, []string{"1"}, target.sent)
+	assert.Equal(t, []time.Duration{DefaultPushInitialBackoff, 2 * DefaultPushInitialBackoff}, *sleeps)
+	assert.Empty(t, pusher.buffer)
+}
+
+func TestPusher_Push_bufferWhenTargetUnavailable(t *testing.T) {
+	unavailable := &pushError{statusCode: http.StatusServiceUnavailable}
+	target := &fakePushTarget{
+		keepHistory: true,
+		errors:      []error{unavailable, unavailable, unavailable, unavailable, unavailable, unavailable},
+	}
+
+	pusher, _ := newTestPusher(t, target, 0, 2)
+
+	for i := 0; i < 3; i++ {
+		err := pusher.Push(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "error while pushing metrics to fake")
+	}
+
+	assert.Equal(t, []pushPayload{[]byte("2"), []byte("3")}, pusher.buffer, "oldest payload should be dropped when buffer is full")
+
+	target.errors = nil
+	assert.NoError(t, pusher.Push(context.Background()))
+	assert.Equal(t, []string{"3", "4"}, target.sent)
+	assert.Empty(t, pusher.buffer)
+}
+
+func TestPusher_Push_keepsOnlyLatestWithoutHistory(t *testing.T) {
+	target := &fakePushTarget{
+		errors: []error{&pushError{statusCode: http.StatusBadGateway}},
+	}
+
+	pusher, _ := newTestPusher(t, target, 0, 10)
+
+	assert.Error(t, pusher.Push(context.Background()))
+	assert.NoError(t, pusher.Push(context.Background()))
+	assert.Equal(t, []string{"2"}, target.sent)
+}
+
+func TestPusher_Push_dropsRejectedPayloads(t *testing.T) {
+	target := &fakePushTarget{
+		keepHistory: true,
+		errors:      []error{&pushError{statusCode: http.StatusBadRequest}},
+	}
+
+	pusher, sleeps := newTestPusher(t, target, 3, 10)
+
+	assert.NoError(t, pusher.Push(context.Background()))
+	assert.Empty(t, target.sent)
+	assert.Empty(t, *sleeps)
+	assert.Empty(t, pusher.buffer)
+}
+
+func TestPusher_Push_notRetryableError(t *testing.T) {
+	target := &fakePushTarget{
+		errors: []error{errors.New("test-error")},
+	}
+
+	pusher, sleeps := newTestPusher(t, target, 3, 10)
+
+	err := pusher.Push(context.Background())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "test-error")
+	assert.Empty(t, *sleeps)
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+type pushgatewayTarget struct {
+	url    string
+	job    string
+	client *http.Client
+}
+
+func (pt *pushgatewayTarget) Name() string {
+	return "pushgateway"
+}
+
+func (pt *pushgatewayTarget) KeepHistory() bool {
+	return false
+}
+
+func (pt *pushgatewayTarget) Encode(families []*dto.MetricFamily, timestamp time.Time) (pushPayload, error) {
+	buf := new(bytes.Buffer)
+	enc := expfmt.NewEncoder(buf, expfmt.FmtProtoDelim)
+
+	for _, family := range families {
+		err := enc.Encode(family)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (pt *pushgatewayTarget) Send(ctx context.Context, payload pushPayload) error {
+	data, err := payloadBytes(payload)
+	if err != nil {
+		return err
+	}
+
+	pushURL := fmt.Sprintf("%s/metrics/job/%s", strings.TrimSuffix(pt.url, "/"), url.PathEscape(pt.job))
+
+	request, err := http.NewRequest(http.MethodPut, pushURL, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	request = request.WithContext(ctx)
+	request.Header.Set("Content-Type", string(expfmt.FmtProtoDelim))
+
+	response, err := pt.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusAccepted {
+		body, _ := ioutil.ReadAll(response.Body)
+		return &pushError{statusCode: response.StatusCode, message: strings.TrimSpace(string(body))}
+	}
+
+	return nil
+}
+
+func newPushgatewayTarget(url string, job string) *pushgatewayTarget {
+	return &pushgatewayTarget{
+		url:    url,
+		job:    job,
+		client: &http.Client{Timeout: DefaultPushTimeout},
+	}
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPushgatewayPusher_Push(t *testing.T) {
+	families := make(map[string]*dto.MetricFamily)
+	requests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests++
+
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, "/metrics/job/test-job", r.URL.Path)
+		assert.Equal(t, string(expfmt.FmtProtoDelim), r.Header.Get("Content-Type"))
+
+		decoder := expfmt.NewDecoder(r.Body, expfmt.FmtProtoDelim)
+		for {
+			family := &dto.MetricFamily{}
+			err := decoder.Decode(family)
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+
+			families[family.GetName()] = family
+		}
+
+		rw.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	pusher := NewPushgatewayPusher(server.URL+"/", "test-job", newTestRegistry(t), 0)
+
+	require.NoError(t, pusher.Push(context.Background()))
+	assert.Equal(t, 1, requests)
+
+	require.Contains(t, families, "test_gauge")
+	assert.Equal(t, 3.0, families["test_gauge"].GetMetric()[0].GetGauge().GetValue())
+	assert.Contains(t, families, "test_histogram")
+}
+
+func TestPushgatewayPusher_Push_failure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		http.Error(rw, "invalid metrics", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	target := newPushgatewayTarget(server.URL, "test-job")
+
+	err := target.Send(context.Background(), []byte{})
+	require.Error(t, err)
+	assert.Equal(t, "unexpected response status 400: invalid metrics", err.Error())
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang/snappy"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/protobuf/proto"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/services/prompb"
+)
+
+type remoteWriteLabel struct {
+	name  string
+	value string
+}
+
+type remoteWriteSeries struct {
+	labels    []remoteWriteLabel
+	value     float64
+	timestamp int64
+}
+
+type remoteWriteTarget struct {
+	url    string
+	client *http.Client
+}
+
+func (rwt *remoteWriteTarget) Name() string {
+	return "remote_write"
+}
+
+func (rwt *remoteWriteTarget) KeepHistory() bool {
+	return true
+}
+
+func (rwt *remoteWriteTarget) Encode(families []*dto.MetricFamily, timestamp time.Time) (pushPayload, error) {
+	series := convertMetricFamilies(families, timestamp.UnixNano()/int64(time.Millisecond))
+
+	data, err := proto.Marshal(newWriteRequest(series))
+	if err != nil {
+		return nil, err
+	}
+
+	return snappy.Encode(nil, data), nil
+}
+
+func (rwt *remoteWriteTarget) Send(ctx context.Context, payload pushPayload) error {
+	data, err := payloadBytes(payload)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, rwt.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+
+	request = request.WithContext(ctx)
+	request.Header.Set("Content-Type", "application/x-protobuf")
+	request.Header.Set("Content-Encoding", "snappy")
+	request.Header.Set("X-Prometheus-Remote-Write-Version", "0.1.0")
+
+	response, err := rwt.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode/100 != 2 {
+		body, _ := ioutil.ReadAll(response.Body)
+		return &pushError{statusCode: response.StatusCode, message: strings.TrimSpace(string(body))}
+	}
+
+	return nil
+}
+
+func newRemoteWriteTarget(url string) *remoteWriteTarget {
+	return &remoteWriteTarget{
+		url:    url,
+		client: &http.Client{Timeout: DefaultPushTimeout},
+	}
+}
+
+func convertMetricFamilies(families []*dto.MetricFamily, timestamp int64) []remoteWriteSeries {
+	series := make([]remoteWriteSeries, 0)
+
+	for _, family := range families {
+		for _, sample := range FlattenMetricFamily(family) {
+			ts := timestamp
+			if sample.Timestamp != nil {
+				ts = *sample.Timestamp
+			}
+
+			series = append(series, remoteWriteSeries{
+				labels:    buildRemoteWriteLabels(sample.Name, sample.Labels),
+				value:     sample.Value,
+				timestamp: ts,
+			})
+		}
+	}
+
+	return series
+}
+
+func buildRemoteWriteLabels(name string, sampleLabels map[string]string) []remoteWriteLabel {
+	labels := []remoteWriteLabel{{name: "__name__", value: name}}
+	for labelName, value := range sampleLabels {
+		labels = append(labels, remoteWriteLabel{name: labelName, value: value})
+	}
+
+	sort.Slice(labels, func(i, j int) bool {
+		return labels[i].name < labels[j].name
+	})
+
+	return labels
+}
+
+func newWriteRequest(series []remoteWriteSeries) *prompb.WriteRequest {
+	request := &prompb.WriteRequest{
+		Timeseries: make([]*prompb.TimeSeries, 0, len(series)),
+	}
+
+	for _, s := range series {
+		timeSeries := &prompb.TimeSeries{
+			Labels:  make([]*prompb.Label, 0, len(s.labels)),
+			Samples: []*prompb.Sample{{Value: s.value, Timestamp: s.timestamp}},
+		}
+
+		for _, label := range s.labels {
+			timeSeries.Labels = append(timeSeries.Labels, &prompb.Label{Name: label.name, Value: label.value})
+		}
+
+		request.Timeseries = append(request.Timeseries, timeSeries)
+	}
+
+	return request
+}
@@ -0,0 +1,99 @@
+package services
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/services/prompb"
+)
+
+func decodeWriteRequest(t *testing.T, buf []byte) []remoteWriteSeries {
+	request := &prompb.WriteRequest{}
+	require.NoError(t, proto.Unmarshal(buf, request))
+
+	series := make([]remoteWriteSeries, 0)
+	for _, timeSeries := range request.GetTimeseries() {
+		require.Len(t, timeSeries.GetSamples(), 1)
+
+		s := remoteWriteSeries{
+			value:     timeSeries.GetSamples()[0].GetValue(),
+			timestamp: timeSeries.GetSamples()[0].GetTimestamp(),
+		}
+
+		for _, label := range timeSeries.GetLabels() {
+			s.labels = append(s.labels, remoteWriteLabel{name: label.GetName(), value: label.GetValue()})
+		}
+
+		series = append(series, s)
+	}
+
+	return series
+}
+
+func TestRemoteWriteTarget_Encode(t *testing.T) {
+	families, err := newTestRegistry(t).Gather()
+	require.NoError(t, err)
+
+	timestamp := time.Unix(1500000000, 0)
+
+	payload, err := newRemoteWriteTarget("").Encode(families, timestamp)
+	require.NoError(t, err)
+
+	decoded, err := snappy.Decode(nil, payload.([]byte))
+	require.NoError(t, err)
+
+	ts := int64(1500000000000)
+	expectedSeries := []remoteWriteSeries{
+		{labels: []remoteWriteLabel{{"__name__", "test_gauge"}, {"zone", "us-east1-c"}}, value: 3, timestamp: ts},
+		{labels: []remoteWriteLabel{{"__name__", "test_histogram_bucket"}, {"le", "1"}}, value: 1, timestamp: ts},
+		{labels: []remoteWriteLabel{{"__name__", "test_histogram_bucket"}, {"le", "+Inf"}}, value: 2, timestamp: ts},
+		{labels: []remoteWriteLabel{{"__name__", "test_histogram_sum"}}, value: 2.5, timestamp: ts},
+		{labels: []remoteWriteLabel{{"__name__", "test_histogram_count"}}, value: 2, timestamp: ts},
+	}
+
+	assert.Equal(t, expectedSeries, decodeWriteRequest(t, decoded))
+}
+
+func TestRemoteWritePusher_Push(t *testing.T) {
+	requests := 0
+	var received []byte
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if requests == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/x-protobuf", r.Header.Get("Content-Type"))
+		assert.Equal(t, "snappy", r.Header.Get("Content-Encoding"))
+		assert.Equal(t, "0.1.0", r.Header.Get("X-Prometheus-Remote-Write-Version"))
+
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		received, err = snappy.Decode(nil, body)
+		assert.NoError(t, err)
+
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	pusher := NewRemoteWritePusher(server.URL, newTestRegistry(t), 1, 10)
+	pusher.initialBackoff = time.Millisecond
+
+	require.NoError(t, pusher.Push(context.Background()))
+	assert.Equal(t, 2, requests)
+	assert.Len(t, decodeWriteRequest(t, received), 5)
+}
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"strconv"
+
+	dto "github.com/prometheus/client_model/go"
+)
+
+// Sample is a single value of a metric family, with histogram and summary
+// metrics flattened into the _bucket, _sum and _count series used by the
+// Prometheus text format.
+type Sample struct {
+	Name      string            `json:"name"`
+	Labels    map[string]string `json:"labels"`
+	Value     float64           `json:"value"`
+	Timestamp *int64            `json:"timestamp_ms,omitempty"`
+}
+
+func FlattenMetricFamily(family *dto.MetricFamily) []Sample {
+	samples := make([]Sample, 0)
+	name := family.GetName()
+
+	for _, metric := range family.GetMetric() {
+		add := func(name string, value float64, extraName string, extraValue string) {
+			labels := make(map[string]string)
+			for _, pair := range metric.GetLabel() {
+				labels[pair.GetName()] = pair.GetValue()
+			}
+			if extraName != "" {
+				labels[extraName] = extraValue
+			}
+
+			samples = append(samples, Sample{Name: name, Labels: labels, Value: value, Timestamp: metric.TimestampMs})
+		}
+
+		switch family.GetType() {
+		case dto.MetricType_COUNTER:
+			add(name, metric.GetCounter().GetValue(), "", "")
+		case dto.MetricType_GAUGE:
+			add(name, metric.GetGauge().GetValue(), "", "")
+		case dto.MetricType_SUMMARY:
+			summary := metric.GetSummary()
+			for _, quantile := range summary.GetQuantile() {
+				add(name, quantile.GetValue(), "quantile", FormatSampleValue(quantile.GetQuantile()))
+			}
+			add(name+"_sum", summary.GetSampleSum(), "", "")
+			add(name+"_count", float64(summary.GetSampleCount()), "", "")
+		case dto.MetricType_HISTOGRAM:
+			histogram := metric.GetHistogram()
+			for _, bucket := range histogram.GetBucket() {
+				add(name+"_bucket", float64(bucket.GetCumulativeCount()), "le", FormatSampleValue(bucket.GetUpperBound()))
+			}
+			add(name+"_bucket", float64(histogram.GetSampleCount()), "le", "+Inf")
+			add(name+"_sum", histogram.GetSampleSum(), "", "")
+			add(name+"_count", float64(histogram.GetSampleCount()), "", "")
+		default:
+			add(name, metric.GetUntyped().GetValue(), "", "")
+		}
+	}
+
+	return samples
+}
+
+func FormatSampleValue(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,29 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlattenMetricFamily_summary(t *testing.T) {
+	registry := prometheus.NewRegistry()
+
+	summary := prometheus.NewSummary(prometheus.SummaryOpts{Name: "test_summary", Help: "Test summary", Objectives: map[float64]float64{0.5: 0.05}})
+	summary.Observe(2)
+	require.NoError(t, registry.Register(summary))
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	require.Len(t, families, 1)
+
+	expected := []Sample{
+		{Name: "test_summary", Labels: map[string]string{"quantile": "0.5"}, Value: 2},
+		{Name: "test_summary_sum", Labels: map[string]string{}, Value: 2},
+		{Name: "test_summary_count", Labels: map[string]string{}, Value: 1},
+	}
+
+	assert.Equal(t, expected, FlattenMetricFamily(families[0]))
+}
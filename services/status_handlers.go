@@ -0,0 +1,165 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors"
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/version"
+)
+
+var statusPageTpl = template.Must(template.New("status").Parse(`<!DOCTYPE html>
+<html>
+<head>
+  <title>{{.Version.Name}} status</title>
+  <style>
+    body { font-family: sans-serif; }
+    table { border-collapse: collapse; margin-bottom: 1em; }
+    th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+    .failed { color: #c00; }
+  </style>
+</head>
+<body>
+  <h1>{{.Version.Name}} {{.Version.Version}} ({{.Version.Revision}})</h1>
+  <p>Generated at: {{.GeneratedAt.Format "2006-01-02T15:04:05Z07:00"}}</p>
+
+  <h2>Readiness: {{if .Ready}}ready{{else}}<span class="failed">not ready</span>{{end}}</h2>
+  <table>
+    <tr><th>Check</th><th>Result</th></tr>
+    {{range .Checks}}<tr><td>{{.Name}}</td><td>{{if .Error}}<span class="failed">{{.Error}}</span>{{else}}ok{{end}}</td></tr>
+    {{end}}
+  </table>
+
+  <h2>Collectors</h2>
+  <table>
+    <tr><th>Name</th><th>Configuration</th><th>Targets</th><th>Last refresh</th><th>Last success</th><th>Errors</th><th>Last error</th></tr>
+    {{range .Collectors}}<tr>
+      <td>{{.Name}}</td>
+      <td>{{range $key, $value := .Configuration}}{{$key}}: {{$value}}<br>{{end}}</td>
+      <td>{{.Targets}}</td>
+      <td>{{if .LastRefresh.IsZero}}never{{else}}{{.LastRefresh.Format "2006-01-02T15:04:05Z07:00"}}{{end}}</td>
+      <td>{{if .LastSuccess.IsZero}}never{{else}}{{.LastSuccess.Format "2006-01-02T15:04:05Z07:00"}}{{end}}</td>
+      <td>{{.Errors}}</td>
+      <td class="failed">{{.LastError}}</td>
+    </tr>
+    {{end}}
+  </table>
+</body>
+</html>
+`))
+
+type ReadinessCheck func() error
+
+type StatusSourceInterface interface {
+	Status() []collectors.CollectorStatus
+}
+
+type readinessCheck struct {
+	name  string
+	check ReadinessCheck
+}
+
+type readinessCheckResult struct {
+	Name  string `json:"name"`
+	Error string `json:"error,omitempty"`
+}
+
+type statusPage struct {
+	Version     version.AppVersionInfo       `json:"version"`
+	GeneratedAt time.Time                    `json:"generated_at"`
+	Ready       bool                         `json:"ready"`
+	Checks      []readinessCheckResult       `json:"checks"`
+	Collectors  []collectors.CollectorStatus `json:"collectors"`
+}
+
+func (ms *MetricsService) AddReadinessCheck(name string, check ReadinessCheck) {
+	ms.readinessChecks = append(ms.readinessChecks, readinessCheck{name: name, check: check})
+}
+
+func (ms *MetricsService) SetStatusSource(source StatusSourceInterface) {
+	ms.statusSource = source
+}
+
+func (ms *MetricsService) runReadinessChecks() ([]readinessCheckResult, bool) {
+	ready := true
+	results := make([]readinessCheckResult, 0, len(ms.readinessChecks))
+
+	for _, rc := range ms.readinessChecks {
+		result := readinessCheckResult{Name: rc.name}
+
+		err := rc.check()
+		if err != nil {
+			result.Error = err.Error()
+			ready = false
+		}
+
+		results = append(results, result)
+	}
+
+	return results, ready
+}
+
+func (ms *MetricsService) handleHealthz(rw http.ResponseWriter, r *http.Request) {
+	rw.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintln(rw, "ok")
+}
+
+func (ms *MetricsService) handleReadyz(rw http.ResponseWriter, r *http.Request) {
+	results, ready := ms.runReadinessChecks()
+
+	rw.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if ready {
+		fmt.Fprintln(rw, "ok")
+		return
+	}
+
+	rw.WriteHeader(http.StatusServiceUnavailable)
+	for _, result := range results {
+		if result.Error != "" {
+			fmt.Fprintf(rw, "%s: %s\n", result.Name, result.Error)
+		}
+	}
+}
+
+func (ms *MetricsService) handleStatus(rw http.ResponseWriter, r *http.Request) {
+	results, ready := ms.runReadinessChecks()
+
+	page := statusPage{
+		Version:     version.AppVersion,
+		GeneratedAt: time.Now(),
+		Ready:       ready,
+		Checks:      results,
+		Collectors:  make([]collectors.CollectorStatus, 0),
+	}
+
+	if ms.statusSource != nil {
+		page.Collectors = ms.statusSource.Status()
+	}
+
+	var err error
+	if wantsJSON(r) {
+		rw.Header().Set("Content-Type", "application/json")
+		err = json.NewEncoder(rw).Encode(page)
+	} else {
+		rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+		err = statusPageTpl.Execute(rw, page)
+	}
+
+	if err != nil {
+		logrus.WithError(err).Errorln("Error while rendering status page")
+	}
+}
+
+func wantsJSON(r *http.Request) bool {
+	if r.URL.Query().Get("format") == "json" {
+		return true
+	}
+
+	return strings.Contains(r.Header.Get("Accept"), "application/json")
+}
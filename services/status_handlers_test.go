@@ -0,0 +1,118 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/collectors"
+)
+
+func TestMetricsService_handleHealthz(t *testing.T) {
+	ms := &MetricsService{}
+	ms.AddReadinessCheck("failing", func() error { return fmt.Errorf("fake-error") })
+
+	rw := httptest.NewRecorder()
+	ms.handleHealthz(rw, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Equal(t, "ok\n", rw.Body.String())
+}
+
+func TestMetricsService_handleReadyz(t *testing.T) {
+	examples := map[string]struct {
+		checkError     error
+		expectedCode   int
+		expectedOutput string
+	}{
+		"ready":     {checkError: nil, expectedCode: http.StatusOK, expectedOutput: "ok\n"},
+		"not-ready": {checkError: fmt.Errorf("fake-error"), expectedCode: http.StatusServiceUnavailable, expectedOutput: "second: fake-error\n"},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			ms := &MetricsService{}
+			ms.AddReadinessCheck("first", func() error { return nil })
+			ms.AddReadinessCheck("second", func() error { return example.checkError })
+
+			rw := httptest.NewRecorder()
+			ms.handleReadyz(rw, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			assert.Equal(t, example.expectedCode, rw.Code)
+			assert.Equal(t, example.expectedOutput, rw.Body.String())
+		})
+	}
+}
+
+func getStatusService() *MetricsService {
+	p := &collectors.MockProviderInterface{}
+	p.On("Status").Return([]collectors.CollectorStatus{
+		{
+			Name:          "fake-collector",
+			Configuration: map[string]string{"projects": "fake-project"},
+			Targets:       2,
+			LastRefresh:   time.Now(),
+			LastError:     "fake-collector-error",
+			Errors:        1,
+		},
+	})
+
+	ms := &MetricsService{}
+	ms.AddReadinessCheck("collectors", func() error { return fmt.Errorf("fake-error") })
+	ms.SetStatusSource(p)
+
+	return ms
+}
+
+func TestMetricsService_handleStatus_JSON(t *testing.T) {
+	examples := map[string]func(r *http.Request){
+		"format-param":  func(r *http.Request) { r.URL.RawQuery = "format=json" },
+		"accept-header": func(r *http.Request) { r.Header.Set("Accept", "application/json") },
+	}
+
+	for name, prepareRequest := range examples {
+		t.Run(name, func(t *testing.T) {
+			ms := getStatusService()
+
+			r := httptest.NewRequest(http.MethodGet, "/status", nil)
+			prepareRequest(r)
+
+			rw := httptest.NewRecorder()
+			ms.handleStatus(rw, r)
+
+			assert.Equal(t, http.StatusOK, rw.Code)
+			assert.Equal(t, "application/json", rw.Header().Get("Content-Type"))
+
+			var page statusPage
+			require.NoError(t, json.Unmarshal(rw.Body.Bytes(), &page))
+
+			assert.False(t, page.Ready)
+			require.Len(t, page.Checks, 1)
+			assert.Equal(t, "fake-error", page.Checks[0].Error)
+			require.Len(t, page.Collectors, 1)
+			assert.Equal(t, "fake-collector", page.Collectors[0].Name)
+			assert.Equal(t, 2, page.Collectors[0].Targets)
+			assert.Equal(t, "fake-collector-error", page.Collectors[0].LastError)
+		})
+	}
+}
+
+func TestMetricsService_handleStatus_HTML(t *testing.T) {
+	ms := getStatusService()
+
+	rw := httptest.NewRecorder()
+	ms.handleStatus(rw, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+	assert.Equal(t, http.StatusOK, rw.Code)
+	assert.Contains(t, rw.Header().Get("Content-Type"), "text/html")
+	assert.Contains(t, rw.Body.String(), "not ready")
+	assert.Contains(t, rw.Body.String(), "fake-collector")
+	assert.Contains(t, rw.Body.String(), "projects: fake-project")
+	assert.Contains(t, rw.Body.String(), "fake-collector-error")
+}
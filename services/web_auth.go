@@ -0,0 +1,71 @@
+package services
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Probe endpoints don't expose any data and must stay reachable for
+// kubelet and load balancer health checks.
+var unauthenticatedPaths = map[string]bool{
+	"/healthz": true,
+	"/readyz":  true,
+}
+
+type authHandler struct {
+	config  *WebConfig
+	handler http.Handler
+}
+
+func (ah *authHandler) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if unauthenticatedPaths[r.URL.Path] || ah.authorized(r) {
+		ah.handler.ServeHTTP(rw, r)
+		return
+	}
+
+	if len(ah.config.BasicAuthUsers) > 0 {
+		rw.Header().Set("WWW-Authenticate", `Basic realm="gcp-exporter"`)
+	}
+
+	http.Error(rw, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
+
+func (ah *authHandler) authorized(r *http.Request) bool {
+	user, password, ok := r.BasicAuth()
+	if ok {
+		hash, exists := ah.config.BasicAuthUsers[user]
+		if !exists {
+			return false
+		}
+
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+	}
+
+	authorization := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, "Bearer ") {
+		return false
+	}
+
+	token := []byte(strings.TrimPrefix(authorization, "Bearer "))
+	for _, expected := range ah.config.BearerTokens {
+		if subtle.ConstantTimeCompare(token, []byte(expected)) == 1 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func withAuthentication(config *WebConfig, handler http.Handler) http.Handler {
+	if config == nil || !config.AuthEnabled() {
+		return handler
+	}
+
+	return &authHandler{
+		config:  config,
+		handler: handler,
+	}
+}
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestWithAuthentication_disabled(t *testing.T) {
+	handler := http.NewServeMux()
+
+	assert.Equal(t, handler, withAuthentication(nil, handler))
+	assert.Equal(t, handler, withAuthentication(&WebConfig{}, handler))
+}
+
+func TestWithAuthentication(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("fake-password"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	config := &WebConfig{
+		BasicAuthUsers: map[string]string{"fake-user": string(hash)},
+		BearerTokens:   []string{"fake-token"},
+	}
+
+	examples := map[string]struct {
+		prepareRequest func(r *http.Request)
+		expectedCode   int
+	}{
+		"no-credentials": {
+			prepareRequest: func(r *http.Request) {},
+			expectedCode:   http.StatusUnauthorized,
+		},
+		"valid-basic-auth": {
+			prepareRequest: func(r *http.Request) { r.SetBasicAuth("fake-user", "fake-password") },
+			expectedCode:   http.StatusOK,
+		},
+		"invalid-password": {
+			prepareRequest: func(r *http.Request) { r.SetBasicAuth("fake-user", "invalid-password") },
+			expectedCode:   http.StatusUnauthorized,
+		},
+		"unknown-user": {
+			prepareRequest: func(r *http.Request) { r.SetBasicAuth("unknown-user", "fake-password") },
+			expectedCode:   http.StatusUnauthorized,
+		},
+		"valid-bearer-token": {
+			prepareRequest: func(r *http.Request) { r.Header.Set("Authorization", "Bearer fake-token") },
+			expectedCode:   http.StatusOK,
+		},
+		"invalid-bearer-token": {
+			prepareRequest: func(r *http.Request) { r.Header.Set("Authorization", "Bearer invalid-token") },
+			expectedCode:   http.StatusUnauthorized,
+		},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			handler := withAuthentication(config, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+			}))
+
+			r := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			example.prepareRequest(r)
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, r)
+
+			assert.Equal(t, example.expectedCode, rw.Code)
+			if example.expectedCode == http.StatusUnauthorized {
+				assert.Contains(t, rw.Header().Get("WWW-Authenticate"), "Basic")
+			}
+		})
+	}
+}
+
+func TestWithAuthentication_probeEndpoints(t *testing.T) {
+	config := &WebConfig{BearerTokens: []string{"fake-token"}}
+
+	for _, path := range []string{"/healthz", "/readyz"} {
+		t.Run(path, func(t *testing.T) {
+			handler := withAuthentication(config, http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+				rw.WriteHeader(http.StatusOK)
+			}))
+
+			rw := httptest.NewRecorder()
+			handler.ServeHTTP(rw, httptest.NewRequest(http.MethodGet, path, nil))
+
+			assert.Equal(t, http.StatusOK, rw.Code)
+		})
+	}
+}
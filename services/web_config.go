@@ -0,0 +1,193 @@
+package services
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+)
+
+var clientAuthTypes = map[string]tls.ClientAuthType{
+	"":                           tls.NoClientCert,
+	"NoClientCert":               tls.NoClientCert,
+	"RequestClientCert":          tls.RequestClientCert,
+	"RequireAnyClientCert":       tls.RequireAnyClientCert,
+	"VerifyClientCertIfGiven":    tls.VerifyClientCertIfGiven,
+	"RequireAndVerifyClientCert": tls.RequireAndVerifyClientCert,
+}
+
+type TLSServerConfig struct {
+	CertFile       string `yaml:"cert_file"`
+	KeyFile        string `yaml:"key_file"`
+	ClientAuthType string `yaml:"client_auth_type"`
+	ClientCAFile   string `yaml:"client_ca_file"`
+}
+
+type WebConfig struct {
+	TLSServerConfig *TLSServerConfig  `yaml:"tls_server_config"`
+	BasicAuthUsers  map[string]string `yaml:"basic_auth_users"`
+	BearerTokens    []string          `yaml:"bearer_tokens"`
+}
+
+func (wc *WebConfig) validate() error {
+	if wc.TLSServerConfig == nil {
+		return nil
+	}
+
+	tc := wc.TLSServerConfig
+	if tc.CertFile == "" || tc.KeyFile == "" {
+		return fmt.Errorf("both cert_file and key_file must be set in tls_server_config")
+	}
+
+	authType, ok := clientAuthTypes[tc.ClientAuthType]
+	if !ok {
+		return fmt.Errorf("unknown client_auth_type %q", tc.ClientAuthType)
+	}
+
+	if tc.ClientCAFile != "" && authType == tls.NoClientCert {
+		return fmt.Errorf("client_ca_file set, but client_auth_type is %q", tc.ClientAuthType)
+	}
+
+	if tc.ClientCAFile == "" && (authType == tls.VerifyClientCertIfGiven || authType == tls.RequireAndVerifyClientCert) {
+		return fmt.Errorf("client_auth_type %q requires client_ca_file to be set", tc.ClientAuthType)
+	}
+
+	return nil
+}
+
+func (wc *WebConfig) AuthEnabled() bool {
+	return len(wc.BasicAuthUsers) > 0 || len(wc.BearerTokens) > 0
+}
+
+func (wc *WebConfig) TLSConfig() (*tls.Config, error) {
+	if wc.TLSServerConfig == nil {
+		return nil, nil
+	}
+
+	tc := wc.TLSServerConfig
+
+	reloader := newCertificateReloader(tc.CertFile, tc.KeyFile)
+	_, err := reloader.GetCertificate(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		ClientAuth:     clientAuthTypes[tc.ClientAuthType],
+		GetCertificate: reloader.GetCertificate,
+	}
+
+	if tc.ClientCAFile != "" {
+		ca, err := ioutil.ReadFile(tc.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not read client CA file: %v", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("could not parse client CA file %s", tc.ClientCAFile)
+		}
+
+		config.ClientCAs = pool
+	}
+
+	return config, nil
+}
+
+func LoadWebConfig(path string) (*WebConfig, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read web config file: %v", err)
+	}
+
+	config := &WebConfig{}
+	err = yaml.UnmarshalStrict(data, config)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse web config file: %v", err)
+	}
+
+	err = config.validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid web config file: %v", err)
+	}
+
+	return config, nil
+}
+
+type certificateReloader struct {
+	certFile string
+	keyFile  string
+
+	certificate *tls.Certificate
+	certModTime time.Time
+	keyModTime  time.Time
+
+	lock sync.Mutex
+}
+
+func (cr *certificateReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cr.lock.Lock()
+	defer cr.lock.Unlock()
+
+	certModTime, keyModTime, err := cr.modTimes()
+	if err != nil {
+		return cr.fallback(err)
+	}
+
+	if cr.certificate != nil && certModTime.Equal(cr.certModTime) && keyModTime.Equal(cr.keyModTime) {
+		return cr.certificate, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(cr.certFile, cr.keyFile)
+	if err != nil {
+		return cr.fallback(fmt.Errorf("could not load TLS certificate: %v", err))
+	}
+
+	if cr.certificate != nil {
+		logrus.WithField("cert_file", cr.certFile).Infoln("Reloaded TLS certificate")
+	}
+
+	cr.certificate = &certificate
+	cr.certModTime = certModTime
+	cr.keyModTime = keyModTime
+
+	return cr.certificate, nil
+}
+
+func (cr *certificateReloader) modTimes() (time.Time, time.Time, error) {
+	certInfo, err := os.Stat(cr.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("could not stat TLS certificate file: %v", err)
+	}
+
+	keyInfo, err := os.Stat(cr.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("could not stat TLS key file: %v", err)
+	}
+
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
+
+func (cr *certificateReloader) fallback(err error) (*tls.Certificate, error) {
+	if cr.certificate == nil {
+		return nil, err
+	}
+
+	logrus.WithError(err).Warningln("Using previously loaded TLS certificate")
+
+	return cr.certificate, nil
+}
+
+func newCertificateReloader(certFile string, keyFile string) *certificateReloader {
+	return &certificateReloader{
+		certFile: certFile,
+		keyFile:  keyFile,
+	}
+}
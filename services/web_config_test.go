@@ -0,0 +1,211 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tests"
+)
+
+func writeCertificate(t *testing.T, dir string, commonName string) (string, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	require.NoError(t, ioutil.WriteFile(certFile, certPEM, 0600))
+	require.NoError(t, ioutil.WriteFile(keyFile, keyPEM, 0600))
+
+	return certFile, keyFile
+}
+
+func writeWebConfig(t *testing.T, dir string, content string) string {
+	file := filepath.Join(dir, "web-config.yml")
+	require.NoError(t, ioutil.WriteFile(file, []byte(content), 0600))
+
+	return file
+}
+
+func TestLoadWebConfig(t *testing.T) {
+	tests.RunOnTempDir(t, "web-config", func(t *testing.T, dir string) {
+		file := writeWebConfig(t, dir, `
+tls_server_config:
+  cert_file: /fake/cert.pem
+  key_file: /fake/key.pem
+  client_auth_type: RequireAndVerifyClientCert
+  client_ca_file: /fake/ca.pem
+basic_auth_users:
+  user: $2y$10$fake-hash
+bearer_tokens:
+  - fake-token
+`)
+
+		config, err := LoadWebConfig(file)
+		require.NoError(t, err)
+
+		require.NotNil(t, config.TLSServerConfig)
+		assert.Equal(t, "/fake/cert.pem", config.TLSServerConfig.CertFile)
+		assert.Equal(t, "/fake/key.pem", config.TLSServerConfig.KeyFile)
+		assert.Equal(t, "RequireAndVerifyClientCert", config.TLSServerConfig.ClientAuthType)
+		assert.Equal(t, "/fake/ca.pem", config.TLSServerConfig.ClientCAFile)
+		assert.Equal(t, "$2y$10$fake-hash", config.BasicAuthUsers["user"])
+		assert.Equal(t, []string{"fake-token"}, config.BearerTokens)
+		assert.True(t, config.AuthEnabled())
+	})
+}
+
+func TestLoadWebConfig_invalid(t *testing.T) {
+	examples := map[string]struct {
+		content       string
+		expectedError string
+	}{
+		"unknown-field": {
+			content:       "unknown_field: true",
+			expectedError: "could not parse web config file",
+		},
+		"missing-key-file": {
+			content:       "tls_server_config:\n  cert_file: /fake/cert.pem",
+			expectedError: "both cert_file and key_file must be set",
+		},
+		"unknown-client-auth-type": {
+			content:       "tls_server_config:\n  cert_file: /fake/cert.pem\n  key_file: /fake/key.pem\n  client_auth_type: Unknown",
+			expectedError: `unknown client_auth_type "Unknown"`,
+		},
+		"missing-client-ca-file": {
+			content:       "tls_server_config:\n  cert_file: /fake/cert.pem\n  key_file: /fake/key.pem\n  client_auth_type: RequireAndVerifyClientCert",
+			expectedError: "requires client_ca_file to be set",
+		},
+	}
+
+	for name, example := range examples {
+		t.Run(name, func(t *testing.T) {
+			tests.RunOnTempDir(t, "web-config", func(t *testing.T, dir string) {
+				_, err := LoadWebConfig(writeWebConfig(t, dir, example.content))
+
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), example.expectedError)
+			})
+		})
+	}
+}
+
+func TestLoadWebConfig_missingFile(t *testing.T) {
+	_, err := LoadWebConfig("non-existing-file")
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not read web config file")
+}
+
+func TestWebConfig_TLSConfig(t *testing.T) {
+	tests.RunOnTempDir(t, "web-config-tls", func(t *testing.T, dir string) {
+		certFile, keyFile := writeCertificate(t, dir, "fake-server")
+
+		config := &WebConfig{
+			TLSServerConfig: &TLSServerConfig{
+				CertFile:       certFile,
+				KeyFile:        keyFile,
+				ClientAuthType: "RequireAndVerifyClientCert",
+				ClientCAFile:   certFile,
+			},
+		}
+
+		tlsConfig, err := config.TLSConfig()
+		require.NoError(t, err)
+		require.NotNil(t, tlsConfig)
+
+		assert.Equal(t, tls.RequireAndVerifyClientCert, tlsConfig.ClientAuth)
+		assert.NotNil(t, tlsConfig.ClientCAs)
+
+		certificate, err := tlsConfig.GetCertificate(nil)
+		require.NoError(t, err)
+		assert.NotNil(t, certificate)
+	})
+}
+
+func TestWebConfig_TLSConfig_disabled(t *testing.T) {
+	config := &WebConfig{}
+
+	tlsConfig, err := config.TLSConfig()
+	assert.NoError(t, err)
+	assert.Nil(t, tlsConfig)
+}
+
+func TestWebConfig_TLSConfig_invalidCertificate(t *testing.T) {
+	config := &WebConfig{
+		TLSServerConfig: &TLSServerConfig{
+			CertFile: "non-existing-cert",
+			KeyFile:  "non-existing-key",
+		},
+	}
+
+	_, err := config.TLSConfig()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "could not stat TLS certificate file")
+}
+
+func TestCertificateReloader_GetCertificate(t *testing.T) {
+	tests.RunOnTempDir(t, "certificate-reloader", func(t *testing.T, dir string) {
+		certFile, keyFile := writeCertificate(t, dir, "first")
+
+		reloader := newCertificateReloader(certFile, keyFile)
+
+		first, err := reloader.GetCertificate(nil)
+		require.NoError(t, err)
+
+		same, err := reloader.GetCertificate(nil)
+		require.NoError(t, err)
+		assert.True(t, first == same, "certificate should not be reloaded when files didn't change")
+
+		writeCertificate(t, dir, "second")
+		changedAt := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(certFile, changedAt, changedAt))
+		require.NoError(t, os.Chtimes(keyFile, changedAt, changedAt))
+
+		second, err := reloader.GetCertificate(nil)
+		require.NoError(t, err)
+
+		parsed, err := x509.ParseCertificate(second.Certificate[0])
+		require.NoError(t, err)
+		assert.Equal(t, "second", parsed.Subject.CommonName)
+
+		require.NoError(t, os.Remove(certFile))
+
+		fallback, err := reloader.GetCertificate(nil)
+		assert.NoError(t, err)
+		assert.True(t, second == fallback, "previously loaded certificate should be used when files are unavailable")
+	})
+}
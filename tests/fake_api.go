@@ -0,0 +1,101 @@
+package tests
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAPIResponse struct {
+	statusCode int
+	body       []byte
+}
+
+type FakeAPIHandlerFunc func(r *http.Request) (int, interface{})
+
+type FakeAPI struct {
+	*httptest.Server
+
+	t         *testing.T
+	responses map[string]fakeAPIResponse
+	handlers  map[string]FakeAPIHandlerFunc
+	requests  []*http.Request
+	lock      sync.RWMutex
+}
+
+func (fa *FakeAPI) Handle(method string, path string, statusCode int, body interface{}) {
+	data, err := json.Marshal(body)
+	require.NoError(fa.t, err)
+
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+
+	fa.responses[method+" "+path] = fakeAPIResponse{statusCode: statusCode, body: data}
+}
+
+func (fa *FakeAPI) HandleFunc(method string, path string, handler FakeAPIHandlerFunc) {
+	fa.lock.Lock()
+	defer fa.lock.Unlock()
+
+	fa.handlers[method+" "+path] = handler
+}
+
+func (fa *FakeAPI) Requests() []*http.Request {
+	fa.lock.RLock()
+	defer fa.lock.RUnlock()
+
+	return fa.requests
+}
+
+func (fa *FakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	fa.lock.Lock()
+	fa.requests = append(fa.requests, r)
+	response, ok := fa.responses[r.Method+" "+r.URL.Path]
+	handler, handlerOk := fa.handlers[r.Method+" "+r.URL.Path]
+	fa.lock.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if handlerOk {
+		statusCode, body := handler(r)
+
+		// ServeHTTP runs on the server's goroutine, where FailNow can't be used
+		data, err := json.Marshal(body)
+		if !assert.NoError(fa.t, err) {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(statusCode)
+		w.Write(data)
+
+		return
+	}
+
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"error": {"code": 404, "message": "%s %s not found"}}`, r.Method, r.URL.Path)
+
+		return
+	}
+
+	w.WriteHeader(response.statusCode)
+	w.Write(response.body)
+}
+
+func NewFakeAPI(t *testing.T) *FakeAPI {
+	fa := &FakeAPI{
+		t:         t,
+		responses: make(map[string]fakeAPIResponse),
+		handlers:  make(map[string]FakeAPIHandlerFunc),
+	}
+	fa.Server = httptest.NewServer(fa)
+
+	return fa
+}
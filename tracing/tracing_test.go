@@ -0,0 +1,44 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"gitlab.com/gitlab-org/ci-cd/gcp-exporter/tests"
+)
+
+func TestStartEnd(t *testing.T) {
+	tests.RunWithSpanRecorder(t, func(t *testing.T, recorder *tracetest.SpanRecorder) {
+		ctx, parent := Start(context.Background(), "parent")
+
+		_, child := Start(ctx, "child", attribute.String("key", "value"))
+		End(child, errors.New("test-error"))
+		End(parent, nil)
+
+		spans := recorder.Ended()
+		require.Len(t, spans, 2)
+
+		assert.Equal(t, "child", spans[0].Name())
+		assert.Equal(t, parent.SpanContext().SpanID(), spans[0].Parent().SpanID())
+		assert.Equal(t, "value", tests.SpanAttributes(spans[0])["key"].AsString())
+		assert.Equal(t, codes.Error, spans[0].Status().Code)
+		assert.Equal(t, "test-error", spans[0].Status().Description)
+
+		assert.Equal(t, "parent", spans[1].Name())
+		assert.Equal(t, codes.Unset, spans[1].Status().Code)
+	})
+}
+
+func TestSetup_unknownProtocol(t *testing.T) {
+	_, err := Setup(context.Background(), Config{Endpoint: "localhost:4318", Protocol: "unknown"})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown protocol "unknown"`)
+}
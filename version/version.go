@@ -1,6 +1,7 @@
 package version
 
 import (
+	"encoding/json"
 	"fmt"
 	"runtime"
 	"time"
@@ -10,6 +11,26 @@ import (
 
 var AppVersion AppVersionInfo
 
+var startTime = time.Now()
+
+var (
+	buildInfoLabels = []string{"name", "version", "revision", "branch", "go_version", "built_at", "os", "architecture"}
+
+	buildInfo = prometheus.NewDesc(
+		"gcp_exporter_build_info",
+		"A metric with a constant '1' value labeled by different build stats fields.",
+		buildInfoLabels,
+		nil,
+	)
+
+	startTimeSeconds = prometheus.NewDesc(
+		"gcp_exporter_start_time_seconds",
+		"Start time of the exporter process since unix epoch in seconds.",
+		[]string{},
+		nil,
+	)
+)
+
 var (
 	NAME     = "gcp-exporter"
 	VERSION  = "dev"
@@ -19,14 +40,14 @@ var (
 )
 
 type AppVersionInfo struct {
-	Name         string
-	Version      string
-	Revision     string
-	Branch       string
-	GOVersion    string
-	BuiltAt      time.Time
-	OS           string
-	Architecture string
+	Name         string    `json:"name"`
+	Version      string    `json:"version"`
+	Revision     string    `json:"revision"`
+	Branch       string    `json:"branch"`
+	GOVersion    string    `json:"go_version"`
+	BuiltAt      time.Time `json:"built_at"`
+	OS           string    `json:"os"`
+	Architecture string    `json:"architecture"`
 }
 
 func (v *AppVersionInfo) UserAgent() string {
@@ -52,33 +73,52 @@ func (v *AppVersionInfo) Extended() string {
 	return version
 }
 
-func (v *AppVersionInfo) VersionCollector() *prometheus.GaugeVec {
-	labels := map[string]string{
-		"name":         v.Name,
-		"version":      v.Version,
-		"revision":     v.Revision,
-		"branch":       v.Branch,
-		"go_version":   v.GOVersion,
-		"built_at":     v.BuiltAt.String(),
-		"os":           v.OS,
-		"architecture": v.Architecture,
+func (v *AppVersionInfo) JSON() (string, error) {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("error while encoding version info: %v", err)
 	}
 
-	labelNames := make([]string, 0, len(labels))
-	for n := range labels {
-		labelNames = append(labelNames, n)
+	return string(data), nil
+}
+
+func (v *AppVersionInfo) VersionCollector() prometheus.Collector {
+	return &versionCollector{
+		info:      v,
+		startTime: startTime,
 	}
+}
+
+type versionCollector struct {
+	info      *AppVersionInfo
+	startTime time.Time
+}
+
+func (vc *versionCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- buildInfo
+	ch <- startTimeSeconds
+}
 
-	buildInfo := prometheus.NewGaugeVec(
-		prometheus.GaugeOpts{
-			Name: "hanging_droplets_cleaner_version_info",
-			Help: "A metric with a constant '1' value labeled by different build stats fields.",
-		},
-		labelNames,
+func (vc *versionCollector) Collect(ch chan<- prometheus.Metric) {
+	ch <- prometheus.MustNewConstMetric(
+		buildInfo,
+		prometheus.GaugeValue,
+		1,
+		vc.info.Name,
+		vc.info.Version,
+		vc.info.Revision,
+		vc.info.Branch,
+		vc.info.GOVersion,
+		vc.info.BuiltAt.Format(time.RFC3339),
+		vc.info.OS,
+		vc.info.Architecture,
 	)
-	buildInfo.With(labels).Set(1)
 
-	return buildInfo
+	ch <- prometheus.MustNewConstMetric(
+		startTimeSeconds,
+		prometheus.GaugeValue,
+		float64(vc.startTime.Unix()),
+	)
 }
 
 func NewAppVersionInfo(name, version, revision, branch, builtAt string) AppVersionInfo {
@@ -1,13 +1,17 @@
 package version
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 var currentTestTime = time.Now()
@@ -87,3 +91,56 @@ func TestNewAppVersionInfo(t *testing.T) {
 		})
 	}
 }
+
+func TestAppVersionInfo_JSON(t *testing.T) {
+	v := newAppVersion()
+
+	output, err := v.JSON()
+	require.NoError(t, err)
+
+	decoded := make(map[string]string)
+	require.NoError(t, json.Unmarshal([]byte(output), &decoded))
+
+	assert.Equal(t, "application", decoded["name"])
+	assert.Equal(t, "1.2.3", decoded["version"])
+	assert.Equal(t, "a1b2c3d4", decoded["revision"])
+	assert.Equal(t, "master", decoded["branch"])
+	assert.Equal(t, "1.9.2", decoded["go_version"])
+	assert.Equal(t, "linux", decoded["os"])
+	assert.Equal(t, "amd64", decoded["architecture"])
+}
+
+func TestAppVersionInfo_VersionCollector(t *testing.T) {
+	v := newAppVersion()
+	collector := v.VersionCollector()
+
+	descs := make(chan *prometheus.Desc, 10)
+	collector.Describe(descs)
+	close(descs)
+
+	require.Len(t, descs, 2)
+	assert.Contains(t, (<-descs).String(), `"gcp_exporter_build_info"`)
+	assert.Contains(t, (<-descs).String(), `"gcp_exporter_start_time_seconds"`)
+
+	metrics := make(chan prometheus.Metric, 10)
+	collector.Collect(metrics)
+	close(metrics)
+
+	require.Len(t, metrics, 2)
+
+	m := &dto.Metric{}
+	require.NoError(t, (<-metrics).Write(m))
+	assert.Equal(t, float64(1), m.GetGauge().GetValue())
+
+	labels := make(map[string]string)
+	for _, label := range m.GetLabel() {
+		labels[label.GetName()] = label.GetValue()
+	}
+	assert.Equal(t, "1.2.3", labels["version"])
+	assert.Equal(t, "a1b2c3d4", labels["revision"])
+	assert.Equal(t, currentTestTime.Format(time.RFC3339), labels["built_at"])
+
+	m = &dto.Metric{}
+	require.NoError(t, (<-metrics).Write(m))
+	assert.Equal(t, float64(startTime.Unix()), m.GetGauge().GetValue())
+}